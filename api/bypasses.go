@@ -0,0 +1,36 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/go-gitea/lgtm/store"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetBypasses gets the most recent commits that landed on the protected
+// branches of the repository without approval.
+func GetBypasses(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	page, _ := strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = 1
+	}
+	bypasses, err := store.GetBypassList(c, repo.ID, perPage, (page-1)*perPage)
+	if err != nil {
+		log.Errorf("Error getting bypasses for %s. %s", repo.Slug, err)
+		c.String(500, "Error getting bypasses. %s.", err)
+		return
+	}
+	c.JSON(200, bypasses)
+}
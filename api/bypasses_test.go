@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	store "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func TestBypasses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logrus.SetOutput(ioutil.Discard)
+
+	g := goblin.Goblin(t)
+
+	g.Describe("Bypass endpoint", func() {
+		g.It("Should return the bypasses", func() {
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeHookRepo, nil)
+			store.On("GetBypassList", int64(1), perPage, 0).Return([]*model.Bypass{
+				{ID: 1, RepoID: 1, Kind: model.BypassPush, Branch: "master", SHA: "6dcb09b"},
+			}, nil)
+
+			e := gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", store)
+			})
+			e.GET("/:owner/:repo/bypasses", GetBypasses)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/bypasses", nil)
+			e.ServeHTTP(w, r)
+
+			bypasses := []*model.Bypass{}
+			json.Unmarshal(w.Body.Bytes(), &bypasses)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(bypasses)).Equal(1)
+			g.Assert(bypasses[0].SHA).Equal("6dcb09b")
+		})
+	})
+}
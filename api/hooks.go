@@ -0,0 +1,95 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/queue"
+	"github.com/go-gitea/lgtm/store"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// perPage is the number of hook deliveries returned per page.
+const perPage = 50
+
+// GetHooks gets the most recent hook deliveries of the repository, along
+// with the result of processing them.
+func GetHooks(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	page, _ := strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = 1
+	}
+	deliveries, err := store.GetDeliveryList(c, repo.ID, perPage, (page-1)*perPage)
+	if err != nil {
+		log.Errorf("Error getting hook deliveries for %s. %s", repo.Slug, err)
+		c.String(500, "Error getting hook deliveries. %s.", err)
+		return
+	}
+	c.JSON(200, deliveries)
+}
+
+// PostHookReplay processes the pull request, push or merge of a hook
+// delivery again, which is recorded as a new delivery.
+func PostHookReplay(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	parent, err := store.GetDelivery(c, id)
+	if err != nil || parent.RepoID != repo.ID {
+		log.Errorf("Error getting hook delivery %s for %s. %v", c.Param("id"), repo.Slug, err)
+		c.String(404, "Hook delivery not found.")
+		return
+	}
+
+	// the author and base branch of the pull request, or the push or
+	// merge, are taken from the original job, if still available.
+	issue := &model.Issue{Number: parent.Number}
+	job, err := store.GetJob(c, parent.JobID)
+	if err == nil {
+		issue.Author = job.Author
+		issue.Base = job.Base
+	}
+
+	now := time.Now().Unix()
+	delivery := &model.Delivery{
+		Parent:  parent.ID,
+		Event:   parent.Event,
+		Created: now,
+		Updated: now,
+	}
+	switch {
+	case job != nil && job.Push != nil:
+		_, err = queue.EnqueuePush(c, repo, job.Push, delivery)
+	case job != nil && job.Merge != nil:
+		_, err = queue.EnqueueMerge(c, repo, job.Merge, delivery)
+	default:
+		_, err = queue.Enqueue(c, repo, issue, delivery)
+	}
+	if err != nil {
+		log.Errorf("Error queueing hook replay for %s pr %d. %s", repo.Slug, issue.Number, err)
+		c.String(500, "Error queueing hook replay. %s.", err)
+		return
+	}
+	c.JSON(202, delivery)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	store "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHooks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logrus.SetOutput(ioutil.Discard)
+
+	g := goblin.Goblin(t)
+
+	g.Describe("Hook endpoints", func() {
+		var e *gin.Engine
+		var s *store.Store
+
+		g.BeforeEach(func() {
+			s = new(store.Store)
+			s.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeHookRepo, nil)
+
+			e = gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", s)
+			})
+			e.GET("/:owner/:repo/hooks", GetHooks)
+			e.POST("/:owner/:repo/hooks/:id/replay", PostHookReplay)
+		})
+
+		g.It("Should return the hook deliveries", func() {
+			s.On("GetDeliveryList", int64(1), perPage, perPage).Return(fakeDeliveries, nil)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/hooks?page=2", nil)
+			e.ServeHTTP(w, r)
+
+			deliveries := []*model.Delivery{}
+			json.Unmarshal(w.Body.Bytes(), &deliveries)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(deliveries)).Equal(1)
+			g.Assert(deliveries[0].GUID).Equal("72d3162e")
+		})
+
+		g.It("Should replay a hook delivery", func() {
+			s.On("GetDelivery", int64(3)).Return(fakeDeliveries[0], nil)
+			s.On("GetJob", int64(2)).Return(&model.Job{ID: 2, Author: "octocat", Base: "master"}, nil)
+			s.On("GetJobPending", int64(1), 42).Return(nil, errors.New("not found"))
+			s.On("CreateJob", mock.MatchedBy(func(job *model.Job) bool {
+				return job.Author == "octocat" && job.Base == "master"
+			})).Return(nil)
+			s.On("CreateDelivery", mock.Anything).Return(nil)
+			s.On("GetJobRetrying", int64(1), mock.Anything).Return([]*model.Job{}, nil)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/octocat/hello-world/hooks/3/replay", nil)
+			e.ServeHTTP(w, r)
+
+			delivery := new(model.Delivery)
+			json.Unmarshal(w.Body.Bytes(), delivery)
+			g.Assert(w.Code).Equal(202)
+			g.Assert(delivery.Parent).Equal(int64(3))
+			g.Assert(delivery.Number).Equal(42)
+		})
+
+		g.It("Should not replay a hook delivery of another repository", func() {
+			s.On("GetDelivery", int64(4)).Return(&model.Delivery{ID: 4, RepoID: 2}, nil)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/octocat/hello-world/hooks/4/replay", nil)
+			e.ServeHTTP(w, r)
+			g.Assert(w.Code).Equal(404)
+		})
+	})
+}
+
+var (
+	fakeHookRepo   = &model.Repo{ID: 1, Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+	fakeDeliveries = []*model.Delivery{
+		{ID: 3, RepoID: 1, JobID: 2, GUID: "72d3162e", Event: "issue_comment", Number: 42},
+	}
+)
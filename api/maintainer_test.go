@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	remote "github.com/go-gitea/lgtm/remote/mock"
+	store "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMaintainer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logrus.SetOutput(ioutil.Discard)
+
+	g := goblin.Goblin(t)
+
+	g.Describe("Maintainer endpoint", func() {
+		var e *gin.Engine
+
+		g.BeforeEach(func() {
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeRepo, nil)
+
+			remote := new(remote.Remote)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, ".lgtm", "").Return([]byte(`team = "core"`), nil)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, "MAINTAINERS", "").Return([]byte(fakeMaintainers), nil)
+
+			e = gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", store)
+				c.Set("remote", remote)
+			})
+			e.GET("/:owner/:repo", GetMaintainer)
+			e.GET("/:owner/:repo/:org", GetMaintainerOrg)
+		})
+
+		g.It("Should narrow the maintainers to the configured team", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world", nil)
+			e.ServeHTTP(w, r)
+
+			maintainer := new(model.Maintainer)
+			json.Unmarshal(w.Body.Bytes(), maintainer)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(maintainer.People)).Equal(1)
+			g.Assert(maintainer.People["bradrydzewski"] != nil).IsTrue()
+			g.Assert(maintainer.Source).Equal("MAINTAINERS")
+		})
+
+		g.It("Should return the team subset", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/docs", nil)
+			e.ServeHTTP(w, r)
+
+			maintainer := new(model.Maintainer)
+			json.Unmarshal(w.Body.Bytes(), maintainer)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(maintainer.People)).Equal(1)
+			g.Assert(maintainer.People["octocat"] != nil).IsTrue()
+		})
+
+		g.It("Should fail when the team has no section", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/release", nil)
+			e.ServeHTTP(w, r)
+
+			g.Assert(w.Code).Equal(404)
+			g.Assert(w.Body.String()).Equal("Error getting subset of MAINTAINERS file. MAINTAINERS file has no [org.release] section.")
+		})
+
+		g.It("Should fail when the configured team has no section", func() {
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeRepo, nil)
+
+			remote := new(remote.Remote)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, ".lgtm", "").Return([]byte(`team = "release"`), nil)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, "MAINTAINERS", "").Return([]byte(fakeMaintainers), nil)
+
+			e := gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", store)
+				c.Set("remote", remote)
+			})
+			e.GET("/:owner/:repo", GetMaintainer)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world", nil)
+			e.ServeHTTP(w, r)
+
+			g.Assert(w.Code).Equal(404)
+		})
+
+		g.It("Should fail when the repository is not found", func() {
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(nil, errors.New("not found"))
+
+			e := gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", store)
+			})
+			e.GET("/:owner/:repo", GetMaintainer)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world", nil)
+			e.ServeHTTP(w, r)
+
+			g.Assert(w.Code).Equal(404)
+		})
+	})
+}
+
+var (
+	fakeRepo        = &model.Repo{Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+	fakeMaintainers = `
+[org.core]
+people = ["bradrydzewski"]
+
+[org.docs]
+people = ["octocat"]
+
+[people.bradrydzewski]
+login = "bradrydzewski"
+
+[people.octocat]
+login = "octocat"
+`
+)
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-gitea/lgtm/engine"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/queue"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/router/middleware/session"
+	"github.com/go-gitea/lgtm/store"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetPullExplain evaluates the approval policy of the pull request without
+// updating its status or labels, and explains whether each comment and
+// review counted towards the approval.
+func GetPullExplain(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	num, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.String(400, "Invalid pull request number %s.", c.Param("number"))
+		return
+	}
+	// the pull request is evaluated as the user acting on the repository,
+	// as when its status is set, since the viewer may lack the access
+	// required to evaluate the policy.
+	user, err := store.GetRepoUser(c, repo)
+	if err != nil {
+		log.Errorf("Error getting repository owner for %s. %s", repo.Slug, err)
+		c.String(404, "Repository owner not found.")
+		return
+	}
+	issue, err := remote.GetPull(c, user, repo, num)
+	if err != nil {
+		log.Errorf("Error getting pull request %s pr %d. %s", repo.Slug, num, err)
+		c.String(404, "Error getting pull request. %s.", err)
+		return
+	}
+	result, err := engine.Evaluate(c, user, repo, issue)
+	if _, ok := err.(*engine.TeamError); ok {
+		log.Errorf("Error getting maintainers for %s. %s", repo.Slug, err)
+		c.String(404, "Error getting maintainers. %s.", err)
+		return
+	}
+	if err != nil {
+		log.Errorf("Error evaluating %s pr %d. %s", repo.Slug, num, err)
+		c.String(500, "Error evaluating pull request. %s.", err)
+		return
+	}
+	c.JSON(200, result)
+}
+
+// PostPullSync recomputes the status of the pull request, for example
+// after the .lgtm or MAINTAINERS file changed.
+func PostPullSync(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+		user  = session.User(c)
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	num, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.String(400, "Invalid pull request number %s.", c.Param("number"))
+		return
+	}
+	issue, err := remote.GetPull(c, user, repo, num)
+	if err != nil {
+		log.Errorf("Error getting pull request %s pr %d. %s", repo.Slug, num, err)
+		c.String(404, "Error getting pull request. %s.", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	delivery := &model.Delivery{
+		Event:   "sync",
+		Created: now,
+		Updated: now,
+	}
+	_, err = queue.Enqueue(c, repo, issue, delivery)
+	if err != nil {
+		log.Errorf("Error queueing sync for %s pr %d. %s", repo.Slug, num, err)
+		c.String(500, "Error queueing sync. %s.", err)
+		return
+	}
+	c.JSON(202, delivery)
+}
+
+// PostSync recomputes the status of all open pull requests of the
+// repository.
+func PostSync(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+		user  = session.User(c)
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	deliveries, err := queue.EnqueueAll(c, user, repo, "", "sync")
+	if err != nil {
+		log.Errorf("Error queueing sync for %s. %s", repo.Slug, err)
+		c.String(500, "Error queueing sync. %s.", err)
+		return
+	}
+	c.JSON(202, deliveries)
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/engine"
+	"github.com/go-gitea/lgtm/model"
+
+	remote "github.com/go-gitea/lgtm/remote/mock"
+	store "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPulls(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logrus.SetOutput(ioutil.Discard)
+
+	g := goblin.Goblin(t)
+
+	g.Describe("Explain endpoint", func() {
+		var e *gin.Engine
+
+		// the pull request is evaluated as the owner of the repository,
+		// instead of the viewer.
+		var fakeRepo = &model.Repo{ID: 1, UserID: 1, Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+		var fakeViewer = &model.User{ID: 2, Login: "janedoe"}
+
+		g.BeforeEach(func() {
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeRepo, nil)
+			store.On("GetUser", int64(1)).Return(fakeUser, nil)
+
+			day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+			remote := new(remote.Remote)
+			remote.On("GetPull", mock.Anything, fakeUser, fakeRepo, 42).Return(&model.Issue{Number: 42, Author: "octocat", Base: "master"}, nil)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, ".lgtm", "master").Return([]byte(`approvals = 1`), nil)
+			remote.On("GetContents", mock.Anything, fakeUser, fakeRepo, "MAINTAINERS", "master").Return([]byte("bradrydzewski\noctocat"), nil)
+			remote.On("GetComments", mock.Anything, fakeUser, fakeRepo, 42).Return([]*model.Comment{
+				{Author: "janedoe", Body: "LGTM", Created: day},
+				{Author: "bradrydzewski", Body: "LGTM", Created: day.Add(time.Hour)},
+			}, nil)
+			remote.On("GetReviews", mock.Anything, fakeUser, fakeRepo, 42).Return([]*model.Review{}, nil)
+			remote.On("GetFiles", mock.Anything, fakeUser, fakeRepo, 42).Return([]string{"README.md"}, nil)
+
+			e = gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeViewer)
+				c.Set("store", store)
+				c.Set("remote", remote)
+			})
+			e.GET("/:owner/:repo/pulls/:number/explain", GetPullExplain)
+		})
+
+		g.It("Should explain the verdict of each comment", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/pulls/42/explain", nil)
+			e.ServeHTTP(w, r)
+
+			result := new(engine.Result)
+			json.Unmarshal(w.Body.Bytes(), result)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(result.Source).Equal("MAINTAINERS")
+			g.Assert(result.Config.Approvals).Equal(1)
+			g.Assert(result.Status.State).Equal(model.StatusSuccess)
+			g.Assert(len(result.Verdicts)).Equal(2)
+			g.Assert(result.Verdicts[0].Reason).Equal(engine.ReasonNotMaintainer)
+			g.Assert(result.Verdicts[1].Reason).Equal(engine.ReasonApproved)
+		})
+
+		g.It("Should reject an invalid pull request number", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/octocat/hello-world/pulls/abc/explain", nil)
+			e.ServeHTTP(w, r)
+			g.Assert(w.Code).Equal(400)
+		})
+	})
+
+	g.Describe("Sync endpoints", func() {
+		var e *gin.Engine
+		var s *store.Store
+
+		g.BeforeEach(func() {
+			s = new(store.Store)
+			s.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeHookRepo, nil)
+			s.On("GetJobPending", int64(1), mock.Anything).Return(nil, errors.New("not found"))
+			s.On("CreateJob", mock.Anything).Return(nil)
+			s.On("GetJobRetrying", int64(1), mock.Anything).Return([]*model.Job{}, nil)
+			s.On("CreateDelivery", mock.Anything).Return(nil)
+
+			remote := new(remote.Remote)
+			remote.On("GetPull", mock.Anything, fakeUser, fakeHookRepo, 42).Return(&model.Issue{Number: 42, Author: "octocat", Base: "master"}, nil)
+			remote.On("GetPulls", mock.Anything, fakeUser, fakeHookRepo).Return([]*model.Issue{
+				{Number: 41, Author: "octocat", Base: "master"},
+				{Number: 42, Author: "octocat", Base: "master"},
+			}, nil)
+
+			e = gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", s)
+				c.Set("remote", remote)
+			})
+			e.POST("/:owner/:repo/sync", PostSync)
+			e.POST("/:owner/:repo/pulls/:number/sync", PostPullSync)
+		})
+
+		g.It("Should queue the pull request", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/octocat/hello-world/pulls/42/sync", nil)
+			e.ServeHTTP(w, r)
+
+			delivery := new(model.Delivery)
+			json.Unmarshal(w.Body.Bytes(), delivery)
+			g.Assert(w.Code).Equal(202)
+			g.Assert(delivery.Event).Equal("sync")
+			g.Assert(delivery.Number).Equal(42)
+		})
+
+		g.It("Should queue all open pull requests", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/octocat/hello-world/sync", nil)
+			e.ServeHTTP(w, r)
+
+			deliveries := []*model.Delivery{}
+			json.Unmarshal(w.Body.Bytes(), &deliveries)
+			g.Assert(w.Code).Equal(202)
+			g.Assert(len(deliveries)).Equal(2)
+			g.Assert(deliveries[0].Number).Equal(41)
+			g.Assert(deliveries[1].Number).Equal(42)
+			s.AssertNumberOfCalls(t, "CreateJob", 2)
+		})
+	})
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gitea/lgtm/cache"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/router/middleware/session"
+	"github.com/go-gitea/lgtm/store"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// reportRange is the default time range of a report.
+const reportRange = 30 * 24 * time.Hour
+
+// reportEntry represents a pull request merged into a protected branch,
+// along with the approvals that were granted at the time of the merge.
+// A merge is pending if the approval status was not successful when the
+// pull request was merged.
+type reportEntry struct {
+	Repo      string            `json:"repo"`
+	Number    int               `json:"number"`
+	Title     string            `json:"title"`
+	Author    string            `json:"author"`
+	Base      string            `json:"base"`
+	SHA       string            `json:"sha"`
+	MergedBy  string            `json:"merged_by"`
+	MergedAt  time.Time         `json:"merged_at"`
+	Status    string            `json:"status"`
+	Pending   bool              `json:"pending"`
+	Approvals []*reportApproval `json:"approvals"`
+}
+
+// reportApproval represents an approval in the report.
+type reportApproval struct {
+	Login     string    `json:"login"`
+	SHA       string    `json:"sha"`
+	Source    string    `json:"source"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+// GetReport gets the compliance report of the pull requests merged into
+// the protected branches of the repository.
+func GetReport(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		name  = c.Param("repo")
+	)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
+	if err != nil {
+		log.Errorf("Error getting repository %s. %s", name, err)
+		c.AbortWithStatus(404)
+		return
+	}
+	since, until, err := reportTime(c)
+	if err != nil {
+		c.String(400, "Invalid report range. %s.", err)
+		return
+	}
+	entries, err := report(c, repo, since, until)
+	if err != nil {
+		log.Errorf("Error getting report for %s. %s", repo.Slug, err)
+		c.String(500, "Error getting report. %s.", err)
+		return
+	}
+	writeReport(c, entries)
+}
+
+// GetOrgReport gets the compliance report of all active repositories of
+// the organization the user has access to.
+func GetOrgReport(c *gin.Context) {
+	var (
+		owner = c.Param("owner")
+		user  = session.User(c)
+	)
+	// only the repositories of the remote of the user are reported,
+	// since their permissions are checked against that remote.
+	repos, err := store.GetRepoOwner(c, userHost(c), owner)
+	if err != nil {
+		log.Errorf("Error getting repositories of %s. %s", owner, err)
+		c.String(500, "Error getting repositories. %s.", err)
+		return
+	}
+	since, until, err := reportTime(c)
+	if err != nil {
+		c.String(400, "Invalid report range. %s.", err)
+		return
+	}
+
+	entries := []*reportEntry{}
+	for _, repo := range repos {
+		perm, err := cache.GetPerm(c, user, repo.Owner, repo.Name)
+		if err != nil || !perm.Pull {
+			continue
+		}
+		list, err := report(c, repo, since, until)
+		if err != nil {
+			log.Errorf("Error getting report for %s. %s", repo.Slug, err)
+			c.String(500, "Error getting report. %s.", err)
+			return
+		}
+		entries = append(entries, list...)
+	}
+	writeReport(c, entries)
+}
+
+// report is a helper function that returns the merges of the repository
+// into protected branches in the time range.
+func report(c context.Context, repo *model.Repo, since, until time.Time) ([]*reportEntry, error) {
+	merges, err := store.GetMergeList(c, repo.ID, since.Unix(), until.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*reportEntry{}
+	for _, merge := range merges {
+		if !merge.Protected {
+			continue
+		}
+		approvals, err := store.GetApprovalList(c, repo.ID, merge.Number)
+		if err != nil {
+			return nil, err
+		}
+		entry := &reportEntry{
+			Repo:      repo.Slug,
+			Number:    merge.Number,
+			Title:     merge.Title,
+			Author:    merge.Author,
+			Base:      merge.Base,
+			SHA:       merge.SHA,
+			MergedBy:  merge.MergedBy,
+			MergedAt:  time.Unix(merge.Merged, 0).UTC(),
+			Status:    merge.Status,
+			Pending:   merge.Status != model.StatusSuccess,
+			Approvals: []*reportApproval{},
+		}
+		// only the approvals granted and not yet revoked at the time of
+		// the merge are reported.
+		for _, approval := range approvals {
+			if approval.Granted > merge.Merged {
+				continue
+			}
+			if approval.Revoked != 0 && approval.Revoked <= merge.Merged {
+				continue
+			}
+			entry.Approvals = append(entry.Approvals, &reportApproval{
+				Login:     approval.Login,
+				SHA:       approval.SHA,
+				Source:    approval.Source,
+				GrantedAt: time.Unix(approval.Granted, 0).UTC(),
+			})
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// reportTime is a helper function that parses the time range of the
+// report from the since and until query parameters, which are dates or
+// RFC 3339 timestamps. A date includes the entire day.
+func reportTime(c *gin.Context) (since, until time.Time, err error) {
+	until = time.Now()
+	if v := c.Query("until"); len(v) != 0 {
+		if until, err = parseTime(v); err != nil {
+			return
+		}
+		if _, err := time.Parse("2006-01-02", v); err == nil {
+			until = until.Add(24 * time.Hour)
+		}
+	}
+	since = until.Add(-reportRange)
+	if v := c.Query("since"); len(v) != 0 {
+		if since, err = parseTime(v); err != nil {
+			return
+		}
+	}
+	if !since.Before(until) {
+		err = fmt.Errorf("since %s is not before until %s", since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+	return
+}
+
+// parseTime is a helper function that parses a date or RFC 3339 timestamp.
+func parseTime(v string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// writeReport is a helper function that writes the report in the format
+// of the format query parameter, which is json or csv.
+func writeReport(c *gin.Context, entries []*reportEntry) {
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(200, entries)
+	case "csv":
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="lgtm-report.csv"`)
+		c.Status(200)
+
+		w := csv.NewWriter(c.Writer)
+		w.Write([]string{"repo", "number", "title", "author", "base", "sha", "merged_by", "merged_at", "status", "pending", "approvers", "approval_shas"})
+		for _, entry := range entries {
+			var logins, shas []string
+			for _, approval := range entry.Approvals {
+				logins = append(logins, approval.Login)
+				shas = append(shas, approval.SHA)
+			}
+			record := []string{
+				entry.Repo,
+				strconv.Itoa(entry.Number),
+				entry.Title,
+				entry.Author,
+				entry.Base,
+				entry.SHA,
+				entry.MergedBy,
+				entry.MergedAt.Format(time.RFC3339),
+				entry.Status,
+				strconv.FormatBool(entry.Pending),
+				strings.Join(logins, ";"),
+				strings.Join(shas, ";"),
+			}
+			for i, cell := range record {
+				record[i] = csvCell(cell)
+			}
+			w.Write(record)
+		}
+		w.Flush()
+	default:
+		c.String(400, "Invalid report format %s.", c.Query("format"))
+	}
+}
+
+// csvCell is a helper function that escapes a cell starting with a
+// character that spreadsheet applications evaluate as a formula, since
+// titles and logins are controlled by the pull request authors.
+func csvCell(cell string) string {
+	if len(cell) != 0 && strings.ContainsAny(cell[:1], "=+-@\t\r") {
+		return "'" + cell
+	}
+	return cell
+}
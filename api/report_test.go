@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/cache"
+	"github.com/go-gitea/lgtm/model"
+
+	remote "github.com/go-gitea/lgtm/remote/mock"
+	store "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logrus.SetOutput(ioutil.Discard)
+
+	g := goblin.Goblin(t)
+
+	g.Describe("Report endpoints", func() {
+		var e *gin.Engine
+
+		g.BeforeEach(func() {
+			merged := fakeMergeTime.Unix()
+			private := &model.Repo{ID: 2, Owner: "octocat", Name: "private", Slug: "octocat/private"}
+
+			store := new(store.Store)
+			store.On("GetRepoSlug", "", "octocat/hello-world").Return(fakeHookRepo, nil)
+			store.On("GetRepoOwner", "", "octocat").Return([]*model.Repo{fakeHookRepo, private}, nil)
+			store.On("GetMergeList", int64(1), mock.Anything, mock.Anything).Return([]*model.Merge{
+				{RepoID: 1, Number: 41, Base: "feature", Status: model.StatusPending, Merged: merged},
+				{RepoID: 1, Number: 42, Title: "=HYPERLINK(\"http://example.com\")", Base: "master", SHA: "a1b2c3d", Status: model.StatusPending, Protected: true, Merged: merged},
+			}, nil)
+			store.On("GetApprovalList", int64(1), 42).Return([]*model.Approval{
+				{Login: "bradrydzewski", SHA: "6dcb09b", Source: model.ApprovalReview, Granted: merged - 60},
+				{Login: "mattnorris", SHA: "6dcb09b", Source: model.ApprovalComment, Granted: merged - 60, Revoked: merged - 30},
+				{Login: "octocat", SHA: "a1b2c3d", Source: model.ApprovalComment, Granted: merged + 60},
+			}, nil)
+
+			remote := new(remote.Remote)
+			remote.On("GetPerm", mock.Anything, fakeUser, "octocat", "hello-world").Return(&model.Perm{Pull: true}, nil)
+			remote.On("GetPerm", mock.Anything, fakeUser, "octocat", "private").Return(&model.Perm{}, nil)
+
+			e = gin.New()
+			e.Use(func(c *gin.Context) {
+				c.Set("user", fakeUser)
+				c.Set("store", store)
+				c.Set("remote", remote)
+				cache.ToContext(c, cache.NewTTL(time.Minute))
+			})
+			e.GET("/repos/:owner/:repo/report", GetReport)
+			e.GET("/orgs/:owner/report", GetOrgReport)
+		})
+
+		g.It("Should report the merges into protected branches", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/repos/octocat/hello-world/report?since=2020-06-01&until=2020-06-30", nil)
+			e.ServeHTTP(w, r)
+
+			entries := []*reportEntry{}
+			json.Unmarshal(w.Body.Bytes(), &entries)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(entries)).Equal(1)
+			g.Assert(entries[0].Number).Equal(42)
+			g.Assert(entries[0].Pending).IsTrue()
+			g.Assert(len(entries[0].Approvals)).Equal(1)
+			g.Assert(entries[0].Approvals[0].Login).Equal("bradrydzewski")
+			g.Assert(entries[0].Approvals[0].SHA).Equal("6dcb09b")
+		})
+
+		g.It("Should report in csv format", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/repos/octocat/hello-world/report?format=csv", nil)
+			e.ServeHTTP(w, r)
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			g.Assert(w.Code).Equal(200)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(records)).Equal(2)
+			g.Assert(records[1][0]).Equal("octocat/hello-world")
+			g.Assert(records[1][9]).Equal("true")
+			g.Assert(records[1][10]).Equal("bradrydzewski")
+		})
+
+		g.It("Should escape formulas in csv format", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/repos/octocat/hello-world/report?format=csv", nil)
+			e.ServeHTTP(w, r)
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			g.Assert(err == nil).IsTrue()
+			g.Assert(records[1][2]).Equal(`'=HYPERLINK("http://example.com")`)
+		})
+
+		g.It("Should reject an invalid range", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/repos/octocat/hello-world/report?since=2020-06-30&until=2020-06-01", nil)
+			e.ServeHTTP(w, r)
+			g.Assert(w.Code).Equal(400)
+		})
+
+		g.It("Should reject an invalid format", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/repos/octocat/hello-world/report?format=xml", nil)
+			e.ServeHTTP(w, r)
+			g.Assert(w.Code).Equal(400)
+		})
+
+		g.It("Should report the repositories of the org the user can access", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/orgs/octocat/report", nil)
+			e.ServeHTTP(w, r)
+
+			entries := []*reportEntry{}
+			json.Unmarshal(w.Body.Bytes(), &entries)
+			g.Assert(w.Code).Equal(200)
+			g.Assert(len(entries)).Equal(1)
+			g.Assert(entries[0].Repo).Equal("octocat/hello-world")
+		})
+	})
+}
+
+var fakeMergeTime = time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-gitea/lgtm/cache"
+	"github.com/go-gitea/lgtm/engine"
 	"github.com/go-gitea/lgtm/model"
 	"github.com/go-gitea/lgtm/remote"
 	"github.com/go-gitea/lgtm/router/middleware/session"
@@ -30,7 +31,7 @@ func GetRepos(c *gin.Context) {
 	repoc := make([]*model.Repo, len(repos))
 	copy(repoc, repos)
 
-	repom, err := store.GetRepoIntersectMap(c, repos)
+	repom, err := store.GetRepoIntersectMap(c, userHost(c), repos)
 	if err != nil {
 		logrus.Errorf("Error getting active repository list. %s", err)
 		c.String(500, "Error getting active repository list")
@@ -54,7 +55,7 @@ func GetRepo(c *gin.Context) {
 		owner = c.Param("owner")
 		name  = c.Param("repo")
 	)
-	repo, err := store.GetRepoOwnerName(c, owner, name)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
 	if err != nil {
 		logrus.Errorf("Error getting repository %s. %s", name, err)
 		c.String(404, "Error getting repository %s", name)
@@ -72,7 +73,7 @@ func PostRepo(c *gin.Context) {
 	)
 
 	// verify repo doesn't already exist
-	if _, err := store.GetRepoOwnerName(c, owner, name); err == nil {
+	if _, err := store.GetRepoOwnerName(c, userHost(c), owner, name); err == nil {
 		c.AbortWithStatus(409)
 		c.String(409, "Error activating a repository that is already active.")
 		return
@@ -85,6 +86,7 @@ func PostRepo(c *gin.Context) {
 	}
 	repo.UserID = user.ID
 	repo.Secret = model.Rand()
+	repo.Remote = remote.Host(c, user.Remote)
 
 	// creates a token to authorize the link callback url
 	t := token.New(token.HookToken, repo.Slug)
@@ -94,23 +96,44 @@ func PostRepo(c *gin.Context) {
 		return
 	}
 
-	// create the hook callback url
+	// create the hook callback url, which routes the payloads to the
+	// remote of the repository.
 	link := fmt.Sprintf(
 		"%s/hook?access_token=%s",
 		httputil.GetURL(c.Request),
 		sig,
 	)
+	if len(repo.Remote) != 0 {
+		link = fmt.Sprintf(
+			"%s/hook/%s?access_token=%s",
+			httputil.GetURL(c.Request),
+			repo.Remote,
+			sig,
+		)
+	}
 	err = remote.SetHook(c, user, repo, link)
 	if err != nil {
 		c.String(500, "Error creating hook. %s", err)
 		return
 	}
+	repo.Signed = remote.SignsHooks(c, repo)
 
 	err = store.CreateRepo(c, repo)
 	if err != nil {
 		c.String(500, "Error activating the repository. %s", err)
 		return
 	}
+
+	// creates the approval labels, which is not fatal since the
+	// labels are also created when first added to a pull request.
+	config, err := engine.GetConfig(c, user, repo, "")
+	if err != nil {
+		logrus.Errorf("Error parsing .lgtm file for %s. %s", repo.Slug, err)
+	} else if !config.DisableLabels {
+		if err := createLabels(c, user, repo, config); err != nil {
+			logrus.Errorf("Error creating labels for %s. %s", repo.Slug, err)
+		}
+	}
 	c.JSON(200, repo)
 }
 
@@ -121,7 +144,7 @@ func DeleteRepo(c *gin.Context) {
 		name  = c.Param("repo")
 		user  = session.User(c)
 	)
-	repo, err := store.GetRepoOwnerName(c, owner, name)
+	repo, err := store.GetRepoOwnerName(c, userHost(c), owner, name)
 	if err != nil {
 		logrus.Errorf("Error getting repository %s. %s", name, err)
 		c.AbortWithStatus(404)
@@ -143,3 +166,19 @@ func DeleteRepo(c *gin.Context) {
 	}
 	c.String(200, "")
 }
+
+// createLabels is a helper function that creates the approval labels
+// in the repository.
+func createLabels(c *gin.Context, user *model.User, repo *model.Repo, config *model.Config) error {
+	labels, err := config.Labels()
+	if err != nil {
+		return err
+	}
+	return remote.CreateLabels(c, user, repo, labels)
+}
+
+// userHost is a helper function that returns the host of the remote of
+// the current user, which identifies the repositories of the user.
+func userHost(c *gin.Context) string {
+	return remote.Host(c, session.User(c).Remote)
+}
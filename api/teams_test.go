@@ -26,7 +26,7 @@ func TestTeams(t *testing.T) {
 	g.Describe("Team endpoint", func() {
 		g.It("Should return the team list", func() {
 			cache := new(cache.Cache)
-			cache.On("Get", "teams:octocat").Return(fakeTeams, nil).Once()
+			cache.On("Get", "teams::octocat").Return(fakeTeams, nil).Once()
 
 			e := gin.New()
 			e.NoRoute(GetTeams)
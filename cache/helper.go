@@ -12,7 +12,8 @@ import (
 // GetRepos returns the list of user repositories from the cache
 // associated with the current context.
 func GetRepos(c context.Context, user *model.User) ([]*model.Repo, error) {
-	key := fmt.Sprintf("repos:%s",
+	key := fmt.Sprintf("repos:%s:%s",
+		remote.Host(c, user.Remote),
 		user.Login,
 	)
 	// if we fetch from the cache we can return immediately
@@ -33,7 +34,8 @@ func GetRepos(c context.Context, user *model.User) ([]*model.Repo, error) {
 // GetTeams returns the list of user teams from the cache
 // associated with the current context.
 func GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
-	key := fmt.Sprintf("teams:%s",
+	key := fmt.Sprintf("teams:%s:%s",
+		remote.Host(c, user.Remote),
 		user.Login,
 	)
 	// if we fetch from the cache we can return immediately
@@ -54,7 +56,8 @@ func GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
 // GetPerm returns the user permissions repositories from the cache
 // associated with the current repository.
 func GetPerm(c context.Context, user *model.User, owner, name string) (*model.Perm, error) {
-	key := fmt.Sprintf("perms:%s:%s/%s",
+	key := fmt.Sprintf("perms:%s:%s:%s/%s",
+		remote.Host(c, user.Remote),
 		user.Login,
 		owner,
 		name,
@@ -74,9 +77,12 @@ func GetPerm(c context.Context, user *model.User, owner, name string) (*model.Pe
 	return perm, nil
 }
 
-// GetMembers returns the team members from the cache.
-func GetMembers(c context.Context, user *model.User, team string) ([]*model.Member, error) {
-	key := fmt.Sprintf("members:%s",
+// GetMembers returns the org team members of the remote of the user from
+// the cache.
+func GetMembers(c context.Context, user *model.User, org, team string) ([]*model.Member, error) {
+	key := fmt.Sprintf("members:%s:%s/%s",
+		remote.Host(c, user.Remote),
+		org,
 		team,
 	)
 	// if we fetch from the cache we can return immediately
@@ -86,7 +92,7 @@ func GetMembers(c context.Context, user *model.User, team string) ([]*model.Memb
 	}
 	// else we try to grab from the remote system and
 	// populate our cache.
-	members, err := remote.GetMembers(c, user, team)
+	members, err := remote.GetMembers(c, user, org, team)
 	if err != nil {
 		return nil, err
 	}
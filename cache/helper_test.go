@@ -38,7 +38,8 @@ func TestHelper(t *testing.T) {
 		})
 
 		g.It("Should get permissions from cache", func() {
-			key := fmt.Sprintf("perms:%s:%s/%s",
+			key := fmt.Sprintf("perms:%s:%s:%s/%s",
+				fakeUser.Remote,
 				fakeUser.Login,
 				fakeRepo.Owner,
 				fakeRepo.Name,
@@ -67,7 +68,8 @@ func TestHelper(t *testing.T) {
 		})
 
 		g.It("Should get repos", func() {
-			key := fmt.Sprintf("repos:%s",
+			key := fmt.Sprintf("repos:%s:%s",
+				fakeUser.Remote,
 				fakeUser.Login,
 			)
 
@@ -93,7 +95,8 @@ func TestHelper(t *testing.T) {
 		})
 
 		g.It("Should get teams", func() {
-			key := fmt.Sprintf("teams:%s",
+			key := fmt.Sprintf("teams:%s:%s",
+				fakeUser.Remote,
 				fakeUser.Login,
 			)
 
@@ -112,25 +115,44 @@ func TestHelper(t *testing.T) {
 		})
 
 		g.It("Should set and get members", func() {
-			r.On("GetMembers", c, fakeUser, "drone").Return(fakeMembers, nil).Once()
-			p, err := GetMembers(c, fakeUser, "drone")
+			r.On("GetMembers", c, fakeUser, "drone", "maintainers").Return(fakeMembers, nil).Once()
+			p, err := GetMembers(c, fakeUser, "drone", "maintainers")
 			g.Assert(p).Equal(fakeMembers)
 			g.Assert(err).Equal(nil)
 		})
 
 		g.It("Should get members", func() {
-			key := "members:drone"
+			key := "members:github.com:drone/maintainers"
 
 			Set(c, key, fakeMembers)
-			r.On("GetMembers", c, fakeUser, "drone").Return(nil, errFake).Once()
-			p, err := GetMembers(c, fakeUser, "drone")
+			r.On("GetMembers", c, fakeUser, "drone", "maintainers").Return(nil, errFake).Once()
+			p, err := GetMembers(c, fakeUser, "drone", "maintainers")
 			g.Assert(p).Equal(fakeMembers)
 			g.Assert(err).Equal(nil)
 		})
 
+		g.It("Should get members per team", func() {
+			Set(c, "members:github.com:drone/maintainers", fakeMembers)
+			release := []*model.Member{{Login: "bradrydzewski"}}
+			r.On("GetMembers", c, fakeUser, "drone", "release").Return(release, nil).Once()
+			p, err := GetMembers(c, fakeUser, "drone", "release")
+			g.Assert(p).Equal(release)
+			g.Assert(err).Equal(nil)
+		})
+
+		g.It("Should get members per remote", func() {
+			Set(c, "members:github.com:drone/maintainers", fakeMembers)
+			user := &model.User{Login: "octocat", Remote: "github.example.com"}
+			members := []*model.Member{{Login: "bradrydzewski"}}
+			r.On("GetMembers", c, user, "drone", "maintainers").Return(members, nil).Once()
+			p, err := GetMembers(c, user, "drone", "maintainers")
+			g.Assert(p).Equal(members)
+			g.Assert(err).Equal(nil)
+		})
+
 		g.It("Should get member error", func() {
-			r.On("GetMembers", c, fakeUser, "drone").Return(nil, errFake).Once()
-			p, err := GetMembers(c, fakeUser, "drone")
+			r.On("GetMembers", c, fakeUser, "drone", "maintainers").Return(nil, errFake).Once()
+			p, err := GetMembers(c, fakeUser, "drone", "maintainers")
 			g.Assert(p == nil).IsTrue()
 			g.Assert(err).Equal(errFake)
 		})
@@ -139,7 +161,7 @@ func TestHelper(t *testing.T) {
 
 var (
 	errFake   = errors.New("Not Found")
-	fakeUser  = &model.User{Login: "octocat"}
+	fakeUser  = &model.User{Login: "octocat", Remote: "github.com"}
 	fakePerm  = &model.Perm{Pull: true, Push: true, Admin: true}
 	fakeRepo  = &model.Repo{Owner: "octocat", Name: "Hello-World"}
 	fakeRepos = []*model.Repo{
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	"golang.org/x/net/context"
+)
+
+// audit records the changes to the approver set of the pull request, so
+// that the approval history can be reported on. New approvals are
+// recorded along with the commit they were given for, and approvals that
+// no longer count are marked as revoked.
+func audit(c context.Context, user *model.User, repo *model.Repo, issue *model.Issue, result *Result) error {
+	active, err := store.GetApprovalActive(c, repo.ID, issue.Number)
+	if err != nil {
+		return err
+	}
+
+	granted := map[string]*Verdict{}
+	for _, verdict := range result.Verdicts {
+		if verdict.Reason == ReasonApproved {
+			granted[verdict.Author] = verdict
+		}
+	}
+
+	recorded := map[string]bool{}
+	for _, approval := range active {
+		verdict, ok := granted[approval.Login]
+		switch {
+		case ok && grantedBy(approval, verdict):
+			recorded[approval.Login] = true
+			continue
+		case ok:
+			// the approval is given again by another comment or review,
+			// which replaces the recorded approval.
+			approval.Revoked = verdict.Created.Unix()
+		default:
+			approval.Revoked = revokedAt(result, approval).Unix()
+		}
+		if err := store.UpdateApproval(c, approval); err != nil {
+			return err
+		}
+	}
+
+	head := result.Head
+	for _, approver := range result.Approvers {
+		if recorded[approver.Login] {
+			continue
+		}
+		verdict := granted[approver.Login]
+		approval := &model.Approval{
+			RepoID:   repo.ID,
+			Number:   issue.Number,
+			SHA:      verdict.Commit,
+			Login:    approver.Login,
+			Source:   verdict.Kind,
+			SourceID: verdict.ID,
+			Granted:  verdict.Created.Unix(),
+		}
+		// comments are not tied to a commit, in which case the approval
+		// is recorded for the head commit of the pull request.
+		if len(approval.SHA) == 0 {
+			if head == nil {
+				head, err = remote.GetHeadCommit(c, user, repo, issue.Number)
+				if err != nil {
+					return err
+				}
+			}
+			approval.SHA = head.SHA
+		}
+		if err := store.CreateApproval(c, approval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantedBy is a helper function that returns true if the recorded
+// approval was given by the comment or review of the verdict. Comments
+// are not tied to a commit, in which case the commit is not compared.
+func grantedBy(approval *model.Approval, verdict *Verdict) bool {
+	if approval.Source != verdict.Kind || approval.SourceID != verdict.ID {
+		return false
+	}
+	return len(verdict.Commit) == 0 || approval.SHA == verdict.Commit
+}
+
+// revokedAt is a helper function that returns the time of the revocation
+// or change request that cancelled the approval, or the current time if
+// the approval no longer counts for another reason, such as a new push.
+func revokedAt(result *Result, approval *model.Approval) time.Time {
+	for i := len(result.Verdicts) - 1; i >= 0; i-- {
+		verdict := result.Verdicts[i]
+		if verdict.Author != approval.Login || verdict.Created.Unix() < approval.Granted {
+			continue
+		}
+		switch verdict.Reason {
+		case ReasonRevoked, ReasonChangesRequested, ReasonDismissed:
+			return verdict.Created
+		}
+	}
+	return time.Now()
+}
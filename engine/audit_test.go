@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	remotes "github.com/go-gitea/lgtm/remote/mock"
+	stores "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAudit(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	issue := &model.Issue{Number: 42}
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	revoked := &model.Approval{ID: 1, RepoID: 1, Number: 42, Login: "bradrydzewski", Granted: day.Unix()}
+	kept := &model.Approval{ID: 2, RepoID: 1, Number: 42, SHA: "6dcb09b", Login: "octocat", Source: model.ApprovalComment, SourceID: 1, Granted: day.Unix()}
+	result := &Result{
+		Approvers: []*model.Person{{Login: "octocat"}, {Login: "mattnorris"}, {Login: "janedoe"}},
+		Verdicts: []*Verdict{
+			{Kind: "comment", ID: 1, Author: "octocat", Created: day, Verdict: VerdictCounted, Reason: ReasonApproved},
+			{Kind: "comment", ID: 2, Author: "bradrydzewski", Created: day.Add(time.Hour), Verdict: VerdictCounted, Reason: ReasonRevoked},
+			{Kind: "comment", ID: 3, Author: "mattnorris", Created: day.Add(time.Hour), Verdict: VerdictCounted, Reason: ReasonApproved},
+			{Kind: "review", ID: 4, Author: "janedoe", Commit: "a1b2c3d", Created: day.Add(time.Hour), Verdict: VerdictCounted, Reason: ReasonApproved},
+		},
+	}
+
+	s := new(stores.Store)
+	s.On("GetApprovalActive", int64(1), 42).Return([]*model.Approval{revoked, kept}, nil)
+	s.On("UpdateApproval", revoked).Return(nil)
+	s.On("CreateApproval", mock.Anything).Return(nil)
+	r := new(remotes.Remote)
+	r.On("GetHeadCommit", mock.Anything, user, repo, 42).Return(&model.Commit{SHA: "6dcb09b"}, nil).Once()
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	if err := audit(c, user, repo, issue, result); err != nil {
+		t.Fatalf("Wanted approvals to be recorded, got %s", err)
+	}
+	if revoked.Revoked != day.Add(time.Hour).Unix() {
+		t.Errorf("Wanted approval of bradrydzewski to be revoked at the time of the revocation")
+	}
+	if kept.Revoked != 0 {
+		t.Errorf("Wanted approval of octocat to be kept")
+	}
+
+	s.AssertNumberOfCalls(t, "CreateApproval", 2)
+	created := map[string]*model.Approval{}
+	for _, call := range s.Calls {
+		if call.Method == "CreateApproval" {
+			approval := call.Arguments.Get(0).(*model.Approval)
+			created[approval.Login] = approval
+		}
+	}
+	if got := created["mattnorris"]; got.SHA != "6dcb09b" || got.Source != model.ApprovalComment || got.SourceID != 3 {
+		t.Errorf("Wanted comment approval of mattnorris for the head commit, got %+v", got)
+	}
+	if got := created["janedoe"]; got.SHA != "a1b2c3d" || got.Source != model.ApprovalReview || got.SourceID != 4 {
+		t.Errorf("Wanted review approval of janedoe for the reviewed commit, got %+v", got)
+	}
+}
+
+func TestAuditReplaced(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	issue := &model.Issue{Number: 42}
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	comment := &model.Approval{ID: 1, RepoID: 1, Number: 42, SHA: "6dcb09b", Login: "octocat", Source: model.ApprovalComment, SourceID: 1, Granted: day.Unix()}
+	review := &model.Approval{ID: 2, RepoID: 1, Number: 42, SHA: "6dcb09b", Login: "janedoe", Source: model.ApprovalReview, SourceID: 2, Granted: day.Unix()}
+	result := &Result{
+		Head:      &model.Commit{SHA: "a1b2c3d"},
+		Approvers: []*model.Person{{Login: "octocat"}, {Login: "janedoe"}},
+		Verdicts: []*Verdict{
+			{Kind: "comment", ID: 3, Author: "octocat", Created: day.Add(time.Hour), Verdict: VerdictCounted, Reason: ReasonApproved},
+			{Kind: "review", ID: 2, Author: "janedoe", Commit: "a1b2c3d", Created: day.Add(2 * time.Hour), Verdict: VerdictCounted, Reason: ReasonApproved},
+		},
+	}
+
+	s := new(stores.Store)
+	s.On("GetApprovalActive", int64(1), 42).Return([]*model.Approval{comment, review}, nil)
+	s.On("UpdateApproval", mock.Anything).Return(nil)
+	s.On("CreateApproval", mock.Anything).Return(nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, new(remotes.Remote))
+
+	if err := audit(c, user, repo, issue, result); err != nil {
+		t.Fatalf("Wanted approvals to be recorded, got %s", err)
+	}
+	if comment.Revoked != day.Add(time.Hour).Unix() {
+		t.Errorf("Wanted approval of octocat to be replaced by the new comment")
+	}
+	if review.Revoked != day.Add(2*time.Hour).Unix() {
+		t.Errorf("Wanted approval of janedoe to be replaced by the review of the new commit")
+	}
+
+	s.AssertNumberOfCalls(t, "CreateApproval", 2)
+	created := map[string]*model.Approval{}
+	for _, call := range s.Calls {
+		if call.Method == "CreateApproval" {
+			approval := call.Arguments.Get(0).(*model.Approval)
+			created[approval.Login] = approval
+		}
+	}
+	if got := created["octocat"]; got.SHA != "a1b2c3d" || got.SourceID != 3 {
+		t.Errorf("Wanted comment approval of octocat for the new head commit, got %+v", got)
+	}
+	if got := created["janedoe"]; got.SHA != "a1b2c3d" || got.SourceID != 2 {
+		t.Errorf("Wanted review approval of janedoe for the new commit, got %+v", got)
+	}
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/notifier"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// CheckMerge flags the merge of a pull request into a protected branch
+// without a successful approval status as a bypass.
+func CheckMerge(c context.Context, user *model.User, repo *model.Repo, merge *model.Merge) (*model.Bypass, error) {
+	if !merge.Protected || merge.Status == model.StatusSuccess {
+		return nil, nil
+	}
+	bypass := &model.Bypass{
+		RepoID:  repo.ID,
+		Kind:    model.BypassMerge,
+		Number:  merge.Number,
+		Branch:  merge.Base,
+		SHA:     merge.SHA,
+		Login:   merge.MergedBy,
+		Message: merge.Title,
+		Link:    fmt.Sprintf("%s/pull/%d", repo.Link, merge.Number),
+		Status:  merge.Status,
+		Created: merge.Merged,
+	}
+	return bypass, flag(c, user, repo, bypass)
+}
+
+// CheckPush flags the commits pushed directly to a protected branch
+// without a successful approval status as bypasses. Commits merged
+// through a pull request are checked when the pull request is merged.
+func CheckPush(c context.Context, user *model.User, repo *model.Repo, push *model.Push) ([]*model.Bypass, error) {
+	protected, err := remote.IsProtected(c, user, repo, push.Branch)
+	if err != nil || !protected {
+		return nil, err
+	}
+
+	bypasses := []*model.Bypass{}
+	for _, commit := range push.Commits {
+		status, err := remote.GetStatus(c, user, repo, commit.SHA)
+		if err != nil {
+			return nil, err
+		}
+		if status.State == model.StatusSuccess {
+			continue
+		}
+		merged, err := remote.IsMerged(c, user, repo, commit.SHA)
+		if err != nil {
+			return nil, err
+		}
+		if merged {
+			continue
+		}
+
+		bypass := &model.Bypass{
+			RepoID:  repo.ID,
+			Kind:    model.BypassPush,
+			Branch:  push.Branch,
+			SHA:     commit.SHA,
+			Login:   push.Pusher,
+			Message: commit.Message,
+			Link:    commit.Link,
+			Status:  status.State,
+			Created: commit.Created.Unix(),
+		}
+		if err := flag(c, user, repo, bypass); err != nil {
+			return nil, err
+		}
+		bypasses = append(bypasses, bypass)
+	}
+	return bypasses, nil
+}
+
+// flag is a helper function that records the bypass, and notifies the
+// repository admins. A commit that is already recorded is skipped, since
+// the remote system may deliver the same hook more than once.
+func flag(c context.Context, user *model.User, repo *model.Repo, bypass *model.Bypass) error {
+	if _, err := store.GetBypassSHA(c, repo.ID, bypass.SHA); err == nil {
+		return nil
+	}
+	if err := store.CreateBypass(c, bypass); err != nil {
+		return err
+	}
+	log.Warnf("Commit %s landed on %s %s without approval.", bypass.SHA, repo.Slug, bypass.Branch)
+
+	admins, err := remote.GetAdmins(c, user, repo)
+	if err != nil {
+		log.Errorf("Error retrieving admins for %s. %s", repo.Slug, err)
+	}
+	reviewers := []*notifier.Reviewer{}
+	for _, admin := range admins {
+		reviewers = append(reviewers, &notifier.Reviewer{Login: admin.Login})
+	}
+	err = notifier.Send(c, &notifier.Notification{
+		Kind:      notifier.KindBypass,
+		Reviewers: reviewers,
+		Commit: &notifier.Commit{
+			Repo:    repo.Slug,
+			Branch:  bypass.Branch,
+			SHA:     bypass.SHA,
+			Message: bypass.Message,
+			Author:  bypass.Login,
+			Link:    bypass.Link,
+		},
+	})
+	if err != nil {
+		log.Errorf("Error sending bypass notification for %s. %s", repo.Slug, err)
+	}
+	return nil
+}
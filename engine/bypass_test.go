@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/notifier"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	senders "github.com/go-gitea/lgtm/notifier/mock"
+	remotes "github.com/go-gitea/lgtm/remote/mock"
+	stores "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckPush(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	push := &model.Push{
+		Branch: "master",
+		Pusher: "octocat",
+		Commits: []*model.Commit{
+			{SHA: "6dcb09b", Message: "Approved"},
+			{SHA: "a1b2c3d", Message: "Merged"},
+			{SHA: "e4f5a6b", Message: "Pushed"},
+		},
+	}
+
+	s := new(stores.Store)
+	s.On("GetBypassSHA", int64(1), "e4f5a6b").Return(nil, errors.New("not found"))
+	s.On("CreateBypass", mock.Anything).Return(nil)
+	r := new(remotes.Remote)
+	r.On("IsProtected", mock.Anything, user, repo, "master").Return(true, nil)
+	r.On("GetStatus", mock.Anything, user, repo, "6dcb09b").Return(&model.Status{State: model.StatusSuccess}, nil)
+	r.On("GetStatus", mock.Anything, user, repo, mock.Anything).Return(&model.Status{}, nil)
+	r.On("IsMerged", mock.Anything, user, repo, "a1b2c3d").Return(true, nil)
+	r.On("IsMerged", mock.Anything, user, repo, "e4f5a6b").Return(false, nil)
+	r.On("GetAdmins", mock.Anything, user, repo).Return([]*model.Member{{Login: "bradrydzewski"}}, nil)
+	n := new(senders.Sender)
+	n.On("Send", mock.Anything).Return(nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+	notifier.ToContext(c, n)
+
+	bypasses, err := CheckPush(c, user, repo, push)
+	if err != nil {
+		t.Fatalf("Wanted push to be checked, got %s", err)
+	}
+	if len(bypasses) != 1 || bypasses[0].SHA != "e4f5a6b" || bypasses[0].Kind != model.BypassPush {
+		t.Fatalf("Wanted the pushed commit to be flagged, got %d bypasses", len(bypasses))
+	}
+	notification := n.Calls[0].Arguments.Get(0).(*notifier.Notification)
+	if notification.Kind != notifier.KindBypass || notification.Reviewers[0].Login != "bradrydzewski" {
+		t.Errorf("Wanted the admins to be notified of the bypass")
+	}
+}
+
+func TestCheckPushUnprotected(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+
+	r := new(remotes.Remote)
+	r.On("IsProtected", mock.Anything, user, repo, "feature").Return(false, nil)
+	c := new(gin.Context)
+	remote.ToContext(c, r)
+
+	bypasses, err := CheckPush(c, user, repo, &model.Push{Branch: "feature", Commits: []*model.Commit{{SHA: "e4f5a6b"}}})
+	if err != nil || len(bypasses) != 0 {
+		t.Errorf("Wanted pushes to unprotected branches to be ignored")
+	}
+}
+
+func TestCheckMerge(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world", Link: "https://github.com/octocat/hello-world"}
+
+	s := new(stores.Store)
+	s.On("GetBypassSHA", int64(1), "a1b2c3d").Return(nil, errors.New("not found"))
+	s.On("CreateBypass", mock.Anything).Return(nil)
+	r := new(remotes.Remote)
+	r.On("GetAdmins", mock.Anything, user, repo).Return(nil, errors.New("Bad Gateway"))
+
+	// notifications are dropped when no sender is configured.
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	var tests = []struct {
+		merge  *model.Merge
+		bypass bool
+	}{
+		{&model.Merge{Number: 42, SHA: "a1b2c3d", Protected: true, Status: model.StatusPending}, true},
+		{&model.Merge{Number: 42, SHA: "a1b2c3d", Protected: true, Status: model.StatusSuccess}, false},
+		{&model.Merge{Number: 42, SHA: "a1b2c3d", Status: model.StatusFailure}, false},
+	}
+	for _, test := range tests {
+		bypass, err := CheckMerge(c, user, repo, test.merge)
+		if err != nil {
+			t.Errorf("Wanted merge to be checked, got %s", err)
+		}
+		if got := bypass != nil; got != test.bypass {
+			t.Errorf("Wanted bypass %v for %s merge into protected %v, got %v", test.bypass, test.merge.Status, test.merge.Protected, got)
+		}
+	}
+	s.AssertNumberOfCalls(t, "CreateBypass", 1)
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+
+	"golang.org/x/net/context"
+)
+
+// config is the path of the .lgtm file.
+const config = ".lgtm"
+
+// GetConfig returns the repository configuration from the .lgtm file at
+// the ref, falling back to the default configuration if the file does
+// not exist. An empty ref refers to the default branch.
+func GetConfig(c context.Context, user *model.User, repo *model.Repo, ref string) (*model.Config, error) {
+	rcfile, _ := remote.GetContents(c, user, repo, config, ref)
+	return model.ParseConfig(rcfile)
+}
+
+// ConfigError is returned when the .lgtm file of the repository cannot
+// be parsed.
+type ConfigError struct {
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("Error parsing .lgtm file. %s", e.Err)
+}
+
+// Permanent returns true, since an invalid .lgtm file is not resolved by
+// retrying.
+func (e *ConfigError) Permanent() bool {
+	return true
+}
+
+// IsPermanent returns true if the error is not resolved by retrying,
+// such as an error in the configuration of the repository.
+func IsPermanent(err error) bool {
+	p, ok := err.(interface {
+		Permanent() bool
+	})
+	return ok && p.Permanent()
+}
+
+// IsPolicy returns true if the file defines the approval policy of the
+// repository, which are the .lgtm, MAINTAINERS and CODEOWNERS files.
+func IsPolicy(file string) bool {
+	if file == config || file == maintainers {
+		return true
+	}
+	for _, path := range codeowners {
+		if file == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,572 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// Result represents the approval state of a pull request.
+type Result struct {
+	Config     *model.Config     `json:"settings"`
+	Maintainer *model.Maintainer `json:"-"`
+	Status     *model.Status     `json:"status"`
+	Approvers  []*model.Person   `json:"approved_by"`
+	Revoked    []*model.Person   `json:"revoked_by"`
+	Blockers   []*model.Person   `json:"blocked_by"`
+	Rules      []*model.Rule     `json:"rules"`
+	Files      []string          `json:"files"`
+	Admins     []*model.Person   `json:"admins"`
+	Policy     []string          `json:"policy"`
+	Source     string            `json:"source"`
+	Verdicts   []*Verdict        `json:"verdicts"`
+	Head       *model.Commit     `json:"-"`
+}
+
+// Process evaluates the approval policy of the pull request, and updates
+// the pull request status and labels accordingly. Concurrent calls for
+// the same pull request are serialized and coalesced, so that a burst of
+// events results in a single update with the latest data. The calls are
+// keyed by the repository ID, since slugs are only unique per remote.
+func Process(c context.Context, user *model.User, repo *model.Repo, issue *model.Issue) (*Result, error) {
+	key := fmt.Sprintf("repo:%d#%d", repo.ID, issue.Number)
+	return pulls.Do(key, func() (*Result, error) {
+		unlock, err := lock(c, key)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		return process(c, user, repo, issue)
+	})
+}
+
+// process evaluates the approval policy of the pull request, and updates
+// the pull request status and labels accordingly.
+func process(c context.Context, user *model.User, repo *model.Repo, issue *model.Issue) (*Result, error) {
+	result, err := evaluate(c, user, repo, issue, false)
+	if terr, ok := err.(*TeamError); ok {
+		// a misconfigured team is reported on the pull request, instead
+		// of silently falling back to all maintainers.
+		status := &model.Status{State: model.StatusError, Desc: terr.Error()}
+		if err := remote.SetStatus(c, user, repo, issue.Number, status); err != nil {
+			log.Errorf("Error setting status for %s pr %d. %s", repo.Slug, issue.Number, err)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = remote.SetStatus(c, user, repo, issue.Number, result.Status)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting status. %s", err)
+	}
+
+	err = audit(c, user, repo, issue, result)
+	if err != nil {
+		return nil, fmt.Errorf("Error recording approvals. %s", err)
+	}
+
+	// the label reflects the number of approvals still required, which
+	// may go down as well as up when approvals are dismissed.
+	if !result.Config.DisableLabels {
+		setLabels(c, user, repo, issue.Number, result.Config, getRemaining(result))
+	}
+
+	log.Debugf("processed pr %d for %s. received %d of %d approvals", issue.Number, repo.Slug, len(result.Approvers), result.Config.Approvals)
+	return result, nil
+}
+
+// Evaluate evaluates the approval policy of the pull request, without
+// updating the pull request or recording the push of its head commit.
+func Evaluate(c context.Context, user *model.User, repo *model.Repo, issue *model.Issue) (*Result, error) {
+	return evaluate(c, user, repo, issue, true)
+}
+
+// evaluate evaluates the approval policy of the pull request. The push of
+// the head commit is recorded unless in dry-run mode.
+func evaluate(c context.Context, user *model.User, repo *model.Repo, issue *model.Issue, dryRun bool) (*Result, error) {
+	// the policy is read from the base branch of the pull request, so
+	// that a pull request cannot change the policy it is checked against.
+	// some remotes do not include the author in their hooks, which is
+	// needed to detect self approvals.
+	if len(issue.Base) == 0 || len(issue.Author) == 0 {
+		pull, err := remote.GetPull(c, user, repo, issue.Number)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving pull request. %s", err)
+		}
+		if len(issue.Base) == 0 {
+			issue.Base = pull.Base
+		}
+		if len(issue.Author) == 0 {
+			issue.Author = pull.Author
+		}
+	}
+
+	config, err := GetConfig(c, user, repo, issue.Base)
+	if err != nil {
+		return nil, &ConfigError{Err: err}
+	}
+
+	maintainer, err := GetMaintainer(c, user, repo, issue.Base, config)
+	if err != nil {
+		if _, ok := err.(*TeamError); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Error getting maintainers. %s", err)
+	}
+
+	comments, err := remote.GetComments(c, user, repo, issue.Number)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving comments. %s", err)
+	}
+
+	reviews, err := remote.GetReviews(c, user, repo, issue.Number)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving reviews. %s", err)
+	}
+
+	// approvals given before the most recent push are only counted
+	// when stale approvals are not dismissed.
+	var head *model.Commit
+	if config.DismissStaleApprovals {
+		head, err = getHead(c, user, repo, issue.Number, dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving head commit. %s", err)
+		}
+	}
+
+	// path-based rules, code owners and the policy guard require the
+	// list of changed files, which we only retrieve when in use.
+	var files []string
+	if len(config.Rules) != 0 || len(maintainer.Owners) != 0 || config.AdminApprovals > 0 {
+		files, err = remote.GetFiles(c, user, repo, issue.Number)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving files. %s", err)
+		}
+	}
+
+	result := getApprovers(config, maintainer, issue, head, comments, reviews)
+	result.Rules = getRules(config, maintainer, files, result.Approvers)
+	result.Files = getOwned(maintainer, files, result.Approvers)
+
+	// changes to the policy files require approval from the repository
+	// admins, so that nobody can add themselves and self-approve.
+	if policy := getPolicy(config, files); len(policy) != 0 {
+		admins, err := remote.GetAdmins(c, user, repo)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving admins. %s", err)
+		}
+		result.Admins = getAdmins(config, maintainer, issue, head, admins, comments, reviews)
+		if len(result.Admins) < config.AdminApprovals {
+			result.Policy = policy
+		}
+	}
+
+	result.Config = config
+	result.Head = head
+	result.Maintainer = maintainer
+	result.Source = maintainer.Source
+	result.Status = getStatus(config, maintainer, result)
+	return result, nil
+}
+
+// getRemaining is a helper function that returns the number of approvals
+// still required, as reported by the label. The pull request is only done
+// when its status is successful, so it still needs at least one approval
+// when blocked by rules, code owners, the policy guard or change requests.
+func getRemaining(result *Result) int {
+	remaining := result.Config.Approvals - len(result.Approvers)
+	switch {
+	case result.Status.State == model.StatusSuccess:
+		return 0
+	case remaining <= 0:
+		return 1
+	}
+	return remaining
+}
+
+// setLabels is a helper function that replaces the approval labels of
+// the pull request with the label for the remaining approvals. The labels
+// of an earlier approvals setting are replaced as well.
+func setLabels(c context.Context, user *model.User, repo *model.Repo, num int, config *model.Config, remaining int) {
+	label, err := config.Label(remaining)
+	if err != nil {
+		log.Errorf("Error rendering labels for %s pr %d. %s", repo.Slug, num, err)
+		return
+	}
+
+	oriLabels, err := remote.GetIssueLabels(c, user, repo, num)
+	if err != nil {
+		log.Errorf("Error retrieving labels for %s pr %d. %s", repo.Slug, num, err)
+	}
+
+	var hasLabel bool
+	var removeLabels []string
+	for _, name := range oriLabels {
+		if name == label.Name {
+			hasLabel = true
+			continue
+		}
+		if config.IsLabel(name) {
+			removeLabels = append(removeLabels, name)
+		}
+	}
+
+	if len(removeLabels) > 0 {
+		// remove old labels
+		err = remote.RemoveIssueLabels(c, user, repo, num, removeLabels)
+		if err != nil {
+			log.Errorf("Error remove old labels for %s pr %d. %s", repo.Slug, num, err)
+		}
+	}
+
+	if !hasLabel {
+		// add new label
+		err = remote.AddIssueLabels(c, user, repo, num, []string{label.Name})
+		if err != nil {
+			log.Errorf("Error add new label for %s pr %d. %s", repo.Slug, num, err)
+		}
+	}
+}
+
+// event represents a comment or review that grants, revokes or
+// cancels the approval of its author.
+type event struct {
+	author  string
+	created time.Time
+	approve bool
+	revoke  bool
+	verdict *Verdict
+}
+
+// getApprovers is a helper function that analyzes the list of comments
+// and reviews in chronological order and returns the list of approvers,
+// the list of maintainers who revoked their approval and the list of
+// maintainers with outstanding change requests. If the head commit is
+// provided, any approval given before the commit was pushed is ignored.
+// The verdict of every comment and review is recorded in the result.
+func getApprovers(config *model.Config, maintainer *model.Maintainer, issue *model.Issue, head *model.Commit, comments []*model.Comment, reviews []*model.Review) *Result {
+	result := &Result{
+		Approvers: []*model.Person{},
+		Revoked:   []*model.Person{},
+		Blockers:  []*model.Person{},
+		Verdicts:  []*Verdict{},
+	}
+
+	// only the most recent review verdict of each author counts, since
+	// a later change request or a dismissal cancels an approval.
+	latest := map[string]*model.Review{}
+	for _, review := range reviews {
+		if !review.IsVerdict() {
+			continue
+		}
+		if prev, ok := latest[review.Author]; ok && prev.Submitted.After(review.Submitted) {
+			continue
+		}
+		latest[review.Author] = review
+	}
+
+	var events []*event
+	for _, comment := range comments {
+		verdict := &Verdict{Kind: "comment", ID: comment.ID, Author: comment.Author, Body: comment.Body, Created: comment.Created}
+		result.Verdicts = append(result.Verdicts, verdict)
+
+		// the user must be a valid maintainer of the project
+		if _, ok := maintainer.People[comment.Author]; !ok {
+			verdict.ignore(ReasonNotMaintainer)
+			continue
+		}
+		// verify the comment matches the revocation or approval pattern.
+		// revocation is checked first since a comment like "not lgtm"
+		// usually matches the approval pattern as well.
+		switch {
+		case config.IsRevoke(comment.Body):
+			events = append(events, &event{author: comment.Author, created: comment.Created, revoke: true, verdict: verdict})
+		case config.IsMatch(comment.Body):
+			// cannot lgtm your own pull request
+			if config.SelfApprovalOff && comment.Author == issue.Author {
+				verdict.ignore(ReasonSelfApproval)
+				continue
+			}
+			// the approval must be given after the latest push
+			if head != nil && comment.Created.Before(head.Created) {
+				verdict.ignore(ReasonStale)
+				continue
+			}
+			events = append(events, &event{author: comment.Author, created: comment.Created, approve: true, verdict: verdict})
+		default:
+			verdict.ignore(ReasonNoMatch)
+		}
+	}
+
+	for _, review := range reviews {
+		verdict := &Verdict{Kind: "review", ID: review.ID, Author: review.Author, Body: review.Body, State: review.State, Commit: review.CommitID, Created: review.Submitted}
+		result.Verdicts = append(result.Verdicts, verdict)
+
+		if !review.IsVerdict() {
+			verdict.ignore(ReasonNoVerdict)
+			continue
+		}
+		if latest[review.Author] != review {
+			verdict.ignore(ReasonSuperseded)
+			continue
+		}
+		// the user must be a valid maintainer of the project
+		person, ok := maintainer.People[review.Author]
+		if !ok {
+			verdict.ignore(ReasonNotMaintainer)
+			continue
+		}
+		switch {
+		case review.IsChangesRequested():
+			// an outstanding change request blocks the pull request,
+			// regardless of the commit it was submitted for.
+			if config.BlockOnChanges {
+				result.Blockers = append(result.Blockers, person)
+			}
+			verdict.count(ReasonChangesRequested)
+			events = append(events, &event{author: review.Author, created: review.Submitted})
+		case review.IsApproved():
+			// cannot lgtm your own pull request
+			if config.SelfApprovalOff && review.Author == issue.Author {
+				verdict.ignore(ReasonSelfApproval)
+				continue
+			}
+			// the approval must be given for the latest push
+			if head != nil && isStale(review, head) {
+				verdict.ignore(ReasonStale)
+				continue
+			}
+			events = append(events, &event{author: review.Author, created: review.Submitted, approve: true, verdict: verdict})
+		default:
+			verdict.count(ReasonDismissed)
+		}
+	}
+
+	// replay the events in order, where the last event of an author
+	// decides whether or not they approve the pull request.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].created.Before(events[j].created)
+	})
+
+	var order []string
+	approved := map[string]bool{}
+	revoked := map[string]bool{}
+	last := map[string]*event{}
+	for _, e := range events {
+		last[e.author] = e
+		switch {
+		case e.approve:
+			if _, ok := approved[e.author]; !ok {
+				order = append(order, e.author)
+			}
+			approved[e.author] = true
+			revoked[e.author] = false
+		case e.revoke:
+			revoked[e.author] = approved[e.author]
+			approved[e.author] = false
+		default:
+			approved[e.author] = false
+		}
+	}
+	for _, login := range order {
+		switch {
+		case approved[login]:
+			result.Approvers = append(result.Approvers, maintainer.People[login])
+		case revoked[login]:
+			result.Revoked = append(result.Revoked, maintainer.People[login])
+		}
+	}
+
+	// only the last approval or revocation of an author counts, since
+	// it decides whether or not they approve the pull request.
+	for _, e := range events {
+		switch {
+		case !e.approve && !e.revoke:
+		case last[e.author] != e && e.approve && last[e.author].approve:
+			e.verdict.ignore(ReasonDuplicate)
+		case last[e.author] != e:
+			e.verdict.ignore(ReasonSuperseded)
+		case e.approve:
+			e.verdict.count(ReasonApproved)
+		default:
+			e.verdict.count(ReasonRevoked)
+		}
+	}
+	sort.SliceStable(result.Verdicts, func(i, j int) bool {
+		return result.Verdicts[i].Created.Before(result.Verdicts[j].Created)
+	})
+	return result
+}
+
+// getRules is a helper function that returns the list of rules that
+// apply to the changed files, but are not yet satisfied by approvals
+// of the members of the rule org.
+func getRules(config *model.Config, maintainer *model.Maintainer, files []string, approvers []*model.Person) []*model.Rule {
+	rules := []*model.Rule{}
+	for _, rule := range config.Rules {
+		var touched bool
+		for _, file := range files {
+			if rule.IsMatch(file) {
+				touched = true
+				break
+			}
+		}
+		if !touched {
+			continue
+		}
+		if countRule(rule, maintainer, approvers) < rule.Approvals {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// countRule is a helper function that returns the number of approvers
+// that are members of the rule org. If the rule does not specify an org
+// all approvers are counted.
+func countRule(rule *model.Rule, maintainer *model.Maintainer, approvers []*model.Person) int {
+	if len(rule.Org) == 0 {
+		return len(approvers)
+	}
+	org, ok := maintainer.Org[rule.Org]
+	if !ok {
+		return 0
+	}
+	var count int
+	for _, approver := range approvers {
+		for _, login := range org.People {
+			if login == approver.Login {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// getOwned is a helper function that returns the list of changed files
+// with code owners, that are not yet approved by any of their owners.
+func getOwned(maintainer *model.Maintainer, files []string, approvers []*model.Person) []string {
+	owned := []string{}
+	for _, file := range files {
+		owners := model.GetOwners(maintainer.Owners, file)
+		if len(owners) == 0 {
+			continue
+		}
+		var approved bool
+		for _, approver := range approvers {
+			if maintainer.IsOwner(owners, approver.Login) {
+				approved = true
+				break
+			}
+		}
+		if !approved {
+			owned = append(owned, file)
+		}
+	}
+	return owned
+}
+
+// isStale is a helper function that returns true if the review was
+// submitted for a commit other than the head commit.
+func isStale(review *model.Review, head *model.Commit) bool {
+	if len(review.CommitID) != 0 {
+		return review.CommitID != head.SHA
+	}
+	return review.Submitted.Before(head.Created)
+}
+
+// getPolicy is a helper function that returns the list of changed
+// policy files, if the policy guard is enabled.
+func getPolicy(config *model.Config, files []string) []string {
+	if config.AdminApprovals <= 0 {
+		return nil
+	}
+	var policy []string
+	for _, file := range files {
+		if IsPolicy(file) {
+			policy = append(policy, file)
+		}
+	}
+	return policy
+}
+
+// getAdmins is a helper function that returns the repository admins
+// approving the pull request, excluding the author of the pull request.
+// Admins are counted whether or not they are listed as maintainers, so
+// that the approvals are not filtered by the policy being changed.
+func getAdmins(config *model.Config, maintainer *model.Maintainer, issue *model.Issue, head *model.Commit, admins []*model.Member, comments []*model.Comment, reviews []*model.Review) []*model.Person {
+	people := &model.Maintainer{People: map[string]*model.Person{}}
+	for _, admin := range admins {
+		person, ok := maintainer.People[admin.Login]
+		if !ok {
+			person = &model.Person{Login: admin.Login}
+		}
+		people.People[admin.Login] = person
+	}
+
+	var approvers []*model.Person
+	for _, approver := range getApprovers(config, people, issue, head, comments, reviews).Approvers {
+		if approver.Login != issue.Author {
+			approvers = append(approvers, approver)
+		}
+	}
+	return approvers
+}
+
+// getStatus is a helper function that returns the pull request status
+// for the approval result.
+func getStatus(config *model.Config, maintainer *model.Maintainer, result *Result) *model.Status {
+	switch {
+	case len(result.Blockers) != 0:
+		return &model.Status{
+			State: model.StatusFailure,
+			Desc:  fmt.Sprintf("changes requested by %s", logins(result.Blockers)),
+		}
+	case len(result.Approvers) < config.Approvals:
+		return &model.Status{
+			State: model.StatusPending,
+			Desc:  fmt.Sprintf("%d of %d required approvals granted", len(result.Approvers), config.Approvals),
+		}
+	case len(result.Rules) != 0:
+		rule := result.Rules[0]
+		return &model.Status{
+			State: model.StatusPending,
+			Desc:  fmt.Sprintf("%d of %d required approvals granted for %s", countRule(rule, maintainer, result.Approvers), rule.Approvals, strings.Join(rule.Paths, ", ")),
+		}
+	case len(result.Files) != 0:
+		return &model.Status{
+			State: model.StatusPending,
+			Desc:  fmt.Sprintf("%d changed files require approval from their code owners", len(result.Files)),
+		}
+	case len(result.Policy) != 0:
+		return &model.Status{
+			State: model.StatusPending,
+			Desc:  fmt.Sprintf("%d of %d required admin approvals granted for %s", len(result.Admins), config.AdminApprovals, strings.Join(result.Policy, ", ")),
+		}
+	default:
+		return &model.Status{
+			State: model.StatusSuccess,
+			Desc:  "this commit looks good",
+		}
+	}
+}
+
+// logins is a helper function that returns the comma separated
+// list of logins of the people.
+func logins(people []*model.Person) string {
+	var names []string
+	for _, person := range people {
+		names = append(names, person.Login)
+	}
+	return strings.Join(names, ", ")
+}
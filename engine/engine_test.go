@@ -0,0 +1,307 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+
+	remotes "github.com/go-gitea/lgtm/remote/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetApprovers(t *testing.T) {
+	config, _ := model.ParseConfigStr("")
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\nmattnorris\noctocat")
+	issue := &model.Issue{Number: 1, Author: "octocat"}
+
+	pushed := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := pushed.Add(-time.Hour)
+	after := pushed.Add(time.Hour)
+
+	comments := []*model.Comment{
+		{Author: "bradrydzewski", Body: "LGTM", Created: before},
+		{Author: "janedoe", Body: "LGTM", Created: after},
+	}
+	reviews := []*model.Review{
+		{Author: "mattnorris", State: "APPROVED", Submitted: after},
+		{Author: "bradrydzewski", State: "APPROVED", Submitted: before},
+	}
+
+	approvers := getApprovers(config, maintainer, issue, nil, comments, reviews).Approvers
+	if len(approvers) != 2 {
+		t.Errorf("Wanted 2 approvers, got %d", len(approvers))
+	}
+
+	head := &model.Commit{SHA: "6dcb09b", Created: pushed}
+	approvers = getApprovers(config, maintainer, issue, head, comments, reviews).Approvers
+	if len(approvers) != 1 {
+		t.Errorf("Wanted 1 approver after push, got %d", len(approvers))
+	} else if approvers[0].Login != "mattnorris" {
+		t.Errorf("Wanted approver mattnorris, got %s", approvers[0].Login)
+	}
+}
+
+func TestGetApproversReviewState(t *testing.T) {
+	config, _ := model.ParseConfigStr("block_on_changes_requested = true")
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\nmattnorris\noctocat")
+	issue := &model.Issue{Number: 1, Author: "octocat"}
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	comments := []*model.Comment{
+		{Author: "octocat", Body: "LGTM", Created: day},
+		{Author: "mattnorris", Body: "LGTM", Created: day},
+	}
+	reviews := []*model.Review{
+		{ID: 1, Author: "bradrydzewski", State: "APPROVED", Submitted: day},
+		{ID: 2, Author: "bradrydzewski", State: "COMMENTED", Submitted: day.Add(time.Hour)},
+		{ID: 3, Author: "mattnorris", State: "CHANGES_REQUESTED", Submitted: day.Add(time.Hour)},
+		{ID: 4, Author: "octocat", State: "APPROVED", Submitted: day},
+		{ID: 5, Author: "octocat", State: "DISMISSED", Submitted: day.Add(time.Hour)},
+	}
+
+	result := getApprovers(config, maintainer, issue, nil, comments, reviews)
+	if len(result.Approvers) != 2 {
+		t.Errorf("Wanted 2 approvers, got %d", len(result.Approvers))
+	}
+	if len(result.Blockers) != 1 || result.Blockers[0].Login != "mattnorris" {
+		t.Errorf("Wanted mattnorris to block the pull request")
+	}
+	if status := getStatus(config, maintainer, result); status.State != model.StatusFailure {
+		t.Errorf("Wanted status %s, got %s", model.StatusFailure, status.State)
+	}
+
+	config.BlockOnChanges = false
+	result = getApprovers(config, maintainer, issue, nil, comments, reviews)
+	if len(result.Blockers) != 0 {
+		t.Errorf("Wanted no blockers, got %d", len(result.Blockers))
+	}
+	if status := getStatus(config, maintainer, result); status.State != model.StatusSuccess {
+		t.Errorf("Wanted status %s, got %s", model.StatusSuccess, status.State)
+	}
+}
+
+func TestGetApproversRevoked(t *testing.T) {
+	config, _ := model.ParseConfigStr(`revoke_pattern = "(?i)^(not lgtm|-1|nack)"`)
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\nmattnorris\noctocat")
+	issue := &model.Issue{Number: 1, Author: "octocat"}
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	comments := []*model.Comment{
+		{Author: "bradrydzewski", Body: "LGTM", Created: day},
+		{Author: "mattnorris", Body: "LGTM", Created: day},
+		{Author: "bradrydzewski", Body: "not LGTM, this breaks the build", Created: day.Add(time.Hour)},
+		{Author: "mattnorris", Body: "-1", Created: day.Add(time.Hour)},
+		{Author: "mattnorris", Body: "LGTM now", Created: day.Add(2 * time.Hour)},
+	}
+
+	result := getApprovers(config, maintainer, issue, nil, comments, nil)
+	if len(result.Approvers) != 1 || result.Approvers[0].Login != "mattnorris" {
+		t.Errorf("Wanted mattnorris to approve the pull request")
+	}
+	if len(result.Revoked) != 1 || result.Revoked[0].Login != "bradrydzewski" {
+		t.Errorf("Wanted bradrydzewski to revoke the approval")
+	}
+}
+
+func TestGetApproversVerdicts(t *testing.T) {
+	config, _ := model.ParseConfigStr("self_approval_off = true")
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\nmattnorris\noctocat")
+	issue := &model.Issue{Number: 1, Author: "octocat"}
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	comments := []*model.Comment{
+		{Author: "bradrydzewski", Body: "LGTM", Created: day},
+		{Author: "janedoe", Body: "LGTM", Created: day.Add(1 * time.Minute)},
+		{Author: "octocat", Body: "LGTM", Created: day.Add(2 * time.Minute)},
+		{Author: "mattnorris", Body: "looks good otherwise", Created: day.Add(3 * time.Minute)},
+		{Author: "bradrydzewski", Body: "LGTM", Created: day.Add(4 * time.Minute)},
+	}
+	reviews := []*model.Review{
+		{ID: 1, Author: "mattnorris", State: "APPROVED", Submitted: day.Add(5 * time.Minute)},
+		{ID: 2, Author: "mattnorris", State: "COMMENTED", Submitted: day.Add(6 * time.Minute)},
+	}
+
+	result := getApprovers(config, maintainer, issue, nil, comments, reviews)
+	want := []struct {
+		author, verdict, reason string
+	}{
+		{"bradrydzewski", VerdictIgnored, ReasonDuplicate},
+		{"janedoe", VerdictIgnored, ReasonNotMaintainer},
+		{"octocat", VerdictIgnored, ReasonSelfApproval},
+		{"mattnorris", VerdictIgnored, ReasonNoMatch},
+		{"bradrydzewski", VerdictCounted, ReasonApproved},
+		{"mattnorris", VerdictCounted, ReasonApproved},
+		{"mattnorris", VerdictIgnored, ReasonNoVerdict},
+	}
+	if len(result.Verdicts) != len(want) {
+		t.Fatalf("Wanted %d verdicts, got %d", len(want), len(result.Verdicts))
+	}
+	for i, w := range want {
+		got := result.Verdicts[i]
+		if got.Author != w.author || got.Verdict != w.verdict || got.Reason != w.reason {
+			t.Errorf("Wanted verdict %s %s for %s, got %s %s for %s", w.verdict, w.reason, w.author, got.Verdict, got.Reason, got.Author)
+		}
+	}
+}
+
+func TestGetRules(t *testing.T) {
+	config, _ := model.ParseConfigStr(`
+approvals = 1
+
+[[rule]]
+paths = ["docs/**"]
+org = "docs"
+
+[[rule]]
+paths = ["api/**", "deploy/**"]
+approvals = 2
+org = "core"
+`)
+	maintainer, _ := model.ParseMaintainerStr(`
+[org.core]
+people = ["bradrydzewski", "mattnorris"]
+
+[org.docs]
+people = ["octocat"]
+
+[people.bradrydzewski]
+[people.mattnorris]
+[people.octocat]
+`)
+	approvers := []*model.Person{
+		maintainer.People["bradrydzewski"],
+	}
+
+	rules := getRules(config, maintainer, []string{"README.md"}, approvers)
+	if len(rules) != 0 {
+		t.Errorf("Wanted no pending rules, got %d", len(rules))
+	}
+	rules = getRules(config, maintainer, []string{"docs/index.md", "api/repos.go"}, approvers)
+	if len(rules) != 2 {
+		t.Errorf("Wanted 2 pending rules, got %d", len(rules))
+	}
+
+	approvers = append(approvers, maintainer.People["mattnorris"], maintainer.People["octocat"])
+	rules = getRules(config, maintainer, []string{"docs/index.md", "api/repos.go"}, approvers)
+	if len(rules) != 0 {
+		t.Errorf("Wanted no pending rules, got %d", len(rules))
+	}
+}
+
+func TestGetRemaining(t *testing.T) {
+	config, _ := model.ParseConfigStr(`approvals = 2`)
+	approver := &model.Person{Login: "bradrydzewski"}
+	var tests = []struct {
+		approvers []*model.Person
+		state     string
+		remaining int
+	}{
+		{nil, model.StatusPending, 2},
+		{[]*model.Person{approver}, model.StatusPending, 1},
+		{[]*model.Person{approver, approver}, model.StatusSuccess, 0},
+		{[]*model.Person{approver, approver}, model.StatusPending, 1},
+		{[]*model.Person{approver, approver}, model.StatusFailure, 1},
+	}
+	for _, test := range tests {
+		result := &Result{
+			Config:    config,
+			Approvers: test.approvers,
+			Status:    &model.Status{State: test.state},
+		}
+		if got := getRemaining(result); got != test.remaining {
+			t.Errorf("Wanted %d remaining approvals with %d approvers and status %s, got %d", test.remaining, len(test.approvers), test.state, got)
+		}
+	}
+}
+
+func TestSetLabels(t *testing.T) {
+	config, _ := model.ParseConfigStr(`approvals = 1`)
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+
+	// the need label of an earlier approvals setting is replaced too.
+	r := new(remotes.Remote)
+	r.On("GetIssueLabels", mock.Anything, user, repo, 42).Return([]string{"lgtm/need 3", "bug", "lgtm/need 1"}, nil)
+	r.On("RemoveIssueLabels", mock.Anything, user, repo, 42, []string{"lgtm/need 3", "lgtm/need 1"}).Return(nil).Once()
+	r.On("AddIssueLabels", mock.Anything, user, repo, 42, []string{"lgtm/done"}).Return(nil).Once()
+
+	c := new(gin.Context)
+	remote.ToContext(c, r)
+
+	setLabels(c, user, repo, 42, config, 0)
+	r.AssertExpectations(t)
+}
+
+func TestGetOwned(t *testing.T) {
+	owners, _ := model.ParseCodeOwnersStr(`
+*       @bradrydzewski
+/docs/  @octocat/docs
+`)
+	maintainer := model.FromCodeOwners(owners, map[string][]string{
+		"@octocat/docs": {"mattnorris"},
+	})
+	files := []string{"main.go", "docs/index.md"}
+
+	owned := getOwned(maintainer, files, []*model.Person{{Login: "bradrydzewski"}})
+	if len(owned) != 1 || owned[0] != "docs/index.md" {
+		t.Errorf("Wanted docs/index.md to require approval, got %v", owned)
+	}
+	owned = getOwned(maintainer, files, []*model.Person{{Login: "bradrydzewski"}, {Login: "mattnorris"}})
+	if len(owned) != 0 {
+		t.Errorf("Wanted all files to be approved, got %v", owned)
+	}
+}
+
+func TestGetPolicy(t *testing.T) {
+	config, _ := model.ParseConfigStr("approvals = 1")
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\nmattnorris\noctocat")
+	issue := &model.Issue{Number: 1, Author: "octocat"}
+	files := []string{"main.go", "MAINTAINERS"}
+
+	if policy := getPolicy(config, files); len(policy) != 0 {
+		t.Errorf("Wanted the policy guard to be disabled by default, got %v", policy)
+	}
+	config, _ = model.ParseConfigStr("approvals = 1\nadmin_approvals = 1")
+	admins := []*model.Member{{Login: "bradrydzewski"}, {Login: "octocat"}}
+
+	policy := getPolicy(config, files)
+	if len(policy) != 1 || policy[0] != "MAINTAINERS" {
+		t.Errorf("Wanted MAINTAINERS to be a changed policy file, got %v", policy)
+	}
+
+	// the author cannot approve their own policy change as an admin.
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	comments := []*model.Comment{
+		{Author: "mattnorris", Body: "LGTM", Created: day},
+		{Author: "octocat", Body: "LGTM", Created: day},
+	}
+	result := getApprovers(config, maintainer, issue, nil, comments, nil)
+	result.Policy = policy
+	result.Admins = getAdmins(config, maintainer, issue, nil, admins, comments, nil)
+	if len(result.Admins) != 0 {
+		t.Errorf("Wanted no admin approvals, got %d", len(result.Admins))
+	}
+	if status := getStatus(config, maintainer, result); status.State != model.StatusPending {
+		t.Errorf("Wanted status %s, got %s", model.StatusPending, status.State)
+	}
+
+	// admins approve policy changes even if they are not maintainers.
+	admins = append(admins, &model.Member{Login: "janedoe"})
+	comments = append(comments, &model.Comment{Author: "janedoe", Body: "LGTM", Created: day})
+	result.Admins = getAdmins(config, maintainer, issue, nil, admins, comments, nil)
+	if len(result.Admins) != 1 || result.Admins[0].Login != "janedoe" {
+		t.Errorf("Wanted janedoe to approve as an admin, got %v", result.Admins)
+	}
+	if _, ok := maintainer.People["janedoe"]; ok {
+		t.Errorf("Wanted janedoe not to be a maintainer")
+	}
+
+	config, _ = model.ParseConfigStr("approvals = 1\nadmin_approvals = 0")
+	if policy := getPolicy(config, files); len(policy) != 0 {
+		t.Errorf("Wanted the policy guard to be disabled, got %v", policy)
+	}
+}
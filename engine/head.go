@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	"golang.org/x/net/context"
+)
+
+// Pushed records the time the head commit of the pull request was pushed,
+// unless the commit was already seen. The first recorded time is kept, so
+// that redelivered hooks do not move the push forward.
+func Pushed(c context.Context, repo *model.Repo, number int, sha string, pushed time.Time) (*model.Head, error) {
+	if head, err := store.GetHead(c, repo.ID, number, sha); err == nil {
+		return head, nil
+	}
+	head := &model.Head{
+		RepoID: repo.ID,
+		Number: number,
+		SHA:    sha,
+		Pushed: pushed.Unix(),
+	}
+	if err := store.CreateHead(c, head); err != nil {
+		// the commit may have been recorded concurrently.
+		if recorded, err := store.GetHead(c, repo.ID, number, sha); err == nil {
+			return recorded, nil
+		}
+		return nil, err
+	}
+	return head, nil
+}
+
+// getHead is a helper function that returns the head commit of the pull
+// request, created at the time it was pushed. The commit date is set by
+// the pusher, and may be back-dated to keep earlier approvals valid, so
+// the time the commit was first seen is used instead. In dry-run mode,
+// a commit not seen yet is not recorded, and is pushed at the current
+// time.
+func getHead(c context.Context, user *model.User, repo *model.Repo, number int, dryRun bool) (*model.Commit, error) {
+	head, err := remote.GetHeadCommit(c, user, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		head.Created = time.Now()
+		if pushed, err := store.GetHead(c, repo.ID, number, head.SHA); err == nil {
+			head.Created = time.Unix(pushed.Pushed, 0)
+		}
+		return head, nil
+	}
+	pushed, err := Pushed(c, repo, number, head.SHA, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	head.Created = time.Unix(pushed.Pushed, 0)
+	return head, nil
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	remotes "github.com/go-gitea/lgtm/remote/mock"
+	stores "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHeadBackdated(t *testing.T) {
+	config, _ := model.ParseConfigStr("")
+	maintainer, _ := model.ParseMaintainerStr("bradrydzewski\noctocat")
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	issue := &model.Issue{Number: 42, Author: "octocat"}
+
+	// the commit is back-dated before the approval, but pushed after it.
+	approved := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	committed := approved.Add(-time.Hour)
+	pushed := approved.Add(time.Hour)
+
+	s := new(stores.Store)
+	s.On("GetHead", int64(1), 42, "6dcb09b").Return(&model.Head{RepoID: 1, Number: 42, SHA: "6dcb09b", Pushed: pushed.Unix()}, nil)
+	r := new(remotes.Remote)
+	r.On("GetHeadCommit", mock.Anything, user, repo, 42).Return(&model.Commit{SHA: "6dcb09b", Created: committed}, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	head, err := getHead(c, user, repo, issue.Number, false)
+	if err != nil {
+		t.Fatalf("Wanted the head commit, got %s", err)
+	}
+	if !head.Created.Equal(pushed) {
+		t.Errorf("Wanted the head commit created when pushed, got %s", head.Created)
+	}
+
+	comments := []*model.Comment{
+		{Author: "bradrydzewski", Body: "LGTM", Created: approved},
+	}
+	result := getApprovers(config, maintainer, issue, head, comments, nil)
+	if len(result.Approvers) != 0 {
+		t.Errorf("Wanted the approval before the push to be dismissed, got %d approvers", len(result.Approvers))
+	}
+}
+
+func TestGetHeadDryRun(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	pushed := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	s := new(stores.Store)
+	s.On("GetHead", int64(1), 42, "6dcb09b").Return(&model.Head{RepoID: 1, Number: 42, SHA: "6dcb09b", Pushed: pushed.Unix()}, nil)
+	s.On("GetHead", int64(1), 43, "a1b2c3d").Return(nil, errors.New("not found"))
+	r := new(remotes.Remote)
+	r.On("GetHeadCommit", mock.Anything, user, repo, 42).Return(&model.Commit{SHA: "6dcb09b"}, nil)
+	r.On("GetHeadCommit", mock.Anything, user, repo, 43).Return(&model.Commit{SHA: "a1b2c3d"}, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	head, err := getHead(c, user, repo, 42, true)
+	if err != nil {
+		t.Fatalf("Wanted the head commit, got %s", err)
+	}
+	if !head.Created.Equal(pushed) {
+		t.Errorf("Wanted the head commit created when pushed, got %s", head.Created)
+	}
+
+	// a commit not seen yet is not recorded in dry-run mode.
+	before := time.Now().Add(-time.Second)
+	head, err = getHead(c, user, repo, 43, true)
+	if err != nil {
+		t.Fatalf("Wanted the head commit, got %s", err)
+	}
+	if head.Created.Before(before) {
+		t.Errorf("Wanted the head commit created now, got %s", head.Created)
+	}
+	s.AssertNotCalled(t, "CreateHead", mock.Anything)
+}
+
+func TestPushed(t *testing.T) {
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	pushed := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	s := new(stores.Store)
+	s.On("GetHead", int64(1), 42, "6dcb09b").Return(nil, errors.New("not found"))
+	s.On("CreateHead", mock.Anything).Return(nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	head, err := Pushed(c, repo, 42, "6dcb09b", pushed)
+	if err != nil {
+		t.Fatalf("Wanted the push to be recorded, got %s", err)
+	}
+	if head.Pushed != pushed.Unix() {
+		t.Errorf("Wanted the push recorded at %d, got %d", pushed.Unix(), head.Pushed)
+	}
+	s.AssertNumberOfCalls(t, "CreateHead", 1)
+}
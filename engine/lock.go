@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/store"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+const (
+	// lockTimeout is the duration after which a lock expires, in case
+	// its owner crashed while holding it.
+	lockTimeout = 5 * time.Minute
+
+	// lockRetry is the delay between attempts to acquire a lock.
+	lockRetry = 250 * time.Millisecond
+
+	// lockRenew is the interval at which a held lock is extended, well
+	// before it expires.
+	lockRenew = lockTimeout / 3
+)
+
+// owner identifies the locks held by this process.
+var owner = model.Rand()
+
+// pulls serializes the processing of pull requests in this process.
+var pulls = newGroup()
+
+// call represents a pending or running function call of a group.
+type call struct {
+	done   chan struct{}
+	result *Result
+	err    error
+}
+
+// group provides mutual exclusion of function calls with the same key.
+// A call that arrives while another call with the same key is running
+// waits for it to finish, and all calls that arrive in the meantime are
+// coalesced into a single call.
+type group struct {
+	sync.Mutex
+	running map[string]*call
+	pending map[string]*call
+}
+
+func newGroup() *group {
+	return &group{
+		running: map[string]*call{},
+		pending: map[string]*call{},
+	}
+}
+
+// Do calls the function once the running call with the same key, if
+// any, is finished, or returns the result of the pending call with the
+// same key, which starts after the running call.
+func (g *group) Do(key string, fn func() (*Result, error)) (*Result, error) {
+	g.Lock()
+	if pending, ok := g.pending[key]; ok {
+		g.Unlock()
+		<-pending.done
+		return pending.result, pending.err
+	}
+	self := &call{done: make(chan struct{})}
+	if running, ok := g.running[key]; ok {
+		g.pending[key] = self
+		g.Unlock()
+		<-running.done
+		g.Lock()
+		delete(g.pending, key)
+	}
+	g.running[key] = self
+	g.Unlock()
+
+	self.result, self.err = fn()
+
+	g.Lock()
+	delete(g.running, key)
+	g.Unlock()
+	close(self.done)
+	return self.result, self.err
+}
+
+// lock acquires the named lock in the store, which provides mutual
+// exclusion across processes. The lock is renewed until released, and
+// it returns the function that releases the lock.
+func lock(c context.Context, name string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		now := time.Now()
+		ok, err := store.Lock(c, name, owner, now.Unix(), now.Add(lockTimeout).Unix())
+		if err != nil {
+			return nil, fmt.Errorf("Error acquiring lock %s. %s", name, err)
+		}
+		if ok {
+			done := make(chan struct{})
+			go renew(c, name, lockRenew, done)
+			return func() {
+				close(done)
+				if err := store.Unlock(c, name, owner); err != nil {
+					log.Errorf("Error releasing lock %s. %s", name, err)
+				}
+			}, nil
+		}
+		if now.After(deadline) {
+			return nil, fmt.Errorf("Error acquiring lock %s. Timeout", name)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// renew is a helper function that extends the named lock at every
+// interval until done is closed, so that the lock does not expire while
+// its owner is still running.
+func renew(c context.Context, name string, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			ok, err := store.RenewLock(c, name, owner, now.Add(lockTimeout).Unix())
+			switch {
+			case err != nil:
+				log.Errorf("Error renewing lock %s. %s", name, err)
+			case !ok:
+				log.Errorf("Error renewing lock %s. Taken over by another owner.", name)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/store"
+
+	stores "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGroup(t *testing.T) {
+	g := newGroup()
+
+	var calls int32
+	var started = make(chan struct{})
+	var release = make(chan struct{})
+
+	// the first call blocks until released, while the other calls
+	// arrive and are coalesced into a single call.
+	go g.Do("octocat/hello-world#1", func() (*Result, error) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Do("octocat/hello-world#1", func() (*Result, error) {
+				atomic.AddInt32(&calls, 1)
+				return nil, nil
+			})
+		}()
+	}
+
+	// calls with other keys are not blocked.
+	g.Do("octocat/hello-world#2", func() (*Result, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	})
+
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 3 {
+		t.Errorf("Wanted 3 calls, got %d", calls)
+	}
+}
+
+func TestRenew(t *testing.T) {
+	s := new(stores.Store)
+	s.On("RenewLock", "octocat/hello-world#1", owner, mock.Anything).Return(true, nil)
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	// the lock is renewed until released.
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		renew(c, "octocat/hello-world#1", 10*time.Millisecond, done)
+		close(stopped)
+	}()
+	time.Sleep(55 * time.Millisecond)
+	close(done)
+	<-stopped
+
+	if n := len(s.Calls); n < 3 {
+		t.Errorf("Wanted the lock renewed at least 3 times, got %d", n)
+	}
+}
+
+func TestRenewTakenOver(t *testing.T) {
+	s := new(stores.Store)
+	s.On("RenewLock", "octocat/hello-world#1", owner, mock.Anything).Return(false, nil).Once()
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	// the renewal stops once the lock is taken over by another owner,
+	// without waiting for the lock to be released.
+	renew(c, "octocat/hello-world#1", 10*time.Millisecond, make(chan struct{}))
+	s.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gitea/lgtm/cache"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// maintainers is the path of the MAINTAINERS file.
+const maintainers = "MAINTAINERS"
+
+// codeowners is the list of paths searched for the CODEOWNERS file.
+var codeowners = []string{"CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS"}
+
+// TeamError is returned when the team named in the .lgtm file has no
+// org section in the MAINTAINERS file.
+type TeamError struct {
+	Team string
+}
+
+func (e *TeamError) Error() string {
+	return fmt.Sprintf("MAINTAINERS file has no [org.%s] section", e.Team)
+}
+
+// Permanent returns true, since a misconfigured team is not resolved by
+// retrying.
+func (e *TeamError) Permanent() bool {
+	return true
+}
+
+// GetMaintainer returns the repository maintainers at the ref. If the
+// .lgtm file names a team, the maintainers are narrowed to the members
+// of the matching org section of the MAINTAINERS file.
+func GetMaintainer(c context.Context, user *model.User, repo *model.Repo, ref string, config *model.Config) (*model.Maintainer, error) {
+	maintainer, err := GetMaintainerAll(c, user, repo, ref, config)
+	if err != nil {
+		return nil, err
+	}
+	if maintainer.Source != maintainers || config.Team == model.MaintainersTeam {
+		return maintainer, nil
+	}
+	return FromTeam(maintainer, config.Team)
+}
+
+// GetMaintainerAll returns all repository maintainers at the ref from the
+// MAINTAINERS file or, if it does not exist, the CODEOWNERS file. If
+// neither exists, the maintainers are the members of the org teams
+// named in the .lgtm file.
+func GetMaintainerAll(c context.Context, user *model.User, repo *model.Repo, ref string, config *model.Config) (*model.Maintainer, error) {
+	if !config.IgnoreMaintainersFile {
+		file, err := remote.GetContents(c, user, repo, maintainers, ref)
+		if err == nil {
+			maintainer, err := model.ParseMaintainer(file)
+			if err != nil {
+				return nil, err
+			}
+			maintainer.Source = maintainers
+			return maintainer, nil
+		}
+		for _, path := range codeowners {
+			file, err := remote.GetContents(c, user, repo, path, ref)
+			if err != nil {
+				continue
+			}
+			owners, err := model.ParseCodeOwners(file)
+			if err != nil {
+				return nil, err
+			}
+			maintainer := model.FromCodeOwners(owners, getTeams(c, user, owners))
+			maintainer.Source = path
+			return maintainer, nil
+		}
+	}
+
+	log.Debugf("no MAINTAINERS file for %s. Checking for team members.", repo.Slug)
+	var file []byte
+	var sources []string
+	for _, team := range config.Teams {
+		members, err := cache.GetMembers(c, user, repo.Owner, team)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			file = append(file, member.Login...)
+			file = append(file, '\n')
+		}
+		sources = append(sources, fmt.Sprintf("@%s/%s", repo.Owner, team))
+	}
+	maintainer, err := model.ParseMaintainer(file)
+	if err != nil {
+		return nil, err
+	}
+	maintainer.Source = strings.Join(sources, ",")
+	return maintainer, nil
+}
+
+// FromTeam returns the subset of maintainers that are members of the
+// team, as defined by the org section of the MAINTAINERS file. The other
+// org sections are kept, limited to the members of the team, so they can
+// still be referenced by rules.
+func FromTeam(maintainer *model.Maintainer, team string) (*model.Maintainer, error) {
+	if _, ok := maintainer.Org[team]; !ok {
+		return nil, &TeamError{team}
+	}
+	subset, err := model.FromOrg(maintainer, team)
+	if err != nil {
+		return nil, err
+	}
+	for name, org := range maintainer.Org {
+		var members []string
+		for _, login := range org.People {
+			if person, ok := subset.People[login]; ok {
+				members = append(members, person.Login)
+			}
+		}
+		subset.Org[name] = &model.Org{People: members}
+	}
+	subset.Source = maintainer.Source
+	return subset, nil
+}
+
+// getTeams is a helper function that returns the members of the teams
+// listed as code owners, keyed by the @org/team name.
+func getTeams(c context.Context, user *model.User, owners []*model.Owner) map[string][]string {
+	teams := map[string][]string{}
+	for _, owner := range owners {
+		for _, name := range owner.Owners {
+			if _, ok := teams[name]; ok || !model.IsTeam(name) {
+				continue
+			}
+			parts := strings.SplitN(strings.TrimPrefix(name, "@"), "/", 2)
+			members, err := cache.GetMembers(c, user, parts[0], parts[1])
+			if err != nil {
+				log.Warnf("Error getting members of code owner %s. %s", name, err)
+				continue
+			}
+			teams[name] = []string{}
+			for _, member := range members {
+				teams[name] = append(teams[name], member.Login)
+			}
+		}
+	}
+	return teams
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+)
+
+func TestFromTeamRules(t *testing.T) {
+	config, _ := model.ParseConfigStr(`
+team = "core"
+approvals = 1
+
+[[rule]]
+paths = ["docs/**"]
+org = "docs"
+`)
+	maintainer, _ := model.ParseMaintainerStr(`
+[org.core]
+people = ["bradrydzewski", "mattnorris"]
+
+[org.docs]
+people = ["mattnorris", "octocat"]
+
+[people.bradrydzewski]
+[people.mattnorris]
+[people.octocat]
+`)
+	subset, err := FromTeam(maintainer, config.Team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subset.People) != 2 {
+		t.Errorf("Wanted 2 maintainers in the team, got %d", len(subset.People))
+	}
+	if docs := subset.Org["docs"]; docs == nil || len(docs.People) != 1 || docs.People[0] != "mattnorris" {
+		t.Errorf("Wanted the docs org limited to mattnorris, got %v", docs)
+	}
+
+	files := []string{"docs/index.md"}
+	rules := getRules(config, subset, files, []*model.Person{subset.People["bradrydzewski"]})
+	if len(rules) != 1 {
+		t.Errorf("Wanted 1 pending rule, got %d", len(rules))
+	}
+	rules = getRules(config, subset, files, []*model.Person{subset.People["mattnorris"]})
+	if len(rules) != 0 {
+		t.Errorf("Wanted no pending rules, got %d", len(rules))
+	}
+}
+
+func TestFromTeamMissing(t *testing.T) {
+	maintainer, _ := model.ParseMaintainerStr(`
+[org.core]
+people = ["bradrydzewski"]
+
+[people.bradrydzewski]
+`)
+	_, err := FromTeam(maintainer, "docs")
+	if _, ok := err.(*TeamError); !ok {
+		t.Errorf("Wanted a team error, got %v", err)
+	}
+}
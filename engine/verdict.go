@@ -0,0 +1,52 @@
+package engine
+
+import "time"
+
+// Verdicts of a comment or review.
+const (
+	VerdictCounted = "counted"
+	VerdictIgnored = "ignored"
+)
+
+// Reason codes that explain the verdict of a comment or review.
+const (
+	ReasonApproved         = "approved"
+	ReasonRevoked          = "revoked"
+	ReasonChangesRequested = "changes_requested"
+	ReasonDismissed        = "dismissed"
+	ReasonNotMaintainer    = "not_maintainer"
+	ReasonNoMatch          = "no_match"
+	ReasonNoVerdict        = "no_verdict"
+	ReasonSelfApproval     = "self_approval"
+	ReasonStale            = "stale"
+	ReasonDuplicate        = "duplicate"
+	ReasonSuperseded       = "superseded"
+)
+
+// Verdict explains whether a comment or review counted towards the
+// approval of the pull request, and why.
+type Verdict struct {
+	Kind    string    `json:"kind"`
+	ID      int64     `json:"id,omitempty"`
+	Author  string    `json:"author"`
+	Body    string    `json:"body,omitempty"`
+	State   string    `json:"state,omitempty"`
+	Commit  string    `json:"commit_id,omitempty"`
+	Created time.Time `json:"created_at"`
+	Verdict string    `json:"verdict"`
+	Reason  string    `json:"reason"`
+}
+
+// count is a helper function that marks the comment or review as
+// counted, with the reason code.
+func (v *Verdict) count(reason string) {
+	v.Verdict = VerdictCounted
+	v.Reason = reason
+}
+
+// ignore is a helper function that marks the comment or review as
+// ignored, with the reason code.
+func (v *Verdict) ignore(reason string) {
+	v.Verdict = VerdictIgnored
+	v.Reason = reason
+}
@@ -29,12 +29,22 @@ func main() {
 		logrus.SetLevel(logrus.WarnLevel)
 	}
 
+	var (
+		store  = middleware.Store()
+		remote = middleware.Remote()
+		cache  = middleware.Cache()
+	)
+
+	middleware.DefaultRemote(store, remote)
+
 	handler := router.Load(
 		ginrus.Ginrus(logrus.StandardLogger(), time.RFC3339, true),
 		middleware.Version,
-		middleware.Store(),
-		middleware.Remote(),
-		middleware.Cache(),
+		store,
+		remote,
+		cache,
+		middleware.Sender(),
+		middleware.Queue(store, remote, cache),
 	)
 
 	if *cert != "" {
@@ -0,0 +1,21 @@
+package model
+
+// Approval sources.
+const (
+	ApprovalComment = "comment"
+	ApprovalReview  = "review"
+)
+
+// Approval represents the approval of a pull request by a maintainer,
+// recorded for auditing purposes.
+type Approval struct {
+	ID       int64  `json:"id"         meddler:"approval_id,pk"`
+	RepoID   int64  `json:"repo_id"    meddler:"approval_repo_id"`
+	Number   int    `json:"number"     meddler:"approval_number"`
+	SHA      string `json:"sha"        meddler:"approval_sha"`
+	Login    string `json:"login"      meddler:"approval_login"`
+	Source   string `json:"source"     meddler:"approval_source"`
+	SourceID int64  `json:"source_id"  meddler:"approval_source_id"`
+	Granted  int64  `json:"granted_at" meddler:"approval_granted"`
+	Revoked  int64  `json:"revoked_at" meddler:"approval_revoked"`
+}
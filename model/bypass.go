@@ -0,0 +1,24 @@
+package model
+
+// Bypass kinds.
+const (
+	BypassMerge = "merge"
+	BypassPush  = "push"
+)
+
+// Bypass represents a commit that landed on a protected branch without a
+// successful approval status, either by merging a pull request that was
+// not approved, or by pushing to the branch directly.
+type Bypass struct {
+	ID      int64  `json:"id"         meddler:"bypass_id,pk"`
+	RepoID  int64  `json:"repo_id"    meddler:"bypass_repo_id"`
+	Kind    string `json:"kind"       meddler:"bypass_kind"`
+	Number  int    `json:"number"     meddler:"bypass_number"`
+	Branch  string `json:"branch"     meddler:"bypass_branch"`
+	SHA     string `json:"sha"        meddler:"bypass_sha"`
+	Login   string `json:"login"      meddler:"bypass_login"`
+	Message string `json:"message"    meddler:"bypass_message"`
+	Link    string `json:"link_url"   meddler:"bypass_link"`
+	Status  string `json:"status"     meddler:"bypass_status"`
+	Created int64  `json:"created_at" meddler:"bypass_created"`
+}
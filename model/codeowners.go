@@ -0,0 +1,141 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Owner represents a line in a CODEOWNERS file, which assigns the files
+// matching the pattern to a list of users and teams.
+type Owner struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// ParseCodeOwners parses a projects CODEOWNERS file and returns the
+// list of code owners.
+func ParseCodeOwners(data []byte) ([]*Owner, error) {
+	return ParseCodeOwnersStr(string(data))
+}
+
+// ParseCodeOwnersStr parses a projects CODEOWNERS file in string
+// format and returns the list of code owners.
+func ParseCodeOwnersStr(data string) ([]*Owner, error) {
+	var owners []*Owner
+
+	buf := bytes.NewBufferString(data)
+	reader := bufio.NewReader(buf)
+	for {
+		line, _, err := reader.ReadLine()
+		if err != nil {
+			break
+		}
+		item := parseln(strings.TrimSpace(string(line)))
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+		owner := &Owner{Pattern: fields[0]}
+		for _, field := range fields[1:] {
+			switch {
+			case strings.HasPrefix(field, "@"):
+				owner.Owners = append(owner.Owners, field)
+			case strings.Contains(field, "@"):
+				// email owners cannot be mapped to a login.
+			default:
+				return nil, fmt.Errorf("Invalid code owner %s", field)
+			}
+		}
+		owners = append(owners, owner)
+	}
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("Invalid file format, missing code owners")
+	}
+	return owners, nil
+}
+
+// IsMatch returns true if the file matches the code owner pattern,
+// using the gitignore rules of the CODEOWNERS file format.
+func (o *Owner) IsMatch(file string) bool {
+	pattern := strings.TrimSuffix(o.Pattern, "/")
+
+	// a pattern is relative to the repository root if it contains a
+	// separator, otherwise it matches at any level.
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	// a pattern also matches the contents of matching directories,
+	// unless it only matches the direct children of a directory.
+	if MatchPath(pattern, file) {
+		return true
+	}
+	return !strings.HasSuffix(pattern, "/*") && MatchPath(pattern+"/**", file)
+}
+
+// IsTeam returns true if the owner is an org team.
+func IsTeam(owner string) bool {
+	return strings.Contains(owner, "/")
+}
+
+// GetOwners returns the owners of the file. The last matching pattern
+// takes precedence.
+func GetOwners(owners []*Owner, file string) []string {
+	for i := len(owners) - 1; i >= 0; i-- {
+		if owners[i].IsMatch(file) {
+			return owners[i].Owners
+		}
+	}
+	return nil
+}
+
+// FromCodeOwners returns a new Maintainer file with the users listed as
+// code owners, and the members of the listed teams. Each team is added
+// as an org section using the @org/team name.
+func FromCodeOwners(owners []*Owner, teams map[string][]string) *Maintainer {
+	m := new(Maintainer)
+	m.Org = map[string]*Org{}
+	m.People = map[string]*Person{}
+	m.Owners = owners
+
+	for _, owner := range owners {
+		for _, name := range owner.Owners {
+			if IsTeam(name) {
+				continue
+			}
+			login := strings.TrimPrefix(name, "@")
+			m.People[login] = &Person{Login: login}
+		}
+	}
+	for name, members := range teams {
+		for _, login := range members {
+			m.People[login] = &Person{Login: login}
+		}
+		m.Org[name] = &Org{members}
+	}
+	return m
+}
+
+// IsOwner returns true if the login is one of the owners, either by
+// name or as a member of an owner team.
+func (m *Maintainer) IsOwner(owners []string, login string) bool {
+	for _, owner := range owners {
+		if !IsTeam(owner) {
+			if strings.EqualFold(strings.TrimPrefix(owner, "@"), login) {
+				return true
+			}
+			continue
+		}
+		org, ok := m.Org[owner]
+		if !ok {
+			continue
+		}
+		for _, member := range org.People {
+			if strings.EqualFold(member, login) {
+				return true
+			}
+		}
+	}
+	return false
+}
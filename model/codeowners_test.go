@@ -0,0 +1,61 @@
+package model
+
+import "testing"
+
+func TestParseCodeOwners(t *testing.T) {
+	owners, err := ParseCodeOwnersStr(codeOwnersFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(owners) != 4 {
+		t.Errorf("Wanted 4 code owners, got %d", len(owners))
+		return
+	}
+
+	var tests = []struct {
+		file   string
+		owners []string
+	}{
+		{"main.go", []string{"@bradrydzewski"}},
+		{"web/static/files/lgtm.js", []string{"@mattnorris"}},
+		{"docs/index.md", []string{"@octocat/docs"}},
+		{"docs/api/index.md", []string{"@bradrydzewski"}},
+		{"deploy/prod/app.yml", []string{"@octocat/ops", "@mattnorris"}},
+		{"api/deploy/app.yml", []string{"@bradrydzewski"}},
+	}
+	for _, test := range tests {
+		got := GetOwners(owners, test.file)
+		if len(got) != len(test.owners) {
+			t.Errorf("Wanted owners %v for %s, got %v", test.owners, test.file, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.owners[i] {
+				t.Errorf("Wanted owners %v for %s, got %v", test.owners, test.file, got)
+			}
+		}
+	}
+
+	m := FromCodeOwners(owners, map[string][]string{
+		"@octocat/docs": {"janedoe"},
+	})
+	if len(m.People) != 3 {
+		t.Errorf("Wanted 3 maintainers, got %d", len(m.People))
+	}
+	if !m.IsOwner([]string{"@octocat/docs"}, "janedoe") {
+		t.Errorf("Wanted janedoe to own the docs")
+	}
+	if m.IsOwner([]string{"@octocat/ops"}, "janedoe") {
+		t.Errorf("Wanted janedoe not to own the deployment")
+	}
+}
+
+var codeOwnersFile = `
+# default owners for everything in the repo
+*       @bradrydzewski
+
+*.js    @mattnorris  # frontend
+/docs/* @octocat/docs
+/deploy/ @octocat/ops @mattnorris ops@example.com
+`
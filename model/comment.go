@@ -1,7 +1,11 @@
 package model
 
+import "time"
+
 // Comment represents a comment from the the remote API.
 type Comment struct {
-	Author string
-	Body   string
+	ID      int64
+	Author  string
+	Body    string
+	Created time.Time
 }
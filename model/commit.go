@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Commit represents a commit from the the remote API.
+type Commit struct {
+	SHA     string
+	Author  string
+	Message string
+	Link    string
+	Created time.Time
+}
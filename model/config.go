@@ -1,29 +1,61 @@
 package model
 
 import (
+	"bytes"
 	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/BurntSushi/toml"
 	"github.com/ianschenck/envflag"
 )
 
+// MaintainersTeam is the default team, which includes all the people
+// in the MAINTAINERS file.
+const MaintainersTeam = "MAINTAINERS"
+
 // Config represents a repo-specific configuration file.
 type Config struct {
-	Approvals             int    `json:"approvals"         toml:"approvals"`
-	Pattern               string `json:"pattern"           toml:"pattern"`
-	Team                  string `json:"team"              toml:"team"`
-	SelfApprovalOff       bool   `json:"self_approval_off" toml:"self_approval_off"`
-	IgnoreMaintainersFile bool   `json:"ignore_maintainers_file" toml:"ignore_maintainers_file"`
+	Approvals             int      `json:"approvals"         toml:"approvals"`
+	Pattern               string   `json:"pattern"           toml:"pattern"`
+	RevokePattern         string   `json:"revoke_pattern"    toml:"revoke_pattern"`
+	Team                  string   `json:"team"              toml:"team"`
+	Teams                 []string `json:"teams"             toml:"teams"`
+	SelfApprovalOff       bool     `json:"self_approval_off" toml:"self_approval_off"`
+	IgnoreMaintainersFile bool     `json:"ignore_maintainers_file" toml:"ignore_maintainers_file"`
+	DismissStaleApprovals bool     `json:"dismiss_stale_approvals" toml:"dismiss_stale_approvals"`
+	BlockOnChanges        bool     `json:"block_on_changes_requested" toml:"block_on_changes_requested"`
+	Rules                 []*Rule  `json:"rules"             toml:"rule"`
+	NeedLabel             string   `json:"need_label"        toml:"need_label"`
+	NeedLabelColor        string   `json:"need_label_color"  toml:"need_label_color"`
+	DoneLabel             string   `json:"done_label"        toml:"done_label"`
+	DoneLabelColor        string   `json:"done_label_color"  toml:"done_label_color"`
+	DisableLabels         bool     `json:"disable_labels"    toml:"disable_labels"`
+	AdminApprovals        int      `json:"admin_approvals"   toml:"admin_approvals"`
 
-	re *regexp.Regexp
+	re     *regexp.Regexp
+	revoke *regexp.Regexp
+	need   *template.Template
+	done   *template.Template
+	labels *regexp.Regexp
 }
 
 var (
 	approvals             = envflag.Int("LGTM_APPROVALS", 2, "")
 	pattern               = envflag.String("LGTM_PATTERN", "(?i)LGTM", "")
-	team                  = envflag.String("LGTM_TEAM", "MAINTAINERS", "")
+	revokePattern         = envflag.String("LGTM_REVOKE_PATTERN", "", "")
+	team                  = envflag.String("LGTM_TEAM", MaintainersTeam, "")
+	teams                 = envflag.String("LGTM_TEAMS", "", "")
 	selfApprovalOff       = envflag.Bool("LGTM_SELF_APPROVAL_OFF", false, "")
 	ignoreMaintainersFile = envflag.Bool("IGNORE_MAINTAINERS_FILE", false, "")
+	dismissStaleApprovals = envflag.Bool("LGTM_DISMISS_STALE_APPROVALS", false, "")
+	blockOnChanges        = envflag.Bool("LGTM_BLOCK_ON_CHANGES_REQUESTED", false, "")
+	needLabel             = envflag.String("LGTM_NEED_LABEL", "lgtm/need {{.Remaining}}", "")
+	needLabelColor        = envflag.String("LGTM_NEED_LABEL_COLOR", "fbca04", "")
+	doneLabel             = envflag.String("LGTM_DONE_LABEL", "lgtm/done", "")
+	doneLabelColor        = envflag.String("LGTM_DONE_LABEL_COLOR", "0e8a16", "")
+	disableLabels         = envflag.Bool("LGTM_DISABLE_LABELS", false, "")
+	adminApprovals        = envflag.Int("LGTM_ADMIN_APPROVALS", 0, "")
 )
 
 // ParseConfig parses a projects .lgtm file
@@ -34,7 +66,7 @@ func ParseConfig(data []byte) (*Config, error) {
 // ParseConfigStr parses a projects .lgtm file in string format.
 func ParseConfigStr(data string) (*Config, error) {
 	c := new(Config)
-	_, err := toml.Decode(data, c)
+	meta, err := toml.Decode(data, c)
 	if err != nil {
 		return nil, err
 	}
@@ -44,16 +76,81 @@ func ParseConfigStr(data string) (*Config, error) {
 	if len(c.Pattern) == 0 {
 		c.Pattern = *pattern
 	}
+	if len(c.RevokePattern) == 0 {
+		c.RevokePattern = *revokePattern
+	}
 	if len(c.Team) == 0 {
 		c.Team = *team
 	}
+	if len(c.Teams) == 0 && len(*teams) != 0 {
+		c.Teams = strings.Split(*teams, ",")
+	}
+	if len(c.Teams) == 0 && c.Team != MaintainersTeam {
+		c.Teams = []string{c.Team}
+	}
+	if len(c.Teams) == 0 {
+		c.Teams = []string{DefaultTeam}
+	}
 	if c.SelfApprovalOff == false {
 		c.SelfApprovalOff = *selfApprovalOff
 	}
 	if c.IgnoreMaintainersFile == false {
 		c.IgnoreMaintainersFile = *ignoreMaintainersFile
 	}
+	if c.DismissStaleApprovals == false {
+		c.DismissStaleApprovals = *dismissStaleApprovals
+	}
+	if c.BlockOnChanges == false {
+		c.BlockOnChanges = *blockOnChanges
+	}
+	if len(c.NeedLabel) == 0 {
+		c.NeedLabel = *needLabel
+	}
+	if len(c.NeedLabelColor) == 0 {
+		c.NeedLabelColor = *needLabelColor
+	}
+	if len(c.DoneLabel) == 0 {
+		c.DoneLabel = *doneLabel
+	}
+	if len(c.DoneLabelColor) == 0 {
+		c.DoneLabelColor = *doneLabelColor
+	}
+	if c.DisableLabels == false {
+		c.DisableLabels = *disableLabels
+	}
+	// an explicit zero disables the policy guard, regardless of the
+	// default.
+	if !meta.IsDefined("admin_approvals") {
+		c.AdminApprovals = *adminApprovals
+	}
 
+	for _, rule := range c.Rules {
+		if rule.Approvals == 0 {
+			rule.Approvals = c.Approvals
+		}
+	}
+	if len(c.RevokePattern) != 0 {
+		c.revoke, err = regexp.Compile(c.RevokePattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.need, err = template.New("need_label").Parse(c.NeedLabel)
+	if err != nil {
+		return nil, err
+	}
+	c.done, err = template.New("done_label").Parse(c.DoneLabel)
+	if err != nil {
+		return nil, err
+	}
+	// the templates are executed once to report unknown fields early.
+	if _, err = c.Labels(); err != nil {
+		return nil, err
+	}
+	c.labels, err = labelPattern(c.need, c.done)
+	if err != nil {
+		return nil, err
+	}
 	c.re, err = regexp.Compile(c.Pattern)
 	return c, err
 }
@@ -67,3 +164,65 @@ func (c *Config) IsMatch(text string) bool {
 	}
 	return c.re.MatchString(text)
 }
+
+// IsRevoke returns true if the text matches the regular
+// expression revocation pattern.
+func (c *Config) IsRevoke(text string) bool {
+	if c.revoke == nil {
+		// revocation is disabled
+		return false
+	}
+	return c.revoke.MatchString(text)
+}
+
+// Label returns the name of the label for a pull request that still
+// requires the specified number of approvals.
+func (c *Config) Label(remaining int) (*Label, error) {
+	var buf bytes.Buffer
+	var data = labelData{
+		Remaining: remaining,
+		Approvals: c.Approvals,
+		Approved:  c.Approvals - remaining,
+	}
+	if remaining <= 0 {
+		data.Remaining = 0
+		data.Approved = c.Approvals
+		if err := c.done.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		return &Label{Name: buf.String(), Color: c.DoneLabelColor}, nil
+	}
+	if err := c.need.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return &Label{Name: buf.String(), Color: c.NeedLabelColor}, nil
+}
+
+// Labels returns the list of labels for every number of required
+// approvals, from the approvals setting down to the done label.
+func (c *Config) Labels() ([]*Label, error) {
+	var labels []*Label
+	for remaining := c.Approvals; remaining >= 0; remaining-- {
+		label, err := c.Label(remaining)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// IsLabel returns true if the name is an approval label for any number
+// of approvals, including the labels of an earlier approvals setting.
+func (c *Config) IsLabel(name string) bool {
+	if c.labels != nil && c.labels.MatchString(name) {
+		return true
+	}
+	labels, _ := c.Labels()
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package model
+
+import "testing"
+
+func TestParseConfigTeams(t *testing.T) {
+	var tests = []struct {
+		data  string
+		teams []string
+	}{
+		{"", []string{DefaultTeam}},
+		{`team = "core"`, []string{"core"}},
+		{`teams = ["core", "release"]`, []string{"core", "release"}},
+	}
+	for _, test := range tests {
+		config, err := ParseConfigStr(test.data)
+		if err != nil {
+			t.Errorf("Error parsing config %q. %s", test.data, err)
+			continue
+		}
+		if len(config.Teams) != len(test.teams) {
+			t.Errorf("Wanted teams %v, got %v", test.teams, config.Teams)
+			continue
+		}
+		for i, team := range test.teams {
+			if config.Teams[i] != team {
+				t.Errorf("Wanted teams %v, got %v", test.teams, config.Teams)
+			}
+		}
+	}
+}
+
+func TestConfigLabels(t *testing.T) {
+	config, err := ParseConfigStr("approvals = 3")
+	if err != nil {
+		t.Fatalf("Error parsing config. %s", err)
+	}
+	var tests = []struct {
+		remaining int
+		name      string
+	}{
+		{3, "lgtm/need 3"},
+		{1, "lgtm/need 1"},
+		{0, "lgtm/done"},
+		{-1, "lgtm/done"},
+	}
+	for _, test := range tests {
+		label, err := config.Label(test.remaining)
+		if err != nil {
+			t.Errorf("Error rendering label. %s", err)
+		} else if label.Name != test.name {
+			t.Errorf("Wanted label %q, got %q", test.name, label.Name)
+		}
+	}
+	labels, _ := config.Labels()
+	if len(labels) != 4 {
+		t.Errorf("Wanted 4 labels, got %d", len(labels))
+	}
+
+	config, _ = ParseConfigStr(`need_label = "review: {{.Approved}}/{{.Approvals}}"`)
+	if label, _ := config.Label(1); label.Name != "review: 1/2" {
+		t.Errorf("Wanted label %q, got %q", "review: 1/2", label.Name)
+	}
+
+	if _, err = ParseConfigStr(`need_label = "lgtm/need {{.Missing}}"`); err == nil {
+		t.Errorf("Wanted error for unknown template field")
+	}
+}
+
+func TestConfigIsLabel(t *testing.T) {
+	config, _ := ParseConfigStr(`approvals = 1`)
+	var tests = []struct {
+		name  string
+		label bool
+	}{
+		{"lgtm/need 1", true},
+		{"lgtm/need 3", true},
+		{"lgtm/done", true},
+		{"lgtm/need", false},
+		{"lgtm/need 1 more", false},
+		{"bug", false},
+	}
+	for _, test := range tests {
+		if got := config.IsLabel(test.name); got != test.label {
+			t.Errorf("Wanted label %q to be an approval label %v, got %v", test.name, test.label, got)
+		}
+	}
+
+	config, _ = ParseConfigStr(`need_label = "review: {{.Approved}}/{{.Approvals}} (+)"`)
+	if !config.IsLabel("review: 0/3 (+)") {
+		t.Errorf("Wanted label %q to be an approval label", "review: 0/3 (+)")
+	}
+	if config.IsLabel("review: 0/3 +") {
+		t.Errorf("Wanted label %q not to be an approval label", "review: 0/3 +")
+	}
+}
+
+func TestParseConfigAdminApprovals(t *testing.T) {
+	defer func(value int) { *adminApprovals = value }(*adminApprovals)
+
+	var tests = []struct {
+		data     string
+		fallback int
+		want     int
+	}{
+		{"", 0, 0},
+		{"admin_approvals = 2", 0, 2},
+		{"", 1, 1},
+		{"admin_approvals = 0", 1, 0},
+	}
+	for _, test := range tests {
+		*adminApprovals = test.fallback
+		config, err := ParseConfigStr(test.data)
+		if err != nil {
+			t.Errorf("Error parsing config %q. %s", test.data, err)
+			continue
+		}
+		if config.AdminApprovals != test.want {
+			t.Errorf("Wanted %d admin approvals for %q with default %d, got %d", test.want, test.data, test.fallback, config.AdminApprovals)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+// Delivery represents a hook delivered by the remote system, along with
+// the result of processing it.
+type Delivery struct {
+	ID      int64       `json:"id"         meddler:"delivery_id,pk"`
+	RepoID  int64       `json:"repo_id"    meddler:"delivery_repo_id"`
+	JobID   int64       `json:"job_id"     meddler:"delivery_job_id"`
+	Parent  int64       `json:"parent_id"  meddler:"delivery_parent"`
+	GUID    string      `json:"guid"       meddler:"delivery_guid"`
+	Event   string      `json:"event"      meddler:"delivery_event"`
+	Number  int         `json:"number"     meddler:"delivery_number"`
+	Status  string      `json:"status"     meddler:"delivery_status"`
+	Error   string      `json:"error"      meddler:"delivery_error"`
+	Result  interface{} `json:"result"     meddler:"delivery_result,json"`
+	Created int64       `json:"created_at" meddler:"delivery_created"`
+	Updated int64       `json:"updated_at" meddler:"delivery_updated"`
+}
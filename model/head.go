@@ -0,0 +1,12 @@
+package model
+
+// Head represents the time the head commit of a pull request was first
+// seen pushed. Commit dates are set by the pusher, and cannot be trusted
+// to tell the approvals given before the push from the ones after.
+type Head struct {
+	ID     int64  `json:"id"        meddler:"head_id,pk"`
+	RepoID int64  `json:"repo_id"   meddler:"head_repo_id"`
+	Number int    `json:"number"    meddler:"head_number"`
+	SHA    string `json:"sha"       meddler:"head_sha"`
+	Pushed int64  `json:"pushed_at" meddler:"head_pushed"`
+}
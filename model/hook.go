@@ -2,8 +2,33 @@ package model
 
 // Hook represents a hook from the remote API.
 type Hook struct {
-	Repo    *Repo
-	Issue   *Issue
-	Comment *Comment
-	Review  *Review
+	Delivery string
+	Event    string
+	Repo     *Repo
+	Issue    *Issue
+	Comment  *Comment
+	Review   *Review
+	Push     *Push
+	Merge    *Merge
+	Install  *Install
+}
+
+// Install represents the repositories added to or removed from an app
+// installation from the remote API.
+type Install struct {
+	Sender  string
+	Added   []*Repo
+	Removed []*Repo
+}
+
+// Push represents a push to a branch from the remote API. The remote
+// may list only some of the pushed commits, in which case the push is
+// truncated and the files changed are incomplete.
+type Push struct {
+	Branch    string
+	Default   bool
+	Pusher    string
+	Files     []string
+	Commits   []*Commit
+	Truncated bool
 }
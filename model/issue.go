@@ -5,4 +5,6 @@ type Issue struct {
 	Number int
 	Title  string
 	Author string
+	Base   string
+	Head   string
 }
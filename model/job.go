@@ -0,0 +1,37 @@
+package model
+
+// Job states.
+const (
+	JobPending    = "pending"
+	JobRunning    = "running"
+	JobSuccess    = "success"
+	JobDead       = "dead"
+	JobSuperseded = "superseded"
+)
+
+// Job kinds.
+const (
+	JobPull  = "pull"
+	JobPush  = "push"
+	JobMerge = "merge"
+)
+
+// Job represents a hook persisted for asynchronous processing. Pull jobs
+// evaluate a pull request, while push and merge jobs check the push or
+// merge of their payload.
+type Job struct {
+	ID       int64  `json:"id"         meddler:"job_id,pk"`
+	RepoID   int64  `json:"repo_id"    meddler:"job_repo_id"`
+	Kind     string `json:"kind"       meddler:"job_kind"`
+	Number   int    `json:"number"     meddler:"job_number"`
+	Author   string `json:"author"     meddler:"job_author"`
+	Base     string `json:"base"       meddler:"job_base"`
+	Push     *Push  `json:"push"       meddler:"job_push,json"`
+	Merge    *Merge `json:"merge"      meddler:"job_merge,json"`
+	Status   string `json:"status"     meddler:"job_status"`
+	Attempts int    `json:"attempts"   meddler:"job_attempts"`
+	Error    string `json:"error"      meddler:"job_error"`
+	Created  int64  `json:"created_at" meddler:"job_created"`
+	Updated  int64  `json:"updated_at" meddler:"job_updated"`
+	NextRun  int64  `json:"next_run"   meddler:"job_next_run"`
+}
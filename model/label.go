@@ -0,0 +1,48 @@
+package model
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// labelSentinel is the number rendered into the label templates to find
+// where the numbers of approvals are placed in the label names.
+const labelSentinel = 918273645
+
+// Label represents an issue label in the remote system.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// labelData is the data passed to the label name templates.
+type labelData struct {
+	Remaining int
+	Approvals int
+	Approved  int
+}
+
+// labelPattern is a helper function that returns a regular expression
+// matching the names rendered by the label templates for any number of
+// approvals.
+func labelPattern(templates ...*template.Template) (*regexp.Regexp, error) {
+	var patterns []string
+	var data = labelData{
+		Remaining: labelSentinel,
+		Approvals: labelSentinel,
+		Approved:  labelSentinel,
+	}
+	for _, tmpl := range templates {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		pattern := regexp.QuoteMeta(buf.String())
+		pattern = strings.Replace(pattern, strconv.Itoa(labelSentinel), `-?\d+`, -1)
+		patterns = append(patterns, pattern)
+	}
+	return regexp.Compile("^(" + strings.Join(patterns, "|") + ")$")
+}
@@ -26,6 +26,8 @@ type Org struct {
 type Maintainer struct {
 	People map[string]*Person `json:"people"    toml:"people"`
 	Org    map[string]*Org    `json:"org"       toml:"org"`
+	Owners []*Owner           `json:"owners,omitempty" toml:"-"`
+	Source string             `json:"source,omitempty" toml:"-"`
 }
 
 // ParseMaintainer parses a projects MAINTAINERS file and returns
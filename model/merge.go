@@ -0,0 +1,18 @@
+package model
+
+// Merge represents a merged pull request, along with the approval status
+// at the time of the merge, recorded for auditing purposes.
+type Merge struct {
+	ID        int64  `json:"id"        meddler:"merge_id,pk"`
+	RepoID    int64  `json:"repo_id"   meddler:"merge_repo_id"`
+	Number    int    `json:"number"    meddler:"merge_number"`
+	Title     string `json:"title"     meddler:"merge_title"`
+	Author    string `json:"author"    meddler:"merge_author"`
+	Base      string `json:"base"      meddler:"merge_base"`
+	SHA       string `json:"sha"       meddler:"merge_sha"`
+	HeadSHA   string `json:"head_sha"  meddler:"merge_head_sha"`
+	MergedBy  string `json:"merged_by" meddler:"merge_merged_by"`
+	Status    string `json:"status"    meddler:"merge_status"`
+	Protected bool   `json:"protected" meddler:"merge_protected"`
+	Merged    int64  `json:"merged_at" meddler:"merge_merged"`
+}
@@ -10,6 +10,8 @@ type Repo struct {
 	Link    string `json:"link_url"           meddler:"repo_link"`
 	Private bool   `json:"private"            meddler:"repo_private"`
 	Secret  string `json:"-"                  meddler:"repo_secret"`
+	Remote  string `json:"remote"             meddler:"repo_remote"`
+	Signed  bool   `json:"-"                  meddler:"repo_signed"`
 }
 
 // Perm represents permissions from the the remote API.
@@ -2,16 +2,36 @@ package model
 
 import (
 	"strings"
+	"time"
 )
 
 // Review represents a pull request review comment from the the remote API.
 type Review struct {
-	Author string
-	Body   string
-	State  string
+	ID        int64
+	Author    string
+	Body      string
+	State     string
+	CommitID  string
+	Submitted time.Time
 }
 
 // IsApproved check review state
 func (r *Review) IsApproved() bool {
 	return strings.ToLower(r.State) == "approved"
 }
+
+// IsChangesRequested checks if the review requests changes.
+func (r *Review) IsChangesRequested() bool {
+	return strings.ToLower(r.State) == "changes_requested"
+}
+
+// IsDismissed checks if the review was dismissed.
+func (r *Review) IsDismissed() bool {
+	return strings.ToLower(r.State) == "dismissed"
+}
+
+// IsVerdict checks if the review approves, requests changes or was
+// dismissed, as opposed to a plain or pending review comment.
+func (r *Review) IsVerdict() bool {
+	return r.IsApproved() || r.IsChangesRequested() || r.IsDismissed()
+}
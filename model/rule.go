@@ -0,0 +1,56 @@
+package model
+
+import (
+	"path"
+	"strings"
+)
+
+// Rule represents a path-based approval rule in the .lgtm file. A pull
+// request that changes files matching any of the paths requires the
+// specified number of approvals from members of the org.
+type Rule struct {
+	Paths     []string `json:"paths"     toml:"paths"`
+	Approvals int      `json:"approvals" toml:"approvals"`
+	Org       string   `json:"org"       toml:"org"`
+}
+
+// IsMatch returns true if the file matches one of the rule paths.
+func (r *Rule) IsMatch(file string) bool {
+	for _, pattern := range r.Paths {
+		if MatchPath(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPath returns true if the file path matches the glob pattern. In
+// addition to the path.Match syntax, a ** segment in the pattern matches
+// zero or more directories.
+func MatchPath(pattern, file string) bool {
+	return matchSegments(
+		strings.Split(strings.Trim(pattern, "/"), "/"),
+		strings.Split(strings.Trim(file, "/"), "/"),
+	)
+}
+
+func matchSegments(pattern, file []string) bool {
+	for len(pattern) != 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(file); i++ {
+				if matchSegments(pattern[1:], file[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(file) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], file[0]); !ok {
+			return false
+		}
+		pattern, file = pattern[1:], file[1:]
+	}
+	return len(file) == 0
+}
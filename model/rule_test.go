@@ -0,0 +1,28 @@
+package model
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		file    string
+		match   bool
+	}{
+		{"docs/**", "docs/README.md", true},
+		{"docs/**", "docs/api/index.md", true},
+		{"docs/**", "api/docs/index.md", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "remote/github/github.go", true},
+		{"**/*.go", "remote/github/README.md", false},
+		{"api/*.go", "api/repos.go", true},
+		{"api/*.go", "api/v2/repos.go", false},
+		{"deploy/**/*.yml", "deploy/prod/app.yml", true},
+		{"deploy/**/*.yml", "deploy/app.yml", true},
+		{"Makefile", "Makefile", true},
+	}
+	for _, test := range tests {
+		if got := MatchPath(test.pattern, test.file); got != test.match {
+			t.Errorf("Wanted MatchPath(%q, %q) to be %v", test.pattern, test.file, test.match)
+		}
+	}
+}
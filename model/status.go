@@ -0,0 +1,15 @@
+package model
+
+// Commit status states supported by the remote API.
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusError   = "error"
+)
+
+// Status represents a pull request status in the remote API.
+type Status struct {
+	State string
+	Desc  string
+}
@@ -6,6 +6,10 @@ type Team struct {
 	Avatar string `json:"avatar"`
 }
 
+// DefaultTeam is the name of the org team whose members are the
+// maintainers of a repository without a MAINTAINERS file.
+const DefaultTeam = "maintainers"
+
 // Member represents a member from the the remote API.
 type Member struct {
 	Login string `json:"login"`
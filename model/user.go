@@ -8,4 +8,5 @@ type User struct {
 	Token  string `json:"-"       meddler:"user_token"`
 	Avatar string `json:"avatar"  meddler:"user_avatar"`
 	Secret string `json:"-"       meddler:"user_secret"`
+	Remote string `json:"remote"  meddler:"user_remote"`
 }
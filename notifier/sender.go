@@ -12,7 +12,11 @@ type Sender interface {
 }
 
 // Send sends a notification to the list of maintainers indicating a commit is
-// ready for their review and possible approval.
+// ready for their review and possible approval. The notification is dropped
+// if no Sender is associated with this context.
 func Send(c context.Context, n *Notification) error {
-	return FromContext(c).Send(n)
+	if s, ok := c.Value(key).(Sender); ok {
+		return s.Send(n)
+	}
+	return nil
 }
@@ -1,4 +1,65 @@
 package slack
 
-// TODO(bradrydzewski) ability to notify users of a new pull request requiring
-// approval by sending a notification to a slack room.
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-gitea/lgtm/notifier"
+)
+
+// Slack sends notifications to a Slack channel using an incoming webhook.
+type Slack struct {
+	URL    string
+	Client *http.Client
+}
+
+// New returns a new Slack sender for the incoming webhook url.
+func New(url string) *Slack {
+	return &Slack{URL: url, Client: http.DefaultClient}
+}
+
+// Send sends the notification to the Slack channel.
+func (s *Slack) Send(n *notifier.Notification) error {
+	payload, err := json.Marshal(map[string]string{"text": format(n)})
+	if err != nil {
+		return err
+	}
+	resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		return fmt.Errorf("Slack returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// format is a helper function that renders the notification message.
+func format(n *notifier.Notification) string {
+	var mentions []string
+	for _, reviewer := range n.Reviewers {
+		mentions = append(mentions, "@"+reviewer.Login)
+	}
+
+	commit := n.Commit
+	switch n.Kind {
+	case notifier.KindBypass:
+		return fmt.Sprintf("<%s|%s> landed on %s %s by %s without approval: %s\ncc %s",
+			commit.Link, short(commit.SHA), commit.Repo, commit.Branch, commit.Author, commit.Message, strings.Join(mentions, " "))
+	default:
+		return fmt.Sprintf("<%s|%s> by %s is ready for review: %s\ncc %s",
+			commit.Link, commit.Repo, commit.Author, commit.Message, strings.Join(mentions, " "))
+	}
+}
+
+// short is a helper function that abbreviates the commit sha.
+func short(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,55 @@
+package slack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-gitea/lgtm/notifier"
+)
+
+func TestSend(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		text = payload["text"]
+	}))
+	defer server.Close()
+
+	err := New(server.URL).Send(&notifier.Notification{
+		Kind:      notifier.KindBypass,
+		Reviewers: []*notifier.Reviewer{{Login: "bradrydzewski"}},
+		Commit: &notifier.Commit{
+			Repo:    "octocat/hello-world",
+			Branch:  "master",
+			SHA:     "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			Message: "Update the README",
+			Author:  "octocat",
+			Link:    "https://github.com/octocat/hello-world/commit/6dcb09b",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Wanted notification to be sent, got %s", err)
+	}
+	if !strings.Contains(text, "|6dcb09b> landed on octocat/hello-world master by octocat without approval") {
+		t.Errorf("Wanted bypass message, got %q", text)
+	}
+	if !strings.Contains(text, "cc @bradrydzewski") {
+		t.Errorf("Wanted admins to be mentioned, got %q", text)
+	}
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer server.Close()
+
+	err := New(server.URL).Send(&notifier.Notification{Commit: new(notifier.Commit)})
+	if err == nil {
+		t.Errorf("Wanted error when Slack rejects the notification")
+	}
+}
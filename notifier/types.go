@@ -1,9 +1,20 @@
 package notifier
 
+// Notification kinds.
+const (
+	// KindReview indicates a commit is ready for review.
+	KindReview = "review"
+
+	// KindBypass indicates a commit landed on a protected branch without
+	// approval.
+	KindBypass = "bypass"
+)
+
 // Notification represents a notification that we are sending to a list of
 // maintainers indicating a commit is ready for their review and, hopefully,
-// approval.
+// approval, or that a commit bypassed the approval.
 type Notification struct {
+	Kind      string
 	Reviewers []*Reviewer
 	Commit    *Commit
 }
@@ -18,6 +29,8 @@ type Reviewer struct {
 // Commit represents the commit for which we are notifiying the maintainers.
 type Commit struct {
 	Repo    string
+	Branch  string
+	SHA     string
 	Message string
 	Author  string
 	Link    string
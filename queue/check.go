@@ -0,0 +1,90 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/go-gitea/lgtm/engine"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// checkPush flags the commits pushed directly to a protected branch
+// without approval, and resyncs the open pull requests when a push to the
+// default branch changes the approval policy.
+func checkPush(c context.Context, user *model.User, repo *model.Repo, job *model.Job) error {
+	push := job.Push
+
+	// a failed check must not prevent the resync of a policy change, so
+	// the error is returned once the pull requests are queued.
+	_, checkErr := engine.CheckPush(c, user, repo, push)
+	if checkErr != nil {
+		checkErr = fmt.Errorf("Error checking push. %s", checkErr)
+	}
+
+	// the files changed are incomplete when the push is truncated, in
+	// which case the policy may have changed.
+	policy := push.Truncated
+	for _, file := range push.Files {
+		if engine.IsPolicy(file) {
+			policy = true
+			break
+		}
+	}
+	if !push.Default || !policy {
+		return checkErr
+	}
+
+	// the resync is recorded as deliveries of the push hook.
+	guid, event := "", model.JobPush
+	if deliveries, err := store.GetDeliveryJob(c, job.ID); err == nil && len(deliveries) != 0 {
+		guid, event = deliveries[0].GUID, deliveries[0].Event
+	}
+	if _, err := EnqueueAll(c, user, repo, guid, event); err != nil {
+		log.Errorf("Error queueing resync for %s. %s", repo.Slug, err)
+		return fmt.Errorf("Error queueing resync. %s", err)
+	}
+	return checkErr
+}
+
+// checkMerge records the merge of a pull request, along with the approval
+// status at the time of the merge, and flags the merge of a pull request
+// into a protected branch without approval.
+func checkMerge(c context.Context, user *model.User, repo *model.Repo, job *model.Job) error {
+	merge := job.Merge
+	if _, err := store.GetMergeNumber(c, repo.ID, merge.Number); err == nil {
+		return nil
+	}
+	merge.RepoID = repo.ID
+
+	// some remotes do not include the author in their hooks.
+	if len(merge.Author) == 0 {
+		pull, err := remote.GetPull(c, user, repo, merge.Number)
+		if err != nil {
+			return fmt.Errorf("Error retrieving pull request. %s", err)
+		}
+		merge.Author = pull.Author
+	}
+	status, err := remote.GetStatus(c, user, repo, merge.HeadSHA)
+	if err != nil {
+		return fmt.Errorf("Error retrieving status. %s", err)
+	}
+	merge.Status = status.State
+	merge.Protected, err = remote.IsProtected(c, user, repo, merge.Base)
+	if err != nil {
+		return fmt.Errorf("Error retrieving branch %s. %s", merge.Base, err)
+	}
+
+	// the merge is checked before it is recorded, so that the check
+	// is repeated when the job is retried after a failure.
+	if _, err := engine.CheckMerge(c, user, repo, merge); err != nil {
+		return fmt.Errorf("Error checking merge. %s", err)
+	}
+	if err := store.CreateMerge(c, merge); err != nil {
+		return fmt.Errorf("Error recording merge. %s", err)
+	}
+	return nil
+}
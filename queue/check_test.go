@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	remotes "github.com/go-gitea/lgtm/remote/mock"
+	stores "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckPush(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	job := &model.Job{ID: 1, RepoID: 1, Kind: model.JobPush, Push: &model.Push{
+		Branch:  "master",
+		Default: true,
+		Files:   []string{"README.md", ".lgtm"},
+	}}
+
+	s := new(stores.Store)
+	s.On("GetDeliveryJob", int64(1)).Return([]*model.Delivery{{GUID: "72d3162e", Event: "push"}}, nil)
+	s.On("GetJobPending", int64(1), 42).Return(nil, sql.ErrNoRows)
+	s.On("CreateJob", mock.Anything).Return(nil)
+	s.On("GetJobRetrying", int64(1), 42).Return([]*model.Job{}, nil)
+	s.On("CreateDelivery", mock.Anything).Return(nil)
+	r := new(remotes.Remote)
+	r.On("IsProtected", mock.Anything, user, repo, "master").Return(false, errors.New("Bad Gateway"))
+	r.On("GetPulls", mock.Anything, user, repo).Return([]*model.Issue{{Number: 42}}, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	// a failed check does not prevent the resync of the policy change.
+	if err := checkPush(c, user, repo, job); err == nil {
+		t.Errorf("Wanted the failed check to be retried")
+	}
+	s.AssertNumberOfCalls(t, "CreateJob", 1)
+	delivery := s.Calls[len(s.Calls)-1].Arguments.Get(0).(*model.Delivery)
+	if delivery.GUID != "72d3162e" || delivery.Number != 42 {
+		t.Errorf("Wanted the resync recorded as a delivery of the push, got %+v", delivery)
+	}
+}
+
+func TestCheckPushTruncated(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	push := &model.Push{Branch: "master", Default: true, Files: []string{"README.md"}}
+	job := &model.Job{ID: 1, RepoID: 1, Kind: model.JobPush, Push: push}
+
+	s := new(stores.Store)
+	s.On("GetDeliveryJob", int64(1)).Return([]*model.Delivery{}, nil)
+	s.On("GetJobPending", int64(1), 42).Return(nil, sql.ErrNoRows)
+	s.On("CreateJob", mock.Anything).Return(nil)
+	s.On("GetJobRetrying", int64(1), 42).Return([]*model.Job{}, nil)
+	s.On("CreateDelivery", mock.Anything).Return(nil)
+	r := new(remotes.Remote)
+	r.On("IsProtected", mock.Anything, user, repo, "master").Return(false, nil)
+	r.On("GetPulls", mock.Anything, user, repo).Return([]*model.Issue{{Number: 42}}, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	if err := checkPush(c, user, repo, job); err != nil {
+		t.Fatalf("Wanted the push to be checked, got %s", err)
+	}
+	s.AssertNotCalled(t, "CreateJob", mock.Anything)
+
+	// the policy files may be among the files left out of the push.
+	push.Truncated = true
+	if err := checkPush(c, user, repo, job); err != nil {
+		t.Fatalf("Wanted the push to be checked, got %s", err)
+	}
+	s.AssertNumberOfCalls(t, "CreateJob", 1)
+}
+
+func TestCheckMerge(t *testing.T) {
+	user := &model.User{Login: "octocat"}
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	merge := &model.Merge{Number: 42, Author: "octocat", Base: "master", HeadSHA: "6dcb09b"}
+	job := &model.Job{ID: 1, RepoID: 1, Kind: model.JobMerge, Number: 42, Merge: merge}
+
+	s := new(stores.Store)
+	s.On("GetMergeNumber", int64(1), 42).Return(nil, sql.ErrNoRows).Once()
+	s.On("GetMergeNumber", int64(1), 42).Return(merge, nil)
+	s.On("CreateMerge", merge).Return(nil)
+	r := new(remotes.Remote)
+	r.On("GetStatus", mock.Anything, user, repo, "6dcb09b").Return(&model.Status{State: model.StatusSuccess}, nil)
+	r.On("IsProtected", mock.Anything, user, repo, "master").Return(true, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+	remote.ToContext(c, r)
+
+	if err := checkMerge(c, user, repo, job); err != nil {
+		t.Fatalf("Wanted the merge to be recorded, got %s", err)
+	}
+	if merge.RepoID != 1 || merge.Status != model.StatusSuccess || !merge.Protected {
+		t.Errorf("Wanted the merge recorded with its status, got %+v", merge)
+	}
+
+	// a merge already recorded is skipped when the job is replayed.
+	if err := checkMerge(c, user, repo, job); err != nil {
+		t.Fatalf("Wanted the recorded merge to be skipped, got %s", err)
+	}
+	s.AssertNumberOfCalls(t, "CreateMerge", 1)
+}
@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/store"
+
+	"golang.org/x/net/context"
+)
+
+// Enqueue persists a job to evaluate the pull request, and wakes up the
+// Queue associated with this context. A burst of hooks for the same pull
+// request is coalesced into the pending job, which reads the latest data
+// once it runs. The optional delivery is recorded along with the job, and
+// receives the result once the job is processed.
+func Enqueue(c context.Context, repo *model.Repo, issue *model.Issue, delivery *model.Delivery) (*model.Job, error) {
+	job, err := store.GetJobPending(c, repo.ID, issue.Number)
+	if err == nil {
+		return job, record(c, job, delivery)
+	}
+
+	job = &model.Job{
+		RepoID: repo.ID,
+		Kind:   model.JobPull,
+		Number: issue.Number,
+		Author: issue.Author,
+		Base:   issue.Base,
+	}
+	if err := create(c, job); err != nil {
+		return nil, err
+	}
+	if err := supersede(c, job); err != nil {
+		return nil, err
+	}
+	err = record(c, job, delivery)
+	Notify(c)
+	return job, err
+}
+
+// EnqueuePush persists a job to check the push to the repository, and
+// wakes up the Queue associated with this context. The optional delivery
+// is recorded along with the job.
+func EnqueuePush(c context.Context, repo *model.Repo, push *model.Push, delivery *model.Delivery) (*model.Job, error) {
+	job := &model.Job{
+		RepoID: repo.ID,
+		Kind:   model.JobPush,
+		Base:   push.Branch,
+		Push:   push,
+	}
+	return enqueue(c, job, delivery)
+}
+
+// EnqueueMerge persists a job to record and check the merge of the pull
+// request, and wakes up the Queue associated with this context. The
+// optional delivery is recorded along with the job.
+func EnqueueMerge(c context.Context, repo *model.Repo, merge *model.Merge, delivery *model.Delivery) (*model.Job, error) {
+	job := &model.Job{
+		RepoID: repo.ID,
+		Kind:   model.JobMerge,
+		Number: merge.Number,
+		Author: merge.Author,
+		Base:   merge.Base,
+		Merge:  merge,
+	}
+	return enqueue(c, job, delivery)
+}
+
+// enqueue is a helper function that persists the job, which is never
+// coalesced, and records the delivery.
+func enqueue(c context.Context, job *model.Job, delivery *model.Delivery) (*model.Job, error) {
+	if err := create(c, job); err != nil {
+		return nil, err
+	}
+	err := record(c, job, delivery)
+	Notify(c)
+	return job, err
+}
+
+// create is a helper function that persists the job as pending and ready
+// to run.
+func create(c context.Context, job *model.Job) error {
+	now := time.Now().Unix()
+	job.Status = model.JobPending
+	job.Created = now
+	job.Updated = now
+	job.NextRun = now
+	return store.CreateJob(c, job)
+}
+
+// supersede marks the older jobs of the pull request that are waiting
+// to be retried as superseded by the new job, which reads the latest
+// data once it runs, so that the new job is not held back until the
+// failed jobs are retried.
+func supersede(c context.Context, job *model.Job) error {
+	retrying, err := store.GetJobRetrying(c, job.RepoID, job.Number)
+	if err != nil {
+		return err
+	}
+	for _, older := range retrying {
+		if older.ID >= job.ID {
+			continue
+		}
+		older.Error = fmt.Sprintf("Superseded by job %d.", job.ID)
+		older.Updated = time.Now().Unix()
+		superseded, err := store.SupersedeJob(c, older)
+		if err != nil {
+			return err
+		}
+		if superseded {
+			deliver(c, older, nil)
+		}
+	}
+	return nil
+}
+
+// EnqueueAll persists a job to evaluate each open pull request of the
+// repository, and records a delivery of the event for each of them.
+func EnqueueAll(c context.Context, user *model.User, repo *model.Repo, guid, event string) ([]*model.Delivery, error) {
+	pulls, err := remote.GetPulls(c, user, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	deliveries := []*model.Delivery{}
+	for _, pull := range pulls {
+		delivery := &model.Delivery{
+			GUID:    guid,
+			Event:   event,
+			Created: now,
+			Updated: now,
+		}
+		if _, err := Enqueue(c, repo, pull, delivery); err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// record records the delivery of a hook processed by the job.
+func record(c context.Context, job *model.Job, delivery *model.Delivery) error {
+	if delivery == nil {
+		return nil
+	}
+	delivery.RepoID = job.RepoID
+	delivery.JobID = job.ID
+	delivery.Number = job.Number
+	delivery.Status = job.Status
+	return store.CreateDelivery(c, delivery)
+}
@@ -0,0 +1,231 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/go-gitea/lgtm/engine"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/store"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+const (
+	// maxBackoff is the upper bound of the delay between retries.
+	maxBackoff = time.Hour
+
+	// runTimeout is the duration after which a running job is processed
+	// again, in case the process running it crashed. It exceeds the
+	// time a job waits for the lock of its pull request.
+	runTimeout = 15 * time.Minute
+)
+
+// Queue processes the persisted hooks with a pool of workers. The jobs
+// of a pull request are processed in order, and failed jobs are retried
+// with exponential backoff until the retry limit is reached, after which
+// they are marked as dead.
+type Queue struct {
+	Workers  int
+	Retries  int
+	Backoff  time.Duration
+	Interval time.Duration
+
+	// Process processes a job, and defaults to evaluating the pull
+	// request and updating its status.
+	Process func(context.Context, *model.Job) (*engine.Result, error)
+
+	signal chan struct{}
+}
+
+// New returns a new Queue.
+func New(workers, retries int, backoff, interval time.Duration) *Queue {
+	return &Queue{
+		Workers:  workers,
+		Retries:  retries,
+		Backoff:  backoff,
+		Interval: interval,
+		Process:  process,
+		signal:   make(chan struct{}, 1),
+	}
+}
+
+// Start starts the workers. The context must provide the store, remote
+// and cache used to process the jobs.
+func (q *Queue) Start(c context.Context) {
+	jobs := make(chan *model.Job)
+	for i := 0; i < q.Workers; i++ {
+		go q.work(c, jobs)
+	}
+	go q.dispatch(c, jobs)
+}
+
+// Notify wakes up the dispatcher to check for pending jobs.
+func (q *Queue) Notify() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch polls the store for pending jobs and hands them over to the
+// workers, until the context is done.
+func (q *Queue) dispatch(c context.Context, jobs chan<- *model.Job) {
+	ticker := time.NewTicker(q.Interval)
+	defer ticker.Stop()
+	for {
+		q.schedule(c, jobs)
+
+		select {
+		case <-c.Done():
+			close(jobs)
+			return
+		case <-ticker.C:
+		case <-q.signal:
+		}
+	}
+}
+
+// schedule claims the pending jobs that are ready to run and hands them
+// over to the workers.
+func (q *Queue) schedule(c context.Context, jobs chan<- *model.Job) {
+	// jobs interrupted by a crash or a restart are processed again once
+	// timed out, since other processes may still be running their jobs.
+	if err := store.ResetJobs(c, time.Now().Add(-runTimeout).Unix()); err != nil {
+		log.Errorf("Error resetting timed out jobs. %s", err)
+	}
+
+	pending, err := store.GetJobQueue(c, time.Now().Unix(), q.Workers)
+	if err != nil {
+		log.Errorf("Error getting pending jobs. %s", err)
+		return
+	}
+	for _, job := range pending {
+		job.Updated = time.Now().Unix()
+		claimed, err := store.ClaimJob(c, job)
+		if err != nil {
+			log.Errorf("Error claiming job %d. %s", job.ID, err)
+			continue
+		}
+		if claimed {
+			jobs <- job
+		}
+	}
+}
+
+// work processes the jobs and records the result.
+func (q *Queue) work(c context.Context, jobs <-chan *model.Job) {
+	for job := range jobs {
+		result, err := q.Process(c, job)
+		q.finish(c, job, result, err)
+	}
+}
+
+// finish records the result of a job, and schedules failed jobs to be
+// retried with exponential backoff. Jobs failing with a permanent error,
+// such as a configuration error, or superseded by a newer job of the
+// pull request, are not retried.
+func (q *Queue) finish(c context.Context, job *model.Job, result *engine.Result, err error) {
+	now := time.Now()
+	job.Attempts++
+	job.Updated = now.Unix()
+
+	switch {
+	case err == nil:
+		job.Status = model.JobSuccess
+		job.Error = ""
+	case engine.IsPermanent(err):
+		log.Errorf("Error processing job %d. Not retrying. %s", job.ID, err)
+		job.Status = model.JobDead
+		job.Error = err.Error()
+	case job.Attempts > q.Retries:
+		log.Errorf("Error processing job %d. Giving up after %d attempts. %s", job.ID, job.Attempts, err)
+		job.Status = model.JobDead
+		job.Error = err.Error()
+	case job.Kind == model.JobPull && superseded(c, job):
+		// a newer job of the pull request was queued while this job
+		// was running, and reads the latest data once it runs.
+		log.Warnf("Error processing job %d. Superseded by a newer job. %s", job.ID, err)
+		job.Status = model.JobSuperseded
+		job.Error = err.Error()
+	default:
+		log.Warnf("Error processing job %d. Retrying. %s", job.ID, err)
+		job.Status = model.JobPending
+		job.Error = err.Error()
+		job.NextRun = now.Add(backoff(q.Backoff, job.Attempts)).Unix()
+	}
+
+	if err := store.UpdateJob(c, job); err != nil {
+		log.Errorf("Error updating job %d. %s", job.ID, err)
+	}
+	deliver(c, job, result)
+
+	// the next job of the pull request may be ready to run.
+	q.Notify()
+}
+
+// superseded is a helper function that returns true if a newer job of
+// the pull request is pending.
+func superseded(c context.Context, job *model.Job) bool {
+	pending, err := store.GetJobPending(c, job.RepoID, job.Number)
+	return err == nil && pending.ID > job.ID
+}
+
+// backoff returns the delay before the next attempt, which doubles with
+// every failed attempt.
+func backoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// deliver records the result of a job on the hook deliveries it
+// processed.
+func deliver(c context.Context, job *model.Job, result *engine.Result) {
+	deliveries, err := store.GetDeliveryJob(c, job.ID)
+	if err != nil {
+		log.Errorf("Error getting deliveries of job %d. %s", job.ID, err)
+		return
+	}
+	for _, delivery := range deliveries {
+		delivery.Status = job.Status
+		delivery.Error = job.Error
+		delivery.Updated = job.Updated
+		if result != nil {
+			delivery.Result = result
+		}
+		if err := store.UpdateDelivery(c, delivery); err != nil {
+			log.Errorf("Error updating delivery %d. %s", delivery.ID, err)
+		}
+	}
+}
+
+// process evaluates the pull request of the job and updates its status,
+// or checks the push or merge of the job.
+func process(c context.Context, job *model.Job) (*engine.Result, error) {
+	repo, err := store.GetRepo(c, job.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := store.GetRepoUser(c, repo)
+	if err != nil {
+		return nil, err
+	}
+	switch job.Kind {
+	case model.JobPush:
+		return nil, checkPush(c, user, repo, job)
+	case model.JobMerge:
+		return nil, checkMerge(c, user, repo, job)
+	}
+	issue := &model.Issue{
+		Number: job.Number,
+		Author: job.Author,
+		Base:   job.Base,
+	}
+	return engine.Process(c, user, repo, issue)
+}
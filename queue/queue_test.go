@@ -0,0 +1,183 @@
+package queue
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/engine"
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/store"
+
+	mocks "github.com/go-gitea/lgtm/store/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func TestBackoff(t *testing.T) {
+	var tests = []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, time.Hour},
+	}
+	for _, test := range tests {
+		if got := backoff(30*time.Second, test.attempts); got != test.delay {
+			t.Errorf("Wanted backoff %s after %d attempts, got %s", test.delay, test.attempts, got)
+		}
+	}
+}
+
+func TestFinish(t *testing.T) {
+	s := new(mocks.Store)
+	s.On("UpdateJob", mock.Anything).Return(nil)
+	s.On("GetDeliveryJob", mock.Anything).Return([]*model.Delivery{}, nil)
+	s.On("GetJobPending", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	q := New(1, 2, time.Minute, time.Minute)
+
+	job := &model.Job{ID: 1, Status: model.JobRunning}
+	q.finish(c, job, nil, errors.New("Bad Gateway"))
+	if job.Status != model.JobPending || job.Attempts != 1 {
+		t.Errorf("Wanted job to be retried, got %s after %d attempts", job.Status, job.Attempts)
+	}
+	if job.NextRun <= time.Now().Unix() {
+		t.Errorf("Wanted job retry to be delayed")
+	}
+
+	q.finish(c, job, nil, errors.New("Bad Gateway"))
+	q.finish(c, job, nil, errors.New("Bad Gateway"))
+	if job.Status != model.JobDead || job.Error != "Bad Gateway" {
+		t.Errorf("Wanted job to be dead, got %s", job.Status)
+	}
+
+	job = &model.Job{ID: 3, Status: model.JobRunning}
+	q.finish(c, job, nil, &engine.TeamError{Team: "core"})
+	if job.Status != model.JobDead || job.Attempts != 1 {
+		t.Errorf("Wanted job with a configuration error to be dead, got %s after %d attempts", job.Status, job.Attempts)
+	}
+
+	job = &model.Job{ID: 2, Status: model.JobRunning, Attempts: 1, Error: "Bad Gateway"}
+	q.finish(c, job, new(engine.Result), nil)
+	if job.Status != model.JobSuccess || job.Error != "" {
+		t.Errorf("Wanted job to succeed, got %s", job.Status)
+	}
+}
+
+func TestFinishSuperseded(t *testing.T) {
+	s := new(mocks.Store)
+	s.On("UpdateJob", mock.Anything).Return(nil)
+	s.On("GetDeliveryJob", mock.Anything).Return([]*model.Delivery{}, nil)
+	s.On("GetJobPending", int64(1), 42).Return(&model.Job{ID: 2, RepoID: 1, Number: 42}, nil)
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	q := New(1, 2, time.Minute, time.Minute)
+
+	job := &model.Job{ID: 1, RepoID: 1, Kind: model.JobPull, Number: 42, Status: model.JobRunning}
+	q.finish(c, job, nil, errors.New("Bad Gateway"))
+	if job.Status != model.JobSuperseded || job.Error != "Bad Gateway" {
+		t.Errorf("Wanted job to be superseded by the newer job, got %s", job.Status)
+	}
+}
+
+func TestEnqueueSupersede(t *testing.T) {
+	retrying := &model.Job{ID: 1, RepoID: 1, Number: 42, Status: model.JobPending, Attempts: 1}
+	delivery := &model.Delivery{ID: 1, JobID: 1, Status: model.JobPending}
+
+	s := new(mocks.Store)
+	s.On("GetJobPending", int64(1), 42).Return(nil, sql.ErrNoRows)
+	s.On("CreateJob", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Job).ID = 2
+	}).Return(nil)
+	s.On("GetJobRetrying", int64(1), 42).Return([]*model.Job{retrying}, nil)
+	s.On("SupersedeJob", retrying).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Job).Status = model.JobSuperseded
+	}).Return(true, nil)
+	s.On("GetDeliveryJob", int64(1)).Return([]*model.Delivery{delivery}, nil)
+	s.On("UpdateDelivery", delivery).Return(nil)
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	repo := &model.Repo{ID: 1, Slug: "octocat/hello-world"}
+	job, err := Enqueue(c, repo, &model.Issue{Number: 42}, nil)
+	if err != nil {
+		t.Fatalf("Wanted job to be queued, got %s", err)
+	}
+	if job.ID != 2 || job.Status != model.JobPending {
+		t.Errorf("Wanted a new pending job, got job %d %s", job.ID, job.Status)
+	}
+	if retrying.Error != "Superseded by job 2." {
+		t.Errorf("Wanted retrying job to be superseded by the new job, got %q", retrying.Error)
+	}
+	if delivery.Status != model.JobSuperseded {
+		t.Errorf("Wanted delivery of the retrying job to be superseded, got %s", delivery.Status)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	delivery := &model.Delivery{ID: 1, JobID: 1, Status: model.JobPending}
+	result := &engine.Result{Approvers: []*model.Person{{Login: "octocat"}}}
+
+	s := new(mocks.Store)
+	s.On("GetDeliveryJob", int64(1)).Return([]*model.Delivery{delivery}, nil)
+	s.On("UpdateDelivery", delivery).Return(nil)
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	job := &model.Job{ID: 1, Status: model.JobSuccess, Updated: 42}
+	deliver(c, job, result)
+	if delivery.Status != model.JobSuccess || delivery.Updated != 42 {
+		t.Errorf("Wanted delivery to succeed, got %s", delivery.Status)
+	}
+	if delivery.Result != result {
+		t.Errorf("Wanted delivery to record the result")
+	}
+
+	// a failed attempt keeps the result of the previous attempt.
+	job = &model.Job{ID: 1, Status: model.JobPending, Error: "Bad Gateway"}
+	deliver(c, job, nil)
+	if delivery.Error != "Bad Gateway" || delivery.Result != result {
+		t.Errorf("Wanted delivery to record the error")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	job := &model.Job{ID: 1, RepoID: 1, Number: 42, Status: model.JobPending}
+
+	s := new(mocks.Store)
+	s.On("ResetJobs", mock.Anything).Return(nil)
+	s.On("GetJobQueue", mock.Anything, 2).Return([]*model.Job{job}, nil).Once()
+	s.On("GetJobQueue", mock.Anything, 2).Return([]*model.Job{}, nil)
+	s.On("ClaimJob", job).Return(true, nil)
+	s.On("UpdateJob", job).Return(nil)
+	s.On("GetDeliveryJob", job.ID).Return([]*model.Delivery{}, nil)
+
+	c := new(gin.Context)
+	store.ToContext(c, s)
+
+	done := make(chan *model.Job, 1)
+	q := New(2, 5, time.Minute, time.Hour)
+	q.Process = func(c context.Context, job *model.Job) (*engine.Result, error) {
+		done <- job
+		return new(engine.Result), nil
+	}
+	q.Start(c)
+
+	select {
+	case got := <-done:
+		if got.Number != 42 {
+			t.Errorf("Wanted job for pr 42, got %d", got.Number)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Wanted job to be processed")
+	}
+}
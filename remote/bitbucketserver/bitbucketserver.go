@@ -0,0 +1,711 @@
+package bitbucketserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-gitea/lgtm/model"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+const contextName = "approvals/lgtm"
+
+// Bitbucket provides the available configuration values.
+type Bitbucket struct {
+	URL string
+
+	// MaxPages is the upper bound of pages retrieved from list
+	// endpoints. Zero means no limit.
+	MaxPages int
+}
+
+// GetUser retrieves the current user from the API. Users authenticate
+// with a personal access token, which is entered as the password of the
+// basic authentication prompt.
+func (g *Bitbucket) GetUser(c context.Context, res http.ResponseWriter, req *http.Request) (*model.User, error) {
+	_, token, ok := req.BasicAuth()
+	if !ok || len(token) == 0 {
+		res.Header().Set("WWW-Authenticate", `Basic realm="Bitbucket personal access token"`)
+		http.Error(res, "A Bitbucket personal access token is required.", http.StatusUnauthorized)
+		return nil, nil
+	}
+
+	client := NewClientToken(g.URL, token)
+	login, err := client.Whoami()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching user. %s", err)
+	}
+	user, err := client.GetUser(login)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching user. %s", err)
+	}
+
+	avatar := user.AvatarURL
+	if strings.HasPrefix(avatar, "/") {
+		avatar = g.URL + avatar
+	}
+	return &model.User{
+		Login:  user.Name,
+		Token:  token,
+		Avatar: avatar,
+	}, nil
+}
+
+// GetUserToken retrieves a user token from the API.
+func (g *Bitbucket) GetUserToken(c context.Context, token string) (string, error) {
+	client := NewClientToken(g.URL, token)
+	login, err := client.Whoami()
+	if err != nil {
+		return "", fmt.Errorf("Error fetching user. %s", err)
+	}
+	return login, nil
+}
+
+// GetTeams retrieves teams from the API. The projects of the user are
+// returned as teams.
+func (g *Bitbucket) GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	teams := []*model.Team{}
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		projects, next, err := client.GetProjects(start)
+		for _, project := range projects {
+			teams = append(teams, &model.Team{
+				Login:  project.Key,
+				Avatar: fmt.Sprintf("%s/projects/%s/avatar.png", g.URL, project.Key),
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching teams. %s", err)
+	}
+	return teams, nil
+}
+
+// GetMembers retrieves members from the API. Bitbucket groups are not
+// scoped to a project, so the team is the group of the same name.
+func (g *Bitbucket) GetMembers(c context.Context, user *model.User, org, team string) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	var members []*model.Member
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		users, next, err := client.GetGroupMembers(team, start)
+		for _, member := range users {
+			members = append(members, &model.Member{
+				Login: member.Name,
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching team members. %s", err)
+	}
+	return members, nil
+}
+
+// GetRepo retrieves a repository from the API.
+func (g *Bitbucket) GetRepo(c context.Context, user *model.User, owner, name string) (*model.Repo, error) {
+	client := NewClientToken(g.URL, user.Token)
+	repo, err := client.GetRepo(owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+	return toRepo(repo), nil
+}
+
+// GetPerm retrieves permissions from the API. The API does not report
+// the permissions of the user for a repository, which are checked by
+// listing the repositories with the same name the user has permissions
+// for.
+func (g *Bitbucket) GetPerm(c context.Context, user *model.User, owner, name string) (*model.Perm, error) {
+	client := NewClientToken(g.URL, user.Token)
+	repo, err := client.GetRepo(owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+
+	m := &model.Perm{Pull: true}
+	for _, perm := range []string{permWrite, permAdmin} {
+		var granted bool
+		err := Paginate(g.MaxPages, func(start int) (int, error) {
+			repos, next, err := client.GetRepos(repo.Name, perm, start)
+			for _, r := range repos {
+				if r.Project.Key == repo.Project.Key && r.Slug == repo.Slug {
+					granted = true
+				}
+			}
+			return next, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching repository permissions. %s", err)
+		}
+		switch perm {
+		case permWrite:
+			m.Push = granted
+		case permAdmin:
+			m.Admin = granted
+		}
+	}
+	return m, nil
+}
+
+// GetRepos retrieves repositories from the API.
+func (g *Bitbucket) GetRepos(c context.Context, u *model.User) ([]*model.Repo, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	// only list repositories that I can admin
+	repos := []*model.Repo{}
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetRepos("", permAdmin, start)
+		for _, repo := range list {
+			repos = append(repos, toRepo(repo))
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// RemoveIssueLabels removes labels from an issue. Bitbucket pull
+// requests have no labels, so this is a no-op.
+func (g *Bitbucket) RemoveIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	return nil
+}
+
+// AddIssueLabels adds labels to an issue. Bitbucket pull requests have
+// no labels, so this is a no-op.
+func (g *Bitbucket) AddIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	return nil
+}
+
+// GetIssueLabels get all labels of issue. Bitbucket pull requests have
+// no labels.
+func (g *Bitbucket) GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error) {
+	return []string{}, nil
+}
+
+// CreateLabels creates the labels that do not exist in the repository.
+// Bitbucket pull requests have no labels, so this is a no-op.
+func (g *Bitbucket) CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	return nil
+}
+
+// SetHook injects a webhook through the API, and requires a successful
+// LGTM build status to merge into the default branch.
+func (g *Bitbucket) SetHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	old, err := g.getHook(client, repo, link)
+	if err == nil && old != nil {
+		client.DeleteHook(repo.Owner, repo.Name, old.ID)
+	}
+
+	err = client.CreateHook(repo.Owner, repo.Name, &Hook{
+		Name: "LGTM",
+		URL:  link,
+		Events: []string{
+			"pr:opened",
+			"pr:from_ref_updated",
+			"pr:comment:added",
+			"pr:reviewer:approved",
+			"pr:reviewer:unapproved",
+			"pr:reviewer:needs_work",
+			"pr:merged",
+		},
+		Active:        true,
+		Configuration: map[string]string{"secret": repo.Secret},
+	})
+	if err != nil {
+		log.Debugf("Error creating the webhook at %s. %s", link, err)
+		return err
+	}
+
+	branch, err := client.GetDefaultBranch(repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+	conditions, err := g.getConditions(client, repo)
+	if isNotFound(err) {
+		// required builds are only available in Bitbucket Data Center
+		// 7.14 and newer.
+		log.Warnf("Error configuring required builds for %s@%s. %s", repo.Slug, branch.DisplayID, err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, condition := range conditions {
+		if condition.RefMatcher.ID == branch.ID {
+			return nil
+		}
+	}
+
+	condition := &Condition{BuildParentKeys: []string{contextName}}
+	condition.RefMatcher.ID = branch.ID
+	condition.RefMatcher.Type.ID = "BRANCH"
+	err = client.CreateCondition(repo.Owner, repo.Name, condition)
+	if err != nil {
+		log.Warnf("Error configuring required builds for %s@%s. %s", repo.Slug, branch.DisplayID, err)
+		return err
+	}
+	return nil
+}
+
+// DelHook removes a webhook through the API, along with the required
+// LGTM build status.
+func (g *Bitbucket) DelHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	hook, err := g.getHook(client, repo, link)
+	if err != nil {
+		return err
+	} else if hook == nil {
+		return nil
+	}
+	err = client.DeleteHook(repo.Owner, repo.Name, hook.ID)
+	if err != nil {
+		return err
+	}
+
+	conditions, err := g.getConditions(client, repo)
+	if err != nil {
+		return nil
+	}
+	for _, condition := range conditions {
+		err := client.DeleteCondition(repo.Owner, repo.Name, condition.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getHook is a helper function that retrieves a hook by hostname. To do
+// this, it will retrieve a list of all hooks and iterate through the
+// list.
+func (g *Bitbucket) getHook(client *Client, repo *model.Repo, rawurl string) (*Hook, error) {
+	newurl, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Hook
+	err = Paginate(g.MaxPages, func(start int) (int, error) {
+		hooks, next, err := client.GetHooks(repo.Owner, repo.Name, start)
+		for _, hook := range hooks {
+			oldurl, err := url.Parse(hook.URL)
+			if err != nil {
+				continue
+			}
+			if found == nil && newurl.Host == oldurl.Host {
+				found = hook
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// getConditions is a helper function that retrieves the required builds
+// merge checks that only require the LGTM build status.
+func (g *Bitbucket) getConditions(client *Client, repo *model.Repo) ([]*Condition, error) {
+	var conditions []*Condition
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetConditions(repo.Owner, repo.Name, start)
+		for _, condition := range list {
+			if len(condition.BuildParentKeys) == 1 && condition.BuildParentKeys[0] == contextName {
+				conditions = append(conditions, condition)
+			}
+		}
+		return next, err
+	})
+	return conditions, err
+}
+
+// GetComments retrieves comments from the API.
+func (g *Bitbucket) GetComments(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Comment, error) {
+	activities, err := g.getActivities(u, r, num)
+	if err != nil {
+		return nil, err
+	}
+
+	// activities are listed from newest to oldest.
+	comments := []*model.Comment{}
+	for i := len(activities) - 1; i >= 0; i-- {
+		activity := activities[i]
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil {
+			continue
+		}
+		comments = append(comments, &model.Comment{
+			ID:      activity.Comment.ID,
+			Author:  activity.Comment.Author.Name,
+			Body:    activity.Comment.Text,
+			Created: toTime(activity.Comment.CreatedDate),
+		})
+	}
+	return comments, nil
+}
+
+// GetReviews retrieves reviews from the API. The review status of each
+// reviewer and participant is returned as a review, submitted at the
+// time of the most recent review activity of the user.
+func (g *Bitbucket) GetReviews(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Review, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := g.getActivities(u, r, num)
+	if err != nil {
+		return nil, err
+	}
+
+	// activities are listed from newest to oldest.
+	reviewed := map[string]*Activity{}
+	for _, activity := range activities {
+		if activity.Action != "APPROVED" && activity.Action != "REVIEWED" && activity.Action != "UNAPPROVED" {
+			continue
+		}
+		if _, ok := reviewed[activity.User.Name]; !ok {
+			reviewed[activity.User.Name] = activity
+		}
+	}
+
+	reviews := []*model.Review{}
+	for _, participant := range append(pr.Reviewers, pr.Participants...) {
+		state := toReviewState(participant.Status)
+		if len(state) == 0 {
+			continue
+		}
+		review := &model.Review{
+			Author:   participant.User.Name,
+			State:    state,
+			CommitID: participant.LastReviewedCommit,
+		}
+		if activity, ok := reviewed[review.Author]; ok {
+			review.ID = activity.ID
+			review.Submitted = toTime(activity.CreatedDate)
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// getActivities is a helper function that retrieves the pull request
+// activities, from newest to oldest.
+func (g *Bitbucket) getActivities(u *model.User, r *model.Repo, num int) ([]*Activity, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var activities []*Activity
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetActivities(r.Owner, r.Name, num, start)
+		activities = append(activities, list...)
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// GetFiles retrieves the pull request changed files from the API.
+func (g *Bitbucket) GetFiles(c context.Context, u *model.User, r *model.Repo, num int) ([]string, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var files []string
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		changes, next, err := client.GetChanges(r.Owner, r.Name, num, start)
+		for _, change := range changes {
+			files = append(files, change.Path.ToString)
+			// renamed files also change the previous path
+			if change.SrcPath != nil && change.SrcPath.ToString != change.Path.ToString {
+				files = append(files, change.SrcPath.ToString)
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetHeadCommit retrieves the pull request head commit from the API.
+func (g *Bitbucket) GetHeadCommit(c context.Context, u *model.User, r *model.Repo, num int) (*model.Commit, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := client.GetCommit(r.Owner, r.Name, pr.FromRef.LatestCommit)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Commit{
+		SHA:     commit.ID,
+		Created: toTime(commit.CommitterTimestamp),
+	}, nil
+}
+
+// GetContents retrieves a file at the ref from the API.
+func (g *Bitbucket) GetContents(c context.Context, u *model.User, r *model.Repo, path, ref string) ([]byte, error) {
+	client := NewClientToken(g.URL, u.Token)
+	return client.GetRaw(r.Owner, r.Name, path, ref)
+}
+
+// GetPull retrieves a pull request from the API.
+func (g *Bitbucket) GetPull(c context.Context, u *model.User, r *model.Repo, num int) (*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	return toIssue(pr), nil
+}
+
+// GetPulls retrieves the open pull requests from the API.
+func (g *Bitbucket) GetPulls(c context.Context, u *model.User, r *model.Repo) ([]*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pulls := []*model.Issue{}
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetPulls(r.Owner, r.Name, start)
+		for _, pr := range list {
+			pulls = append(pulls, toIssue(pr))
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pulls, nil
+}
+
+// GetStatus retrieves the approval status of the commit from the API.
+func (g *Bitbucket) GetStatus(c context.Context, u *model.User, r *model.Repo, sha string) (*model.Status, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var latest *Status
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetStatuses(sha, start)
+		for _, s := range list {
+			if s.Key == contextName && (latest == nil || s.DateAdded > latest.DateAdded) {
+				latest = s
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := new(model.Status)
+	if latest != nil {
+		status.State = fromState(latest.State)
+		status.Desc = latest.Desc
+	}
+	return status, nil
+}
+
+// IsProtected checks if the branch is protected from the API. A branch
+// is protected if it has any branch permissions.
+func (g *Bitbucket) IsProtected(c context.Context, u *model.User, r *model.Repo, branch string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	restrictions, _, err := client.GetRestrictions(r.Owner, r.Name, branch, 0)
+	if err != nil {
+		return false, err
+	}
+	return len(restrictions) != 0, nil
+}
+
+// IsMerged checks if the commit was merged through a pull request from
+// the API.
+func (g *Bitbucket) IsMerged(c context.Context, u *model.User, r *model.Repo, sha string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var merged bool
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		pulls, next, err := client.GetCommitPulls(r.Owner, r.Name, sha, start)
+		for _, pr := range pulls {
+			if pr.State == "MERGED" {
+				merged = true
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return false, err
+	}
+	return merged, nil
+}
+
+// GetAdmins retrieves the repository administrators from the API.
+func (g *Bitbucket) GetAdmins(c context.Context, u *model.User, r *model.Repo) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var admins []*model.Member
+	err := Paginate(g.MaxPages, func(start int) (int, error) {
+		list, next, err := client.GetUserPermissions(r.Owner, r.Name, start)
+		for _, perm := range list {
+			if perm.Permission != permAdmin {
+				continue
+			}
+			admins = append(admins, &model.Member{
+				Login: perm.User.Name,
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return admins, nil
+}
+
+// SetStatus sets the pull request status through the API, as a build
+// status of the head commit.
+func (g *Bitbucket) SetStatus(c context.Context, u *model.User, r *model.Repo, num int, status *model.Status) error {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return err
+	}
+	return client.CreateStatus(pr.FromRef.LatestCommit, &Status{
+		State: toState(status.State),
+		Key:   contextName,
+		Name:  "LGTM",
+		URL:   fmt.Sprintf("%s/projects/%s/repos/%s/pull-requests/%d", g.URL, r.Owner, r.Name, num),
+		Desc:  status.Desc,
+	})
+}
+
+// GetHook gets a webhook from the API.
+func (g *Bitbucket) GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
+	event := r.Header.Get("X-Event-Key")
+
+	// only process pull request events that require the status to be
+	// set or recalculated, and merges.
+	switch event {
+	case "pr:opened", "pr:from_ref_updated", "pr:comment:added", "pr:merged",
+		"pr:reviewer:approved", "pr:reviewer:unapproved", "pr:reviewer:needs_work":
+	default:
+		return nil, nil
+	}
+
+	data := prHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+	pr := &data.PullRequest
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Request-Id")
+	hook.Event = event
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = pr.ToRef.Repository.Project.Key
+	hook.Repo.Name = pr.ToRef.Repository.Slug
+	hook.Repo.Slug = hook.Repo.Owner + "/" + hook.Repo.Name
+
+	if event == "pr:merged" {
+		hook.Merge = new(model.Merge)
+		hook.Merge.Number = pr.ID
+		hook.Merge.Title = pr.Title
+		hook.Merge.Author = pr.Author.User.Name
+		hook.Merge.Base = pr.ToRef.DisplayID
+		hook.Merge.SHA = pr.Properties.MergeCommit.ID
+		hook.Merge.HeadSHA = pr.FromRef.LatestCommit
+		hook.Merge.MergedBy = data.Actor.Name
+		hook.Merge.Merged = toTime(pr.ClosedDate).Unix()
+		return hook, nil
+	}
+
+	hook.Issue = toIssue(pr)
+	hook.Comment = new(model.Comment)
+	if data.Comment != nil {
+		hook.Comment.ID = data.Comment.ID
+		hook.Comment.Body = data.Comment.Text
+		hook.Comment.Author = data.Comment.Author.Name
+	}
+	hook.Review = new(model.Review)
+	if data.Participant != nil {
+		hook.Review.Author = data.Participant.User.Name
+		hook.Review.State = toReviewState(data.Participant.Status)
+		hook.Review.CommitID = data.Participant.LastReviewedCommit
+	}
+	return hook, nil
+}
+
+// toRepo is a helper function that converts the repository. The owner
+// is the project key.
+func toRepo(repo *Repo) *model.Repo {
+	return &model.Repo{
+		Owner:   repo.Project.Key,
+		Name:    repo.Slug,
+		Slug:    repo.Project.Key + "/" + repo.Slug,
+		Link:    repo.Link(),
+		Private: !repo.Public,
+	}
+}
+
+// toIssue is a helper function that converts the pull request to an
+// issue.
+func toIssue(pr *PullRequest) *model.Issue {
+	return &model.Issue{
+		Number: pr.ID,
+		Title:  pr.Title,
+		Author: pr.Author.User.Name,
+		Base:   pr.ToRef.DisplayID,
+	}
+}
+
+// toReviewState is a helper function that converts the participant
+// status to the review state. Participants that did not review return
+// an empty state.
+func toReviewState(status string) string {
+	switch status {
+	case "APPROVED":
+		return "APPROVED"
+	case "NEEDS_WORK":
+		return "CHANGES_REQUESTED"
+	}
+	return ""
+}
+
+// toState is a helper function that converts the status state to the
+// build state.
+func toState(state string) string {
+	switch state {
+	case model.StatusSuccess:
+		return stateSuccessful
+	case model.StatusFailure, model.StatusError:
+		return stateFailed
+	}
+	return stateInProgress
+}
+
+// fromState is a helper function that converts the build state to the
+// status state.
+func fromState(state string) string {
+	switch state {
+	case stateSuccessful:
+		return model.StatusSuccess
+	case stateFailed:
+		return model.StatusFailure
+	}
+	return model.StatusPending
+}
@@ -0,0 +1,327 @@
+package bitbucketserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/franela/goblin"
+	"golang.org/x/net/context"
+)
+
+func TestBitbucket(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Bitbucket", func() {
+		var server *httptest.Server
+		var remote *Bitbucket
+		var api *fakeBitbucket
+
+		g.BeforeEach(func() {
+			api = &fakeBitbucket{}
+			server = httptest.NewServer(api)
+			remote = &Bitbucket{URL: server.URL}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("Should login with a personal access token", func() {
+			r, _ := http.NewRequest("GET", "/login", nil)
+			r.SetBasicAuth("octocat", "cfcd2084")
+			user, err := remote.GetUser(context.Background(), httptest.NewRecorder(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(user.Login).Equal("octocat")
+			g.Assert(user.Token).Equal("cfcd2084")
+			g.Assert(user.Avatar).Equal(server.URL + "/users/octocat/avatar.png?s=64")
+		})
+
+		g.It("Should prompt for a personal access token", func() {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/login", nil)
+			user, err := remote.GetUser(context.Background(), w, r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(user == nil).IsTrue()
+			g.Assert(w.Code).Equal(401)
+			g.Assert(w.Header().Get("WWW-Authenticate") != "").IsTrue()
+		})
+
+		g.It("Should get comments from all pages", func() {
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(2)
+			g.Assert(comments[0].ID).Equal(int64(11))
+			g.Assert(comments[0].Author).Equal("tboerger")
+			g.Assert(comments[1].Body).Equal("LGTM")
+			g.Assert(comments[1].Created.Unix()).Equal(int64(1592218800))
+		})
+
+		g.It("Should fail at the page limit", func() {
+			remote.MaxPages = 1
+			_, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should get the last page at the page limit", func() {
+			remote.MaxPages = 2
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(2)
+		})
+
+		g.It("Should get participant states as reviews", func() {
+			reviews, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(reviews)).Equal(2)
+			g.Assert(reviews[0].ID).Equal(int64(104))
+			g.Assert(reviews[0].Author).Equal("bradrydzewski")
+			g.Assert(reviews[0].State).Equal("APPROVED")
+			g.Assert(reviews[0].CommitID).Equal("a1b2c3d4e5f6")
+			g.Assert(reviews[0].Submitted.Unix()).Equal(int64(1592222400))
+			g.Assert(reviews[1].Author).Equal("tboerger")
+			g.Assert(reviews[1].State).Equal("CHANGES_REQUESTED")
+		})
+
+		g.It("Should get the pull request", func() {
+			issue, err := remote.GetPull(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(issue.Number).Equal(1)
+			g.Assert(issue.Author).Equal("octocat")
+			g.Assert(issue.Base).Equal("master")
+		})
+
+		g.It("Should get the head commit", func() {
+			commit, err := remote.GetHeadCommit(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(commit.SHA).Equal("a1b2c3d4e5f6")
+			g.Assert(commit.Created.Unix()).Equal(int64(1592215200))
+		})
+
+		g.It("Should get the changed files", func() {
+			files, err := remote.GetFiles(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(files).Equal([]string{"README.md", "docs/README.md", "MAINTAINERS"})
+		})
+
+		g.It("Should get the raw file contents", func() {
+			file, err := remote.GetContents(context.Background(), fakeUser, fakeRepo, "docs/MAINTAINERS", "refs/heads/master")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(string(file)).Equal("octocat\n")
+		})
+
+		g.It("Should get the repository with the project as owner", func() {
+			repo, err := remote.GetRepo(context.Background(), fakeUser, "OCTO", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(repo.Owner).Equal("OCTO")
+			g.Assert(repo.Name).Equal("hello-world")
+			g.Assert(repo.Slug).Equal("OCTO/hello-world")
+			g.Assert(repo.Private).IsTrue()
+		})
+
+		g.It("Should get the permissions from the repository lists", func() {
+			perm, err := remote.GetPerm(context.Background(), fakeUser, "OCTO", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(perm.Admin).IsTrue()
+			g.Assert(perm.Push).IsFalse()
+			g.Assert(perm.Pull).IsTrue()
+		})
+
+		g.It("Should get the repository administrators", func() {
+			admins, err := remote.GetAdmins(context.Background(), fakeUser, fakeRepo)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(admins)).Equal(1)
+			g.Assert(admins[0].Login).Equal("octocat")
+		})
+
+		g.It("Should set the build status of the head commit", func() {
+			err := remote.SetStatus(context.Background(), fakeUser, fakeRepo, 1, &model.Status{State: "failure", Desc: "needs approval"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.statusSHA).Equal("a1b2c3d4e5f6")
+			g.Assert(api.status.State).Equal("FAILED")
+			g.Assert(api.status.Key).Equal(contextName)
+			g.Assert(api.status.Desc).Equal("needs approval")
+			g.Assert(api.status.URL).Equal(server.URL + "/projects/OCTO/repos/hello-world/pull-requests/1")
+		})
+
+		g.It("Should get the most recent build status of the commit", func() {
+			status, err := remote.GetStatus(context.Background(), fakeUser, fakeRepo, "a1b2c3d4e5f6")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(status.State).Equal("success")
+			g.Assert(status.Desc).Equal("approved")
+		})
+
+		g.It("Should check if the commit was merged", func() {
+			merged, err := remote.IsMerged(context.Background(), fakeUser, fakeRepo, "a1b2c3d4e5f6")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(merged).IsTrue()
+		})
+
+		g.It("Should replace the hook and require the build status", func() {
+			err := remote.SetHook(context.Background(), fakeUser, fakeRepo, "http://lgtm.example.com/hook")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.deleted).Equal([]string{"/rest/api/1.0/projects/OCTO/repos/hello-world/webhooks/7"})
+			g.Assert(api.hook.URL).Equal("http://lgtm.example.com/hook")
+			g.Assert(api.hook.Configuration["secret"]).Equal("9f2a4c")
+			g.Assert(len(api.hook.Events)).Equal(7)
+			g.Assert(api.condition.BuildParentKeys).Equal([]string{contextName})
+			g.Assert(api.condition.RefMatcher.ID).Equal("refs/heads/master")
+		})
+	})
+}
+
+func TestHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Hook", func() {
+		var remote = new(Bitbucket)
+
+		g.It("Should return the pull request of a comment", func() {
+			r := fakeHook("pr:comment:added", "hook_comment.json")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Delivery).Equal("e9a1c6f2")
+			g.Assert(hook.Repo.Owner).Equal("OCTO")
+			g.Assert(hook.Repo.Name).Equal("hello-world")
+			g.Assert(hook.Repo.Slug).Equal("OCTO/hello-world")
+			g.Assert(hook.Issue.Number).Equal(1)
+			g.Assert(hook.Issue.Author).Equal("octocat")
+			g.Assert(hook.Issue.Base).Equal("master")
+			g.Assert(hook.Comment.ID).Equal(int64(12))
+			g.Assert(hook.Comment.Body).Equal("LGTM")
+			g.Assert(hook.Comment.Author).Equal("bradrydzewski")
+		})
+
+		g.It("Should return the pull request of an approval", func() {
+			r := fakeHook("pr:reviewer:approved", "hook_approved.json")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue.Number).Equal(1)
+			g.Assert(hook.Review.Author).Equal("bradrydzewski")
+			g.Assert(hook.Review.State).Equal("APPROVED")
+			g.Assert(hook.Review.CommitID).Equal("a1b2c3d4e5f6")
+		})
+
+		g.It("Should return the merge of a merged pull request", func() {
+			r := fakeHook("pr:merged", "hook_merged.json")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue == nil).IsTrue()
+			g.Assert(hook.Merge.Number).Equal(1)
+			g.Assert(hook.Merge.Author).Equal("octocat")
+			g.Assert(hook.Merge.SHA).Equal("9c4f1e2d3b4a5c6d")
+			g.Assert(hook.Merge.HeadSHA).Equal("a1b2c3d4e5f6")
+			g.Assert(hook.Merge.MergedBy).Equal("bradrydzewski")
+			g.Assert(hook.Merge.Merged).Equal(int64(1592222400))
+		})
+
+		g.It("Should ignore other events", func() {
+			r := fakeHook("repo:refs_changed", "hook_comment.json")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+// fakeBitbucket is a fake of the Bitbucket Server API, which serves the
+// recorded responses in testdata and records the statuses, hooks and
+// required builds written to it.
+type fakeBitbucket struct {
+	status    Status
+	statusSHA string
+	hook      Hook
+	condition Condition
+	deleted   []string
+}
+
+func (f *fakeBitbucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const repo = "/rest/api/1.0/projects/OCTO/repos/hello-world"
+
+	switch path := r.URL.Path; {
+	case r.Method == "DELETE":
+		f.deleted = append(f.deleted, path)
+		w.WriteHeader(204)
+	case path == "/plugins/servlet/applinks/whoami":
+		w.Write([]byte("octocat"))
+	case path == "/rest/api/1.0/users/octocat":
+		fakeFixture(w, "user.json")
+	case path == "/rest/api/1.0/repos" && r.FormValue("name") == "Hello World":
+		switch r.FormValue("permission") {
+		case permAdmin:
+			fakeFixture(w, "repos_admin.json")
+		case permWrite:
+			fakeFixture(w, "repos_write.json")
+		}
+	case path == repo:
+		fakeFixture(w, "repo.json")
+	case path == repo+"/branches/default":
+		fakeFixture(w, "default_branch.json")
+	case path == repo+"/permissions/users":
+		fakeFixture(w, "permissions.json")
+	case path == repo+"/raw/docs/MAINTAINERS" && r.FormValue("at") == "refs/heads/master":
+		w.Write([]byte("octocat\n"))
+	case path == repo+"/commits/a1b2c3d4e5f6":
+		fakeFixture(w, "commit.json")
+	case path == repo+"/commits/a1b2c3d4e5f6/pull-requests":
+		fakeFixture(w, "commit_pulls.json")
+	case path == repo+"/pull-requests/1":
+		fakeFixture(w, "pull.json")
+	case path == repo+"/pull-requests/1/activities":
+		fakeFixture(w, "activities_"+r.FormValue("start")+".json")
+	case path == repo+"/pull-requests/1/changes":
+		fakeFixture(w, "changes.json")
+	case path == repo+"/webhooks" && r.Method == "POST":
+		json.NewDecoder(r.Body).Decode(&f.hook)
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	case path == repo+"/webhooks":
+		fakeFixture(w, "hooks.json")
+	case path == "/rest/build-status/1.0/commits/a1b2c3d4e5f6" && r.Method == "POST":
+		f.statusSHA = "a1b2c3d4e5f6"
+		json.NewDecoder(r.Body).Decode(&f.status)
+		w.WriteHeader(204)
+	case path == "/rest/build-status/1.0/commits/a1b2c3d4e5f6":
+		fakeFixture(w, "statuses.json")
+	case path == "/rest/required-builds/latest/projects/OCTO/repos/hello-world/conditions":
+		fakeFixture(w, "conditions.json")
+	case path == "/rest/required-builds/latest/projects/OCTO/repos/hello-world/condition" && r.Method == "POST":
+		json.NewDecoder(r.Body).Decode(&f.condition)
+		w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(404)
+		w.Write([]byte(`{"errors":[{"message":"Not Found"}]}`))
+	}
+}
+
+// fakeFixture writes the recorded response in the testdata file.
+func fakeFixture(w http.ResponseWriter, name string) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if os.IsNotExist(err) {
+		w.WriteHeader(404)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// fakeHook returns a webhook request with the payload of the testdata
+// file.
+func fakeHook(event, name string) *http.Request {
+	file, _ := os.Open(filepath.Join("testdata", name))
+	r, _ := http.NewRequest("POST", "/hook", file)
+	r.Header.Set("X-Event-Key", event)
+	r.Header.Set("X-Request-Id", "e9a1c6f2")
+	return r
+}
+
+var (
+	fakeUser = &model.User{Login: "octocat", Token: "cfcd2084"}
+	fakeRepo = &model.Repo{Owner: "OCTO", Name: "hello-world", Slug: "OCTO/hello-world", Secret: "9f2a4c"}
+)
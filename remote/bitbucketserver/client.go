@@ -0,0 +1,395 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	pathWhoami       = "%s/plugins/servlet/applinks/whoami"
+	pathUser         = "%s/rest/api/1.0/users/%s"
+	pathProjects     = "%s/rest/api/1.0/projects?start=%d&limit=%d"
+	pathGroupMembers = "%s/rest/api/1.0/admin/groups/more-members?context=%s&start=%d&limit=%d"
+	pathRepos        = "%s/rest/api/1.0/repos?name=%s&permission=%s&start=%d&limit=%d"
+	pathRepo         = "%s/rest/api/1.0/projects/%s/repos/%s"
+	pathDefault      = "%s/rest/api/1.0/projects/%s/repos/%s/branches/default"
+	pathUserPerms    = "%s/rest/api/1.0/projects/%s/repos/%s/permissions/users?start=%d&limit=%d"
+	pathRaw          = "%s/rest/api/1.0/projects/%s/repos/%s/raw/%s?at=%s"
+	pathCommit       = "%s/rest/api/1.0/projects/%s/repos/%s/commits/%s"
+	pathCommitPulls  = "%s/rest/api/1.0/projects/%s/repos/%s/commits/%s/pull-requests?start=%d&limit=%d"
+	pathPulls        = "%s/rest/api/1.0/projects/%s/repos/%s/pull-requests?state=OPEN&start=%d&limit=%d"
+	pathPull         = "%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d"
+	pathActivities   = "%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?start=%d&limit=%d"
+	pathChanges      = "%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/changes?start=%d&limit=%d"
+	pathHooks        = "%s/rest/api/1.0/projects/%s/repos/%s/webhooks?start=%d&limit=%d"
+	pathHook         = "%s/rest/api/1.0/projects/%s/repos/%s/webhooks/%d"
+	pathHookCreate   = "%s/rest/api/1.0/projects/%s/repos/%s/webhooks"
+	pathStatuses     = "%s/rest/build-status/1.0/commits/%s?start=%d&limit=%d"
+	pathStatus       = "%s/rest/build-status/1.0/commits/%s"
+	pathRestrictions = "%s/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions?matcherType=BRANCH&matcherId=%s&start=%d&limit=%d"
+	pathConditions   = "%s/rest/required-builds/latest/projects/%s/repos/%s/conditions?start=%d&limit=%d"
+	pathCondition    = "%s/rest/required-builds/latest/projects/%s/repos/%s/condition/%d"
+	pathConditionAdd = "%s/rest/required-builds/latest/projects/%s/repos/%s/condition"
+)
+
+// perPage is the number of items requested per page.
+const perPage = 100
+
+// Paginate is a helper function that calls the list function for each
+// page of results, until the last page is retrieved. The list function
+// returns the start of the next page, or -1 for the last page. If the
+// limit is greater than zero, at most limit pages are retrieved, and an
+// error is returned if more pages remain.
+func Paginate(limit int, list func(start int) (int, error)) error {
+	for start, pages := 0, 0; start >= 0; pages++ {
+		if limit > 0 && pages == limit {
+			return fmt.Errorf("Reached the limit of %d pages. Remaining results are not retrieved.", limit)
+		}
+		next, err := list(start)
+		if err != nil {
+			return err
+		}
+		start = next
+	}
+	return nil
+}
+
+// Client represents the simple HTTP client for the Bitbucket Server API.
+type Client struct {
+	client *http.Client
+	base   string // base url
+}
+
+// NewClient returns a client at the specified url.
+func NewClient(uri string) *Client {
+	return &Client{http.DefaultClient, strings.TrimSuffix(uri, "/")}
+}
+
+// NewClientToken returns a client at the specified url that
+// authenticates all outbound requests with the given personal access
+// token.
+func NewClientToken(uri, token string) *Client {
+	config := new(oauth2.Config)
+	auther := config.Client(oauth2.NoContext, &oauth2.Token{AccessToken: token})
+	return &Client{auther, strings.TrimSuffix(uri, "/")}
+}
+
+// SetClient sets the default http client. This should be
+// used in conjunction with golang.org/x/oauth2 to
+// authenticate requests to the server.
+func (c *Client) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// Whoami retrieves the name of the currently authenticated user.
+func (c *Client) Whoami() (string, error) {
+	uri := fmt.Sprintf(pathWhoami, c.base)
+	body, err := c.stream(uri, "GET", nil)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	name, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(name)) == 0 {
+		return "", fmt.Errorf("Invalid or missing access token")
+	}
+	return string(bytes.TrimSpace(name)), nil
+}
+
+// GetUser retrieves a user.
+func (c *Client) GetUser(slug string) (*User, error) {
+	out := new(User)
+	uri := fmt.Sprintf(pathUser, c.base, url.PathEscape(slug))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetProjects retrieves a page of the projects the currently
+// authenticated user can browse.
+func (c *Client) GetProjects(start int) ([]*Project, int, error) {
+	var out []*Project
+	uri := fmt.Sprintf(pathProjects, c.base, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetGroupMembers retrieves a page of the group members.
+func (c *Client) GetGroupMembers(group string, start int) ([]*User, int, error) {
+	var out []*User
+	uri := fmt.Sprintf(pathGroupMembers, c.base, url.QueryEscape(group), start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetRepos retrieves a page of the repositories with the name, or of
+// all repositories if empty, that the currently authenticated user has
+// the permission for.
+func (c *Client) GetRepos(name, permission string, start int) ([]*Repo, int, error) {
+	var out []*Repo
+	uri := fmt.Sprintf(pathRepos, c.base, url.QueryEscape(name), permission, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetRepo retrieves a repository.
+func (c *Client) GetRepo(project, slug string) (*Repo, error) {
+	out := new(Repo)
+	uri := fmt.Sprintf(pathRepo, c.base, project, slug)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetDefaultBranch retrieves the default branch of the repository.
+func (c *Client) GetDefaultBranch(project, slug string) (*Branch, error) {
+	out := new(Branch)
+	uri := fmt.Sprintf(pathDefault, c.base, project, slug)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetUserPermissions retrieves a page of the users granted a permission
+// on the repository.
+func (c *Client) GetUserPermissions(project, slug string, start int) ([]*UserPermission, int, error) {
+	var out []*UserPermission
+	uri := fmt.Sprintf(pathUserPerms, c.base, project, slug, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetRaw retrieves the raw contents of a file at the ref.
+func (c *Client) GetRaw(project, slug, path, ref string) ([]byte, error) {
+	uri := fmt.Sprintf(pathRaw, c.base, project, slug, path, url.QueryEscape(ref))
+	body, err := c.stream(uri, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// GetCommit retrieves a commit.
+func (c *Client) GetCommit(project, slug, sha string) (*Commit, error) {
+	out := new(Commit)
+	uri := fmt.Sprintf(pathCommit, c.base, project, slug, sha)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetCommitPulls retrieves a page of the pull requests that include the
+// commit.
+func (c *Client) GetCommitPulls(project, slug, sha string, start int) ([]*PullRequest, int, error) {
+	var out []*PullRequest
+	uri := fmt.Sprintf(pathCommitPulls, c.base, project, slug, sha, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetPulls retrieves a page of the open pull requests.
+func (c *Client) GetPulls(project, slug string, start int) ([]*PullRequest, int, error) {
+	var out []*PullRequest
+	uri := fmt.Sprintf(pathPulls, c.base, project, slug, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetPull retrieves a pull request.
+func (c *Client) GetPull(project, slug string, id int) (*PullRequest, error) {
+	out := new(PullRequest)
+	uri := fmt.Sprintf(pathPull, c.base, project, slug, id)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetActivities retrieves a page of the pull request activities, from
+// newest to oldest.
+func (c *Client) GetActivities(project, slug string, id, start int) ([]*Activity, int, error) {
+	var out []*Activity
+	uri := fmt.Sprintf(pathActivities, c.base, project, slug, id, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetChanges retrieves a page of the pull request changed files.
+func (c *Client) GetChanges(project, slug string, id, start int) ([]*Change, int, error) {
+	var out []*Change
+	uri := fmt.Sprintf(pathChanges, c.base, project, slug, id, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetHooks retrieves a page of the repository webhooks.
+func (c *Client) GetHooks(project, slug string, start int) ([]*Hook, int, error) {
+	var out []*Hook
+	uri := fmt.Sprintf(pathHooks, c.base, project, slug, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateHook creates a repository webhook.
+func (c *Client) CreateHook(project, slug string, in *Hook) error {
+	uri := fmt.Sprintf(pathHookCreate, c.base, project, slug)
+	return c.post(uri, in, nil)
+}
+
+// DeleteHook deletes a repository webhook.
+func (c *Client) DeleteHook(project, slug string, id int64) error {
+	uri := fmt.Sprintf(pathHook, c.base, project, slug, id)
+	return c.delete(uri)
+}
+
+// GetStatuses retrieves a page of the build statuses of the commit.
+func (c *Client) GetStatuses(sha string, start int) ([]*Status, int, error) {
+	var out []*Status
+	uri := fmt.Sprintf(pathStatuses, c.base, sha, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateStatus creates a build status of the commit.
+func (c *Client) CreateStatus(sha string, in *Status) error {
+	uri := fmt.Sprintf(pathStatus, c.base, sha)
+	return c.post(uri, in, nil)
+}
+
+// GetRestrictions retrieves a page of the permissions of the branch.
+func (c *Client) GetRestrictions(project, slug, branch string, start int) ([]*Restriction, int, error) {
+	var out []*Restriction
+	uri := fmt.Sprintf(pathRestrictions, c.base, project, slug, url.QueryEscape("refs/heads/"+branch), start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetConditions retrieves a page of the required builds merge checks.
+func (c *Client) GetConditions(project, slug string, start int) ([]*Condition, int, error) {
+	var out []*Condition
+	uri := fmt.Sprintf(pathConditions, c.base, project, slug, start, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateCondition creates a required builds merge check.
+func (c *Client) CreateCondition(project, slug string, in *Condition) error {
+	uri := fmt.Sprintf(pathConditionAdd, c.base, project, slug)
+	return c.post(uri, in, nil)
+}
+
+// DeleteCondition deletes a required builds merge check.
+func (c *Client) DeleteCondition(project, slug string, id int64) error {
+	uri := fmt.Sprintf(pathCondition, c.base, project, slug, id)
+	return c.delete(uri)
+}
+
+//
+// http request helper functions
+//
+
+// helper function for making an http GET request.
+func (c *Client) get(rawurl string, out interface{}) error {
+	return c.do(rawurl, "GET", nil, out)
+}
+
+// helper function for making an http GET request of a paginated list,
+// which returns the start of the next page, or -1 for the last page.
+func (c *Client) list(rawurl string, out interface{}) (int, error) {
+	var data = struct {
+		page
+		Values interface{} `json:"values"`
+	}{Values: out}
+	if err := c.get(rawurl, &data); err != nil {
+		return -1, err
+	}
+	if data.IsLastPage {
+		return -1, nil
+	}
+	return data.NextPageStart, nil
+}
+
+// helper function for making an http POST request.
+func (c *Client) post(rawurl string, in, out interface{}) error {
+	return c.do(rawurl, "POST", in, out)
+}
+
+// helper function for making an http DELETE request.
+func (c *Client) delete(rawurl string) error {
+	return c.do(rawurl, "DELETE", nil, nil)
+}
+
+// helper function to make an http request
+func (c *Client) do(rawurl, method string, in, out interface{}) error {
+	// executes the http request and returns the body as
+	// and io.ReadCloser
+	body, err := c.stream(rawurl, method, in)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// if a json response is expected, parse and return
+	// the json response.
+	if out != nil {
+		return json.NewDecoder(body).Decode(out)
+	}
+	return nil
+}
+
+// helper function to stream an http request
+func (c *Client) stream(rawurl, method string, in interface{}) (io.ReadCloser, error) {
+	uri, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	// if we are posting or putting data, we need to
+	// write it to the body of the request.
+	var buf io.ReadWriter
+	if in != nil {
+		buf = new(bytes.Buffer)
+		err := json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// creates a new http request to bitbucket.
+	req, err := http.NewRequest(method, uri.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode > http.StatusPartialContent {
+		defer resp.Body.Close()
+		out, _ := ioutil.ReadAll(resp.Body)
+		apiErr := &Error{Status: resp.StatusCode, message: string(out)}
+		if json.Unmarshal(out, apiErr) == nil && len(apiErr.Errors) != 0 {
+			apiErr.message = apiErr.Errors[0].Message
+		}
+		return nil, apiErr
+	}
+	return resp.Body, nil
+}
+
+// isNotFound is a helper function that checks if the error is an API
+// error for a missing resource.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,208 @@
+package bitbucketserver
+
+import "time"
+
+// Error represents an API error.
+type Error struct {
+	Status int `json:"-"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+
+	message string
+}
+
+func (e *Error) Error() string  { return e.message }
+func (e *Error) String() string { return e.message }
+
+// Build states of the build status API.
+const (
+	stateInProgress = "INPROGRESS"
+	stateSuccessful = "SUCCESSFUL"
+	stateFailed     = "FAILED"
+)
+
+// Repository permissions of the users.
+const (
+	permRead  = "REPO_READ"
+	permWrite = "REPO_WRITE"
+	permAdmin = "REPO_ADMIN"
+)
+
+// page represents a page of a paginated list.
+type page struct {
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// User represents a Bitbucket Server user.
+type User struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	DisplayName string `json:"displayName"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+// Project represents a Bitbucket Server project.
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Repo represents a Bitbucket Server repository.
+type Repo struct {
+	Slug    string  `json:"slug"`
+	Name    string  `json:"name"`
+	Public  bool    `json:"public"`
+	Project Project `json:"project"`
+	Links   struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// Link returns the web link of the repository.
+func (r *Repo) Link() string {
+	if len(r.Links.Self) == 0 {
+		return ""
+	}
+	return r.Links.Self[0].Href
+}
+
+// Branch represents the default branch of a repository.
+type Branch struct {
+	ID        string `json:"id"`
+	DisplayID string `json:"displayId"`
+}
+
+// UserPermission represents the repository permission of a user.
+type UserPermission struct {
+	User       User   `json:"user"`
+	Permission string `json:"permission"`
+}
+
+// Ref represents the source or target ref of a pull request.
+type Ref struct {
+	ID           string `json:"id"`
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+	Repository   struct {
+		Slug    string  `json:"slug"`
+		Project Project `json:"project"`
+	} `json:"repository"`
+}
+
+// Participant represents a reviewer or participant of a pull request.
+type Participant struct {
+	User               User   `json:"user"`
+	Status             string `json:"status"`
+	LastReviewedCommit string `json:"lastReviewedCommit"`
+}
+
+// PullRequest represents a pull request.
+type PullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Author struct {
+		User User `json:"user"`
+	} `json:"author"`
+	FromRef      Ref            `json:"fromRef"`
+	ToRef        Ref            `json:"toRef"`
+	Reviewers    []*Participant `json:"reviewers"`
+	Participants []*Participant `json:"participants"`
+	ClosedDate   int64          `json:"closedDate"`
+	Properties   struct {
+		MergeCommit struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+// Comment represents a pull request comment.
+type Comment struct {
+	ID          int64  `json:"id"`
+	Text        string `json:"text"`
+	Author      User   `json:"author"`
+	CreatedDate int64  `json:"createdDate"`
+}
+
+// Activity represents a pull request activity, such as a comment or an
+// approval.
+type Activity struct {
+	ID            int64    `json:"id"`
+	Action        string   `json:"action"`
+	CommentAction string   `json:"commentAction"`
+	Comment       *Comment `json:"comment"`
+	User          User     `json:"user"`
+	CreatedDate   int64    `json:"createdDate"`
+}
+
+// Change represents a file changed by a pull request.
+type Change struct {
+	Path struct {
+		ToString string `json:"toString"`
+	} `json:"path"`
+	SrcPath *struct {
+		ToString string `json:"toString"`
+	} `json:"srcPath"`
+}
+
+// Commit represents a git commit.
+type Commit struct {
+	ID                 string `json:"id"`
+	CommitterTimestamp int64  `json:"committerTimestamp"`
+}
+
+// Status represents a build status of a commit.
+type Status struct {
+	State     string `json:"state"`
+	Key       string `json:"key"`
+	Name      string `json:"name,omitempty"`
+	URL       string `json:"url"`
+	Desc      string `json:"description"`
+	DateAdded int64  `json:"dateAdded,omitempty"`
+}
+
+// Restriction represents a branch permission.
+type Restriction struct {
+	ID int64 `json:"id"`
+}
+
+// Condition represents a required builds merge check.
+type Condition struct {
+	ID              int64    `json:"id,omitempty"`
+	BuildParentKeys []string `json:"buildParentKeys"`
+	RefMatcher      struct {
+		ID   string `json:"id"`
+		Type struct {
+			ID string `json:"id"`
+		} `json:"type"`
+	} `json:"refMatcher"`
+}
+
+// Hook represents a repository webhook.
+type Hook struct {
+	ID            int64             `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	URL           string            `json:"url"`
+	Events        []string          `json:"events"`
+	Active        bool              `json:"active"`
+	Configuration map[string]string `json:"configuration"`
+}
+
+// prHook represents a subset of the pull request event payloads.
+type prHook struct {
+	EventKey    string       `json:"eventKey"`
+	Actor       User         `json:"actor"`
+	PullRequest PullRequest  `json:"pullRequest"`
+	Comment     *Comment     `json:"comment"`
+	Participant *Participant `json:"participant"`
+}
+
+// toTime is a helper function that converts the milliseconds since the
+// epoch of the API to a time.
+func toTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -1,6 +1,11 @@
 package remote
 
-import "golang.org/x/net/context"
+import (
+	"fmt"
+
+	"github.com/go-gitea/lgtm/model"
+	"golang.org/x/net/context"
+)
 
 const key = "remote"
 
@@ -10,8 +15,61 @@ type Setter interface {
 }
 
 // FromContext returns the Remote client associated with this context.
+// If a Registry is associated with this context, the remote of the
+// current user is returned, or the default remote without a user.
 func FromContext(c context.Context) Remote {
-	return c.Value(key).(Remote)
+	registry, ok := c.Value(key).(*Registry)
+	if !ok {
+		return c.Value(key).(Remote)
+	}
+	if user, ok := c.Value("user").(*model.User); ok {
+		if remote := registry.Lookup(user.Remote); remote != nil {
+			return remote
+		}
+	}
+	return registry.Lookup("")
+}
+
+// FromHost returns the Remote client of the host. An empty host refers
+// to the default remote.
+func FromHost(c context.Context, host string) (Remote, error) {
+	registry, ok := c.Value(key).(*Registry)
+	if !ok {
+		return FromContext(c), nil
+	}
+	remote := registry.Lookup(host)
+	if remote == nil {
+		return nil, fmt.Errorf("Unknown remote %s", host)
+	}
+	return remote, nil
+}
+
+// FromRepo returns the Remote client of the repository.
+func FromRepo(c context.Context, repo *model.Repo) (Remote, error) {
+	return FromHost(c, repo.Remote)
+}
+
+// FromUser returns the Remote client of the user.
+func FromUser(c context.Context, user *model.User) (Remote, error) {
+	return FromHost(c, user.Remote)
+}
+
+// Host returns the host identifying the remote. An empty host refers to
+// the default remote.
+func Host(c context.Context, host string) string {
+	if registry, ok := c.Value(key).(*Registry); ok {
+		return registry.Host(host)
+	}
+	return host
+}
+
+// Hosts returns the hosts of the remotes associated with this context,
+// the default remote first.
+func Hosts(c context.Context) []string {
+	if registry, ok := c.Value(key).(*Registry); ok {
+		return registry.Hosts()
+	}
+	return nil
 }
 
 // ToContext adds the Remote client to this context if it supports
@@ -19,3 +77,9 @@ func FromContext(c context.Context) Remote {
 func ToContext(c Setter, client Remote) {
 	c.Set(key, client)
 }
+
+// RegistryToContext adds the Registry of remotes to this context if it
+// supports the Setter interface.
+func RegistryToContext(c Setter, registry *Registry) {
+	c.Set(key, registry)
+}
@@ -0,0 +1,423 @@
+package gitea
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	pathUser        = "%s/api/v1/user"
+	pathUserRepos   = "%s/api/v1/user/repos?page=%d&limit=%d"
+	pathUserOrgs    = "%s/api/v1/user/orgs?page=%d&limit=%d"
+	pathOrgTeams    = "%s/api/v1/orgs/%s/teams?page=%d&limit=%d"
+	pathTeamMembers = "%s/api/v1/teams/%d/members?page=%d&limit=%d"
+	pathRepo        = "%s/api/v1/repos/%s/%s"
+	pathRaw         = "%s/api/v1/repos/%s/%s/raw/%s?ref=%s"
+	pathCollabs     = "%s/api/v1/repos/%s/%s/collaborators?page=%d&limit=%d"
+	pathCollabPerm  = "%s/api/v1/repos/%s/%s/collaborators/%s/permission"
+	pathComments    = "%s/api/v1/repos/%s/%s/issues/%d/comments"
+	pathIssueLabels = "%s/api/v1/repos/%s/%s/issues/%d/labels"
+	pathIssueLabel  = "%s/api/v1/repos/%s/%s/issues/%d/labels/%d"
+	pathLabels      = "%s/api/v1/repos/%s/%s/labels?page=%d&limit=%d"
+	pathLabel       = "%s/api/v1/repos/%s/%s/labels"
+	pathPulls       = "%s/api/v1/repos/%s/%s/pulls?state=open&page=%d&limit=%d"
+	pathPull        = "%s/api/v1/repos/%s/%s/pulls/%d"
+	pathReviews     = "%s/api/v1/repos/%s/%s/pulls/%d/reviews?page=%d&limit=%d"
+	pathFiles       = "%s/api/v1/repos/%s/%s/pulls/%d/files?page=%d&limit=%d"
+	pathCommit      = "%s/api/v1/repos/%s/%s/git/commits/%s"
+	pathCommitPull  = "%s/api/v1/repos/%s/%s/commits/%s/pull"
+	pathCombined    = "%s/api/v1/repos/%s/%s/commits/%s/status"
+	pathStatus      = "%s/api/v1/repos/%s/%s/statuses/%s"
+	pathHooks       = "%s/api/v1/repos/%s/%s/hooks?page=%d&limit=%d"
+	pathHook        = "%s/api/v1/repos/%s/%s/hooks/%d"
+	pathHookCreate  = "%s/api/v1/repos/%s/%s/hooks"
+	pathBranch      = "%s/api/v1/repos/%s/%s/branches/%s"
+	pathProtection  = "%s/api/v1/repos/%s/%s/branch_protections/%s"
+	pathProtections = "%s/api/v1/repos/%s/%s/branch_protections"
+)
+
+// perPage is the number of items requested per page. Gitea caps list
+// responses at 50 items by default.
+const perPage = 50
+
+// Paginate is a helper function that calls the list function for each
+// page of results, until a page with less than perPage items is
+// retrieved. If the limit is greater than zero, at most limit pages are
+// retrieved, and an error is returned if more pages remain.
+func Paginate(limit int, list func(page int) (int, error)) error {
+	for page := 1; ; page++ {
+		if limit > 0 && page > limit {
+			return fmt.Errorf("Reached the limit of %d pages. Remaining results are not retrieved.", limit)
+		}
+		n, err := list(page)
+		if err != nil {
+			return err
+		}
+		if n < perPage {
+			return nil
+		}
+	}
+}
+
+// Client represents the simple HTTP client for the Gitea API.
+type Client struct {
+	client *http.Client
+	base   string // base url
+}
+
+// NewClient returns a client at the specified url.
+func NewClient(uri string) *Client {
+	return &Client{http.DefaultClient, strings.TrimSuffix(uri, "/")}
+}
+
+// NewClientToken returns a client at the specified url that
+// authenticates all outbound requests with the given token.
+func NewClientToken(uri, token string) *Client {
+	config := new(oauth2.Config)
+	auther := config.Client(oauth2.NoContext, &oauth2.Token{AccessToken: token})
+	return &Client{auther, strings.TrimSuffix(uri, "/")}
+}
+
+// SetClient sets the default http client. This should be
+// used in conjunction with golang.org/x/oauth2 to
+// authenticate requests to the server.
+func (c *Client) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// GetUser retrieves the currently authenticated user.
+func (c *Client) GetUser() (*User, error) {
+	out := new(User)
+	uri := fmt.Sprintf(pathUser, c.base)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetUserRepos retrieves a page of the repositories of the currently
+// authenticated user.
+func (c *Client) GetUserRepos(page int) ([]*Repo, error) {
+	var out []*Repo
+	uri := fmt.Sprintf(pathUserRepos, c.base, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetUserOrgs retrieves a page of the organizations of the currently
+// authenticated user.
+func (c *Client) GetUserOrgs(page int) ([]*Org, error) {
+	var out []*Org
+	uri := fmt.Sprintf(pathUserOrgs, c.base, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetOrgTeams retrieves a page of the organization teams.
+func (c *Client) GetOrgTeams(org string, page int) ([]*Team, error) {
+	var out []*Team
+	uri := fmt.Sprintf(pathOrgTeams, c.base, org, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetTeamMembers retrieves a page of the team members.
+func (c *Client) GetTeamMembers(team int64, page int) ([]*User, error) {
+	var out []*User
+	uri := fmt.Sprintf(pathTeamMembers, c.base, team, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetRepo retrieves a repository, including the permissions of the
+// currently authenticated user.
+func (c *Client) GetRepo(owner, name string) (*Repo, error) {
+	out := new(Repo)
+	uri := fmt.Sprintf(pathRepo, c.base, owner, name)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetRaw retrieves the raw contents of a file at the ref.
+func (c *Client) GetRaw(owner, name, path, ref string) ([]byte, error) {
+	uri := fmt.Sprintf(pathRaw, c.base, owner, name, path, url.QueryEscape(ref))
+	body, err := c.stream(uri, "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// GetCollaborators retrieves a page of the repository collaborators.
+func (c *Client) GetCollaborators(owner, name string, page int) ([]*User, error) {
+	var out []*User
+	uri := fmt.Sprintf(pathCollabs, c.base, owner, name, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetCollaboratorPerm retrieves the repository permission of a
+// collaborator.
+func (c *Client) GetCollaboratorPerm(owner, name, login string) (*Permission, error) {
+	out := new(Permission)
+	uri := fmt.Sprintf(pathCollabPerm, c.base, owner, name, login)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetComments retrieves the issue comments. Gitea does not paginate
+// issue comments.
+func (c *Client) GetComments(owner, name string, number int) ([]*Comment, error) {
+	var out []*Comment
+	uri := fmt.Sprintf(pathComments, c.base, owner, name, number)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetIssueLabels retrieves the issue labels.
+func (c *Client) GetIssueLabels(owner, name string, number int) ([]*Label, error) {
+	var out []*Label
+	uri := fmt.Sprintf(pathIssueLabels, c.base, owner, name, number)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// AddIssueLabels adds the labels to the issue.
+func (c *Client) AddIssueLabels(owner, name string, number int, labels []int64) error {
+	in := struct {
+		Labels []int64 `json:"labels"`
+	}{labels}
+	uri := fmt.Sprintf(pathIssueLabels, c.base, owner, name, number)
+	return c.post(uri, &in, nil)
+}
+
+// DeleteIssueLabel removes the label from the issue.
+func (c *Client) DeleteIssueLabel(owner, name string, number int, label int64) error {
+	uri := fmt.Sprintf(pathIssueLabel, c.base, owner, name, number, label)
+	return c.delete(uri)
+}
+
+// GetLabels retrieves a page of the repository labels.
+func (c *Client) GetLabels(owner, name string, page int) ([]*Label, error) {
+	var out []*Label
+	uri := fmt.Sprintf(pathLabels, c.base, owner, name, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// CreateLabel creates a repository label.
+func (c *Client) CreateLabel(owner, name string, in *Label) error {
+	uri := fmt.Sprintf(pathLabel, c.base, owner, name)
+	return c.post(uri, in, nil)
+}
+
+// GetPulls retrieves a page of the open pull requests.
+func (c *Client) GetPulls(owner, name string, page int) ([]*PullRequest, error) {
+	var out []*PullRequest
+	uri := fmt.Sprintf(pathPulls, c.base, owner, name, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetPull retrieves a pull request.
+func (c *Client) GetPull(owner, name string, number int) (*PullRequest, error) {
+	out := new(PullRequest)
+	uri := fmt.Sprintf(pathPull, c.base, owner, name, number)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetReviews retrieves a page of the pull request reviews.
+func (c *Client) GetReviews(owner, name string, number, page int) ([]*Review, error) {
+	var out []*Review
+	uri := fmt.Sprintf(pathReviews, c.base, owner, name, number, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetFiles retrieves a page of the pull request changed files.
+func (c *Client) GetFiles(owner, name string, number, page int) ([]*ChangedFile, error) {
+	var out []*ChangedFile
+	uri := fmt.Sprintf(pathFiles, c.base, owner, name, number, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetCommit retrieves a commit.
+func (c *Client) GetCommit(owner, name, sha string) (*Commit, error) {
+	out := new(Commit)
+	uri := fmt.Sprintf(pathCommit, c.base, owner, name, sha)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetCommitPull retrieves the pull request that introduced the commit.
+func (c *Client) GetCommitPull(owner, name, sha string) (*PullRequest, error) {
+	out := new(PullRequest)
+	uri := fmt.Sprintf(pathCommitPull, c.base, owner, name, sha)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetCombinedStatus retrieves the latest status of each context of the
+// commit.
+func (c *Client) GetCombinedStatus(owner, name, sha string) (*CombinedStatus, error) {
+	out := new(CombinedStatus)
+	uri := fmt.Sprintf(pathCombined, c.base, owner, name, sha)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// CreateStatus creates a commit status.
+func (c *Client) CreateStatus(owner, name, sha string, in *Status) error {
+	uri := fmt.Sprintf(pathStatus, c.base, owner, name, sha)
+	return c.post(uri, in, nil)
+}
+
+// GetHooks retrieves a page of the repository webhooks.
+func (c *Client) GetHooks(owner, name string, page int) ([]*Hook, error) {
+	var out []*Hook
+	uri := fmt.Sprintf(pathHooks, c.base, owner, name, page, perPage)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// CreateHook creates a repository webhook.
+func (c *Client) CreateHook(owner, name string, in *Hook) error {
+	uri := fmt.Sprintf(pathHookCreate, c.base, owner, name)
+	return c.post(uri, in, nil)
+}
+
+// DeleteHook deletes a repository webhook.
+func (c *Client) DeleteHook(owner, name string, id int64) error {
+	uri := fmt.Sprintf(pathHook, c.base, owner, name, id)
+	return c.delete(uri)
+}
+
+// GetBranch retrieves a repository branch.
+func (c *Client) GetBranch(owner, name, branch string) (*Branch, error) {
+	out := new(Branch)
+	uri := fmt.Sprintf(pathBranch, c.base, owner, name, url.PathEscape(branch))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetBranchProtection retrieves the protection rule of the branch.
+func (c *Client) GetBranchProtection(owner, name, branch string) (*BranchProtection, error) {
+	out := new(BranchProtection)
+	uri := fmt.Sprintf(pathProtection, c.base, owner, name, url.PathEscape(branch))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// CreateBranchProtection creates the protection rule of a branch.
+func (c *Client) CreateBranchProtection(owner, name string, in *BranchProtection) error {
+	uri := fmt.Sprintf(pathProtections, c.base, owner, name)
+	return c.post(uri, in, nil)
+}
+
+// PatchBranchProtection updates the protection rule of the branch.
+func (c *Client) PatchBranchProtection(owner, name, branch string, in *BranchProtection) error {
+	uri := fmt.Sprintf(pathProtection, c.base, owner, name, url.PathEscape(branch))
+	return c.patch(uri, in, nil)
+}
+
+//
+// http request helper functions
+//
+
+// helper function for making an http GET request.
+func (c *Client) get(rawurl string, out interface{}) error {
+	return c.do(rawurl, "GET", nil, out)
+}
+
+// helper function for making an http POST request.
+func (c *Client) post(rawurl string, in, out interface{}) error {
+	return c.do(rawurl, "POST", in, out)
+}
+
+// helper function for making an http PATCH request.
+func (c *Client) patch(rawurl string, in, out interface{}) error {
+	return c.do(rawurl, "PATCH", in, out)
+}
+
+// helper function for making an http DELETE request.
+func (c *Client) delete(rawurl string) error {
+	return c.do(rawurl, "DELETE", nil, nil)
+}
+
+// helper function to make an http request
+func (c *Client) do(rawurl, method string, in, out interface{}) error {
+	// executes the http request and returns the body as
+	// and io.ReadCloser
+	body, err := c.stream(rawurl, method, in)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// if a json response is expected, parse and return
+	// the json response.
+	if out != nil {
+		return json.NewDecoder(body).Decode(out)
+	}
+	return nil
+}
+
+// helper function to stream an http request
+func (c *Client) stream(rawurl, method string, in interface{}) (io.ReadCloser, error) {
+	uri, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	// if we are posting or putting data, we need to
+	// write it to the body of the request.
+	var buf io.ReadWriter
+	if in != nil {
+		buf = new(bytes.Buffer)
+		err := json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// creates a new http request to gitea.
+	req, err := http.NewRequest(method, uri.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode > http.StatusPartialContent {
+		defer resp.Body.Close()
+		out, _ := ioutil.ReadAll(resp.Body)
+		apiErr := &Error{Status: resp.StatusCode}
+		if json.Unmarshal(out, apiErr) != nil || len(apiErr.Message) == 0 {
+			apiErr.Message = string(out)
+		}
+		return nil, apiErr
+	}
+	return resp.Body, nil
+}
+
+// isNotFound is a helper function that checks if the error is an API
+// error for a missing resource.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.Status == http.StatusNotFound
+}
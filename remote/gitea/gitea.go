@@ -0,0 +1,836 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/shared/httputil"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const contextName = "approvals/lgtm"
+
+// Gitea provides the available configuration values.
+type Gitea struct {
+	URL    string
+	Client string
+	Secret string
+
+	// MaxPages is the upper bound of pages retrieved from list
+	// endpoints. Zero means no limit.
+	MaxPages int
+}
+
+// GetUser retrieves the current user from the API.
+func (g *Gitea) GetUser(c context.Context, res http.ResponseWriter, req *http.Request) (*model.User, error) {
+
+	var config = &oauth2.Config{
+		ClientID:     g.Client,
+		ClientSecret: g.Secret,
+		RedirectURL:  fmt.Sprintf("%s/login", httputil.GetURL(req)),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  fmt.Sprintf("%s/login/oauth/authorize", g.URL),
+			TokenURL: fmt.Sprintf("%s/login/oauth/access_token", g.URL),
+		},
+	}
+
+	// get the oauth code from the incoming request. if no code is present
+	// redirec the user to Gitea login to retrieve a code.
+	var code = req.FormValue("code")
+	if len(code) == 0 {
+		state := fmt.Sprintln(time.Now().Unix())
+		http.Redirect(res, req, config.AuthCodeURL(state), http.StatusSeeOther)
+		return nil, nil
+	}
+
+	// exchanges the oauth2 code for an access token
+	token, err := config.Exchange(oauth2.NoContext, code)
+	if err != nil {
+		return nil, fmt.Errorf("Error exchanging token. %s", err)
+	}
+
+	// get the currently authenticated user details for the access token
+	client := NewClientToken(g.URL, token.AccessToken)
+	user, err := client.GetUser()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching user. %s", err)
+	}
+
+	return &model.User{
+		Login:  user.Login,
+		Token:  token.AccessToken,
+		Avatar: user.Avatar,
+	}, nil
+}
+
+// GetUserToken retrieves a user token from the API.
+func (g *Gitea) GetUserToken(c context.Context, token string) (string, error) {
+	client := NewClientToken(g.URL, token)
+	user, err := client.GetUser()
+	if err != nil {
+		return "", fmt.Errorf("Error fetching user. %s", err)
+	}
+	return user.Login, nil
+}
+
+// GetTeams retrieves teams from the API.
+func (g *Gitea) GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	teams := []*model.Team{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		orgs, err := client.GetUserOrgs(page)
+		for _, org := range orgs {
+			teams = append(teams, &model.Team{
+				Login:  org.Name,
+				Avatar: org.Avatar,
+			})
+		}
+		return len(orgs), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching teams. %s", err)
+	}
+	return teams, nil
+}
+
+// GetMembers retrieves members from the API. Gitea teams are looked up
+// by name, and cannot be nested.
+func (g *Gitea) GetMembers(c context.Context, user *model.User, org, team string) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	var found *Team
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		teams, err := client.GetOrgTeams(org, page)
+		for _, t := range teams {
+			if found == nil && strings.EqualFold(t.Name, team) {
+				found = t
+			}
+		}
+		return len(teams), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching teams. %s", err)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("Error fetching team members. Team %s/%s not found", org, team)
+	}
+
+	var members []*model.Member
+	err = Paginate(g.MaxPages, func(page int) (int, error) {
+		teammates, err := client.GetTeamMembers(found.ID, page)
+		for _, teammate := range teammates {
+			members = append(members, &model.Member{
+				Login: teammate.Login,
+			})
+		}
+		return len(teammates), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching team members. %s", err)
+	}
+	return members, nil
+}
+
+// GetRepo retrieves a repository from the API.
+func (g *Gitea) GetRepo(c context.Context, user *model.User, owner, name string) (*model.Repo, error) {
+	client := NewClientToken(g.URL, user.Token)
+	repo, err := client.GetRepo(owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+	return &model.Repo{
+		Owner:   owner,
+		Name:    name,
+		Slug:    repo.FullName,
+		Link:    repo.HTMLURL,
+		Private: repo.Private,
+	}, nil
+}
+
+// GetPerm retrieves permissions from the API.
+func (g *Gitea) GetPerm(c context.Context, user *model.User, owner, name string) (*model.Perm, error) {
+	client := NewClientToken(g.URL, user.Token)
+	repo, err := client.GetRepo(owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+	m := &model.Perm{}
+	if repo.Permissions != nil {
+		m.Admin = repo.Permissions.Admin
+		m.Push = repo.Permissions.Push
+		m.Pull = repo.Permissions.Pull
+	}
+	return m, nil
+}
+
+// GetRepos retrieves repositories from the API.
+func (g *Gitea) GetRepos(c context.Context, u *model.User) ([]*model.Repo, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	repos := []*model.Repo{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetUserRepos(page)
+		for _, repo := range list {
+			// only list repositories that I can admin
+			if repo.Permissions == nil || !repo.Permissions.Admin {
+				continue
+			}
+			repos = append(repos, &model.Repo{
+				Owner:   repo.Owner.Login,
+				Name:    repo.Name,
+				Slug:    repo.FullName,
+				Link:    repo.HTMLURL,
+				Private: repo.Private,
+			})
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// RemoveIssueLabels removes labels from an issue.
+func (g *Gitea) RemoveIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	// gitea removes labels by id, so the names are looked up in the
+	// labels of the issue.
+	current, err := client.GetIssueLabels(repo.Owner, repo.Name, number)
+	if err != nil {
+		return err
+	}
+	for _, name := range labels {
+		for _, label := range current {
+			if label.Name != name {
+				continue
+			}
+			err := client.DeleteIssueLabel(repo.Owner, repo.Name, number, label.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddIssueLabels adds labels to an issue.
+func (g *Gitea) AddIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	// gitea adds labels by id, so the names are looked up in the
+	// labels of the repository.
+	ids := map[string]int64{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetLabels(repo.Owner, repo.Name, page)
+		for _, label := range list {
+			ids[label.Name] = label.ID
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return err
+	}
+
+	var add []int64
+	for _, name := range labels {
+		id, ok := ids[name]
+		if !ok {
+			return fmt.Errorf("Label %s not found", name)
+		}
+		add = append(add, id)
+	}
+	return client.AddIssueLabels(repo.Owner, repo.Name, number, add)
+}
+
+// GetIssueLabels get all labels of issue
+func (g *Gitea) GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	labels, err := client.GetIssueLabels(repo.Owner, repo.Name, number)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, label := range labels {
+		res = append(res, label.Name)
+	}
+	return res, nil
+}
+
+// CreateLabels creates the labels that do not exist in the repository.
+func (g *Gitea) CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	var exists = map[string]bool{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetLabels(repo.Owner, repo.Name, page)
+		for _, label := range list {
+			exists[label.Name] = true
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		if exists[label.Name] {
+			continue
+		}
+		err := client.CreateLabel(repo.Owner, repo.Name, &Label{
+			Name:  label.Name,
+			Color: "#" + strings.TrimPrefix(label.Color, "#"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetHook injects a webhook through the API.
+func (g *Gitea) SetHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	currentRepo, err := client.GetRepo(repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	old, err := g.getHook(client, repo.Owner, repo.Name, link)
+	if err == nil && old != nil {
+		client.DeleteHook(repo.Owner, repo.Name, old.ID)
+	}
+
+	err = client.CreateHook(repo.Owner, repo.Name, &Hook{
+		Type: "gitea",
+		Config: map[string]string{
+			"url":          link,
+			"content_type": "json",
+			"secret":       repo.Secret,
+		},
+		Events: []string{
+			"issue_comment",
+			"pull_request",
+			"pull_request_sync",
+			"pull_request_review_approved",
+			"pull_request_review_rejected",
+			"push",
+		},
+		Active: true,
+	})
+	if err != nil {
+		log.Debugf("Error creating the webhook at %s. %s", link, err)
+		return err
+	}
+
+	branch := currentRepo.DefaultBranch
+	protection, err := client.GetBranchProtection(repo.Owner, repo.Name, branch)
+
+	// Branch not protected
+	if isNotFound(err) {
+		err := client.CreateBranchProtection(repo.Owner, repo.Name, &BranchProtection{
+			BranchName:          branch,
+			EnableStatusCheck:   true,
+			StatusCheckContexts: []string{contextName},
+		})
+		if err != nil {
+			log.Warnf("Error configuring protected branch for %s/%s@%s. %s", repo.Owner, repo.Name, branch, err)
+			return err
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	checks := []string{}
+	for _, check := range protection.StatusCheckContexts {
+		if check != contextName {
+			checks = append(checks, check)
+		}
+	}
+	checks = append(checks, contextName)
+
+	protection.EnableStatusCheck = true
+	protection.StatusCheckContexts = checks
+	return client.PatchBranchProtection(repo.Owner, repo.Name, branch, protection)
+}
+
+// DelHook removes a webhook through the API.
+func (g *Gitea) DelHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	hook, err := g.getHook(client, repo.Owner, repo.Name, link)
+	if err != nil {
+		return err
+	} else if hook == nil {
+		return nil
+	}
+	err = client.DeleteHook(repo.Owner, repo.Name, hook.ID)
+	if err != nil {
+		return err
+	}
+
+	currentRepo, err := client.GetRepo(repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	branch := currentRepo.DefaultBranch
+	protection, err := client.GetBranchProtection(repo.Owner, repo.Name, branch)
+	if err != nil || len(protection.StatusCheckContexts) == 0 {
+		return nil
+	}
+	checks := []string{}
+	for _, check := range protection.StatusCheckContexts {
+		if check != contextName {
+			checks = append(checks, check)
+		}
+	}
+
+	protection.EnableStatusCheck = len(checks) != 0
+	protection.StatusCheckContexts = checks
+	return client.PatchBranchProtection(repo.Owner, repo.Name, branch, protection)
+}
+
+// getHook is a helper function that retrieves a hook by hostname. To do
+// this, it will retrieve a list of all hooks and iterate through the
+// list.
+func (g *Gitea) getHook(client *Client, owner, name, rawurl string) (*Hook, error) {
+	newurl, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Hook
+	err = Paginate(g.MaxPages, func(page int) (int, error) {
+		hooks, err := client.GetHooks(owner, name, page)
+		for _, hook := range hooks {
+			oldurl, err := url.Parse(hook.Config["url"])
+			if err != nil {
+				continue
+			}
+			if found == nil && newurl.Host == oldurl.Host {
+				found = hook
+			}
+		}
+		return len(hooks), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// GetComments retrieves comments from the API.
+func (g *Gitea) GetComments(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Comment, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	list, err := client.GetComments(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	comments := []*model.Comment{}
+	for _, comment := range list {
+		comments = append(comments, &model.Comment{
+			ID:      comment.ID,
+			Author:  comment.User.Login,
+			Body:    comment.Body,
+			Created: comment.Created,
+		})
+	}
+	return comments, nil
+}
+
+// GetReviews retrieves reviews from the API.
+func (g *Gitea) GetReviews(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Review, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	reviews := []*model.Review{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetReviews(r.Owner, r.Name, num, page)
+		for _, review := range list {
+			reviews = append(reviews, &model.Review{
+				ID:        review.ID,
+				Author:    review.User.Login,
+				Body:      review.Body,
+				State:     reviewState(review.State, review.Dismissed),
+				CommitID:  review.CommitID,
+				Submitted: review.Submitted,
+			})
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetFiles retrieves the pull request changed files from the API.
+func (g *Gitea) GetFiles(c context.Context, u *model.User, r *model.Repo, num int) ([]string, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var files []string
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetFiles(r.Owner, r.Name, num, page)
+		for _, file := range list {
+			files = append(files, file.Filename)
+			// renamed files also change the previous path
+			if len(file.PreviousFilename) != 0 {
+				files = append(files, file.PreviousFilename)
+			}
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetHeadCommit retrieves the pull request head commit from the API.
+func (g *Gitea) GetHeadCommit(c context.Context, u *model.User, r *model.Repo, num int) (*model.Commit, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := client.GetCommit(r.Owner, r.Name, pr.Head.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Commit{
+		SHA:     commit.SHA,
+		Created: commit.Commit.Committer.Date,
+	}, nil
+}
+
+// GetContents retrieves a file at the ref from the API.
+func (g *Gitea) GetContents(c context.Context, u *model.User, r *model.Repo, path, ref string) ([]byte, error) {
+	client := NewClientToken(g.URL, u.Token)
+	return client.GetRaw(r.Owner, r.Name, path, ref)
+}
+
+// GetPull retrieves a pull request from the API.
+func (g *Gitea) GetPull(c context.Context, u *model.User, r *model.Repo, num int) (*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	return toIssue(pr), nil
+}
+
+// GetPulls retrieves the open pull requests from the API.
+func (g *Gitea) GetPulls(c context.Context, u *model.User, r *model.Repo) ([]*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pulls := []*model.Issue{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetPulls(r.Owner, r.Name, page)
+		for _, pr := range list {
+			pulls = append(pulls, toIssue(pr))
+		}
+		return len(list), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pulls, nil
+}
+
+// GetStatus retrieves the approval status of the commit from the API.
+func (g *Gitea) GetStatus(c context.Context, u *model.User, r *model.Repo, sha string) (*model.Status, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	combined, err := client.GetCombinedStatus(r.Owner, r.Name, sha)
+	if err != nil {
+		return nil, err
+	}
+	status := new(model.Status)
+	for _, s := range combined.Statuses {
+		if s.Context == contextName {
+			status.State = s.Status
+			status.Desc = s.Desc
+		}
+	}
+	return status, nil
+}
+
+// IsProtected checks if the branch is protected from the API.
+func (g *Gitea) IsProtected(c context.Context, u *model.User, r *model.Repo, branch string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	b, err := client.GetBranch(r.Owner, r.Name, branch)
+	if err != nil {
+		return false, err
+	}
+	return b.Protected, nil
+}
+
+// IsMerged checks if the commit was merged through a pull request from
+// the API.
+func (g *Gitea) IsMerged(c context.Context, u *model.User, r *model.Repo, sha string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetCommitPull(r.Owner, r.Name, sha)
+	if isNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return pr.Merged, nil
+}
+
+// GetAdmins retrieves the repository administrators from the API.
+func (g *Gitea) GetAdmins(c context.Context, u *model.User, r *model.Repo) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var collaborators []*User
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, err := client.GetCollaborators(r.Owner, r.Name, page)
+		collaborators = append(collaborators, list...)
+		return len(list), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the collaborator list does not include permissions, which are
+	// retrieved for each collaborator.
+	var admins []*model.Member
+	for _, collaborator := range collaborators {
+		perm, err := client.GetCollaboratorPerm(r.Owner, r.Name, collaborator.Login)
+		if err != nil {
+			return nil, err
+		}
+		if perm.Permission != "admin" && perm.Permission != "owner" {
+			continue
+		}
+		admins = append(admins, &model.Member{
+			Login: collaborator.Login,
+		})
+	}
+	return admins, nil
+}
+
+// SetStatus sets the pull request status through the API.
+func (g *Gitea) SetStatus(c context.Context, u *model.User, r *model.Repo, num int, status *model.Status) error {
+	client := NewClientToken(g.URL, u.Token)
+
+	pr, err := client.GetPull(r.Owner, r.Name, num)
+	if err != nil {
+		return err
+	}
+	return client.CreateStatus(r.Owner, r.Name, pr.Head.SHA, &Status{
+		State:   status.State,
+		Context: contextName,
+		Desc:    status.Desc,
+	})
+}
+
+// GetHook gets a webhook from the API.
+func (g *Gitea) GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
+	event := r.Header.Get("X-Gitea-Event")
+
+	// pushes may bypass the approval of protected branches, and pushes
+	// to the default branch may change the approval policy of the open
+	// pull requests.
+	if event == "push" {
+		return getPushHook(r)
+	}
+
+	// only process comment, review and pull request hooks. gitea sends
+	// approving and rejecting reviews as separate events.
+	if event != "issue_comment" &&
+		event != "pull_request_approved" &&
+		event != "pull_request_rejected" &&
+		event != "pull_request" {
+		return nil, nil
+	}
+
+	data := commentHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	// only process pull request actions that require the status to be
+	// set or recalculated: a newly opened or reopened pull request
+	// needs its initial status, and new commits may invalidate existing
+	// approvals.
+	if event == "pull_request" {
+		switch data.Action {
+		case "opened", "reopened", "synchronized":
+		case "closed":
+			if data.PullRequest != nil && data.PullRequest.Merged {
+				return getMergeHook(r, &data), nil
+			}
+			return nil, nil
+		default:
+			return nil, nil
+		}
+	}
+
+	if event == "issue_comment" && !data.IsPull {
+		return nil, nil
+	}
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitea-Delivery")
+	hook.Event = event
+	hook.Issue = new(model.Issue)
+	hook.Issue.Number = data.Issue.Number
+	hook.Issue.Author = data.Issue.User.Login
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = data.Repository.Owner.Login
+	hook.Repo.Name = data.Repository.Name
+	hook.Repo.Slug = data.Repository.FullName
+	hook.Comment = new(model.Comment)
+	hook.Comment.ID = data.Comment.ID
+	hook.Comment.Body = data.Comment.Body
+	hook.Comment.Author = data.Comment.User.Login
+
+	// review payloads carry the review body and type, and the reviewer
+	// as the sender.
+	hook.Review = new(model.Review)
+	if event == "pull_request_approved" || event == "pull_request_rejected" {
+		hook.Review.Body = data.Review.Content
+		hook.Review.Author = data.Sender.Login
+		hook.Review.State = reviewState(strings.TrimPrefix(data.Review.Type, "pull_request_review_"), false)
+	}
+
+	if data.PullRequest != nil && data.PullRequest.Number > 0 {
+		hook.Issue = toIssue(data.PullRequest)
+	}
+
+	return hook, nil
+}
+
+// getMergeHook is a helper function that returns the merge of a closed
+// and merged pull request.
+func getMergeHook(r *http.Request, data *commentHook) *model.Hook {
+	pr := data.PullRequest
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitea-Delivery")
+	hook.Event = "pull_request"
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = data.Repository.Owner.Login
+	hook.Repo.Name = data.Repository.Name
+	hook.Repo.Slug = data.Repository.FullName
+	hook.Merge = new(model.Merge)
+	hook.Merge.Number = pr.Number
+	hook.Merge.Title = pr.Title
+	hook.Merge.SHA = pr.MergeCommitSHA
+	hook.Merge.MergedBy = data.Sender.Login
+	if pr.User != nil {
+		hook.Merge.Author = pr.User.Login
+	}
+	if pr.Base != nil {
+		hook.Merge.Base = pr.Base.Ref
+	}
+	if pr.Head != nil {
+		hook.Merge.HeadSHA = pr.Head.SHA
+	}
+	if pr.MergedBy != nil {
+		hook.Merge.MergedBy = pr.MergedBy.Login
+	}
+	if pr.MergedAt != nil {
+		hook.Merge.Merged = pr.MergedAt.Unix()
+	}
+	return hook
+}
+
+// getPushHook is a helper function that parses a push hook, and returns
+// the commits pushed and the files changed on the branch.
+func getPushHook(r *http.Request) (*model.Hook, error) {
+	data := pushHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	// only process pushes to branches, as opposed to tags, that were
+	// not deleted.
+	if !strings.HasPrefix(data.Ref, "refs/heads/") || strings.Trim(data.After, "0") == "" {
+		return nil, nil
+	}
+	branch := strings.TrimPrefix(data.Ref, "refs/heads/")
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitea-Delivery")
+	hook.Event = "push"
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = data.Repository.Owner.Login
+	hook.Repo.Name = data.Repository.Name
+	hook.Repo.Slug = data.Repository.FullName
+	hook.Push = new(model.Push)
+	hook.Push.Branch = branch
+	hook.Push.Default = branch == data.Repository.DefaultBranch
+	hook.Push.Pusher = data.Pusher.Login
+	hook.Push.Truncated = data.Total > len(data.Commits)
+
+	seen := map[string]bool{}
+	for _, commit := range data.Commits {
+		hook.Push.Commits = append(hook.Push.Commits, &model.Commit{
+			SHA:     commit.ID,
+			Author:  commit.Author.Username,
+			Message: commit.Message,
+			Link:    commit.URL,
+			Created: commit.Timestamp,
+		})
+		for _, files := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range files {
+				if !seen[file] {
+					seen[file] = true
+					hook.Push.Files = append(hook.Push.Files, file)
+				}
+			}
+		}
+	}
+	return hook, nil
+}
+
+// toIssue is a helper function that converts the pull request to an
+// issue.
+func toIssue(pr *PullRequest) *model.Issue {
+	issue := &model.Issue{
+		Number: pr.Number,
+		Title:  pr.Title,
+	}
+	if pr.User != nil {
+		issue.Author = pr.User.Login
+	}
+	if pr.Base != nil {
+		issue.Base = pr.Base.Ref
+	}
+	return issue
+}
+
+// reviewState is a helper function that converts the gitea review state
+// to the review state of the model.
+func reviewState(state string, dismissed bool) string {
+	if dismissed {
+		return "DISMISSED"
+	}
+	switch strings.ToUpper(state) {
+	case "REQUEST_CHANGES", "REJECTED":
+		return "CHANGES_REQUESTED"
+	}
+	return strings.ToUpper(state)
+}
@@ -0,0 +1,402 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/franela/goblin"
+	"golang.org/x/net/context"
+)
+
+func TestGitea(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Gitea", func() {
+		var server *httptest.Server
+		var remote *Gitea
+		var api *fakeGitea
+
+		g.BeforeEach(func() {
+			api = &fakeGitea{}
+			server = httptest.NewServer(api.handler())
+			remote = &Gitea{URL: server.URL}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("Should get reviews from all pages", func() {
+			reviews, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(reviews)).Equal(120)
+			g.Assert(reviews[0].State).Equal("APPROVED")
+			g.Assert(reviews[1].State).Equal("CHANGES_REQUESTED")
+			g.Assert(reviews[2].State).Equal("DISMISSED")
+			g.Assert(reviews[0].Author).Equal("reviewer-0")
+		})
+
+		g.It("Should fail at the page limit", func() {
+			remote.MaxPages = 2
+			_, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should get the last page at the page limit", func() {
+			remote.MaxPages = 3
+			reviews, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(reviews)).Equal(120)
+		})
+
+		g.It("Should get the comments", func() {
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(1)
+			g.Assert(comments[0].ID).Equal(int64(7))
+			g.Assert(comments[0].Body).Equal("LGTM")
+		})
+
+		g.It("Should get the members of the team by name", func() {
+			members, err := remote.GetMembers(context.Background(), fakeUser, "octocat", "maintainers")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(members)).Equal(1)
+			g.Assert(members[0].Login).Equal("bradrydzewski")
+		})
+
+		g.It("Should return an error for a missing team", func() {
+			_, err := remote.GetMembers(context.Background(), fakeUser, "octocat", "missing")
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should get the raw file contents", func() {
+			file, err := remote.GetContents(context.Background(), fakeUser, fakeRepo, "MAINTAINERS", "master")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(string(file)).Equal("octocat\n")
+		})
+
+		g.It("Should get the head commit", func() {
+			commit, err := remote.GetHeadCommit(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(commit.SHA).Equal("6dcb09b")
+			g.Assert(commit.Created.Unix()).Equal(int64(1592222400))
+		})
+
+		g.It("Should set the status on the head commit", func() {
+			err := remote.SetStatus(context.Background(), fakeUser, fakeRepo, 1, &model.Status{State: "success", Desc: "approved"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.status.State).Equal("success")
+			g.Assert(api.status.Context).Equal(contextName)
+		})
+
+		g.It("Should get the status of the commit", func() {
+			status, err := remote.GetStatus(context.Background(), fakeUser, fakeRepo, "6dcb09b")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(status.State).Equal("pending")
+		})
+
+		g.It("Should add labels by id", func() {
+			err := remote.AddIssueLabels(context.Background(), fakeUser, fakeRepo, 1, []string{"lgtm/done"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.labels).Equal([]int64{2})
+		})
+
+		g.It("Should check if the commit was merged", func() {
+			merged, err := remote.IsMerged(context.Background(), fakeUser, fakeRepo, "6dcb09b")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(merged).IsTrue()
+
+			merged, err = remote.IsMerged(context.Background(), fakeUser, fakeRepo, "a1b2c3d")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(merged).IsFalse()
+		})
+
+		g.It("Should protect the default branch", func() {
+			err := remote.SetHook(context.Background(), fakeUser, fakeRepo, "http://lgtm.example.com/hook")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.hook.Type).Equal("gitea")
+			g.Assert(api.hook.Config["url"]).Equal("http://lgtm.example.com/hook")
+			g.Assert(api.protection.BranchName).Equal("master")
+			g.Assert(api.protection.EnableStatusCheck).IsTrue()
+			g.Assert(api.protection.StatusCheckContexts).Equal([]string{contextName})
+		})
+
+		g.It("Should append the status check to the branch protection", func() {
+			api.protected = true
+			err := remote.SetHook(context.Background(), fakeUser, fakeRepo, "http://lgtm.example.com/hook")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.protection.StatusCheckContexts).Equal([]string{"ci", contextName})
+		})
+	})
+}
+
+func TestHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Hook", func() {
+		var remote = new(Gitea)
+
+		g.It("Should return the pull request of a comment", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeComment))
+			r.Header.Set("X-Gitea-Event", "issue_comment")
+			r.Header.Set("X-Gitea-Delivery", "72d3162e")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Delivery).Equal("72d3162e")
+			g.Assert(hook.Repo.Slug).Equal("octocat/hello-world")
+			g.Assert(hook.Issue.Number).Equal(1)
+			g.Assert(hook.Comment.Body).Equal("LGTM")
+		})
+
+		g.It("Should ignore comments on issues", func() {
+			body := strings.Replace(fakeComment, `"is_pull": true`, `"is_pull": false`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Gitea-Event", "issue_comment")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+
+		g.It("Should return the review of an approval", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeReview))
+			r.Header.Set("X-Gitea-Event", "pull_request_approved")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue.Number).Equal(42)
+			g.Assert(hook.Issue.Base).Equal("master")
+			g.Assert(hook.Review.Author).Equal("bradrydzewski")
+			g.Assert(hook.Review.State).Equal("APPROVED")
+		})
+
+		g.It("Should return the merge of a closed pull request", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeMerge))
+			r.Header.Set("X-Gitea-Event", "pull_request")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue == nil).IsTrue()
+			g.Assert(hook.Merge.Number).Equal(42)
+			g.Assert(hook.Merge.SHA).Equal("a1b2c3d")
+			g.Assert(hook.Merge.HeadSHA).Equal("6dcb09b")
+			g.Assert(hook.Merge.MergedBy).Equal("bradrydzewski")
+			g.Assert(hook.Merge.Merged).Equal(int64(1592222400))
+		})
+
+		g.It("Should return the files changed by a push", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakePush))
+			r.Header.Set("X-Gitea-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Push.Branch).Equal("master")
+			g.Assert(hook.Push.Default).IsTrue()
+			g.Assert(hook.Push.Pusher).Equal("octocat")
+			g.Assert(hook.Push.Files).Equal([]string{"MAINTAINERS", "README.md"})
+			g.Assert(hook.Push.Truncated).IsFalse()
+		})
+
+		g.It("Should flag a push listing only some of the commits", func() {
+			body := strings.Replace(fakePush, `"total_commits": 1`, `"total_commits": 25`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Gitea-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Push.Truncated).IsTrue()
+		})
+
+		g.It("Should ignore other events", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader("{}"))
+			r.Header.Set("X-Gitea-Event", "create")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+// fakeGitea is a fake of the Gitea API, which records the statuses,
+// labels, hooks and branch protections written to it.
+type fakeGitea struct {
+	protected  bool
+	status     Status
+	labels     []int64
+	hook       Hook
+	protection BranchProtection
+}
+
+func (f *fakeGitea) handler() http.Handler {
+	const repo = "/api/v1/repos/octocat/hello-world"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(repo, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_name":"octocat/hello-world","default_branch":"master","permissions":{"admin":true}}`))
+	})
+	mux.HandleFunc(repo+"/pulls/1/reviews", fakeList(120, func(i int) string {
+		states := []string{"APPROVED", "REQUEST_CHANGES", "APPROVED"}
+		return fmt.Sprintf(`{"id":%d,"state":%q,"dismissed":%t,"user":{"login":"reviewer-%d"}}`, i, states[i%3], i%3 == 2, i)
+	}))
+	mux.HandleFunc(repo+"/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":7,"body":"LGTM","user":{"login":"octocat"}}]`))
+	})
+	mux.HandleFunc("/api/v1/orgs/octocat/teams", fakeList(2, func(i int) string {
+		return []string{`{"id":1,"name":"Owners"}`, `{"id":2,"name":"Maintainers"}`}[i]
+	}))
+	mux.HandleFunc("/api/v1/teams/2/members", fakeList(1, func(i int) string {
+		return `{"login":"bradrydzewski"}`
+	}))
+	mux.HandleFunc(repo+"/raw/MAINTAINERS", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("octocat\n"))
+	})
+	mux.HandleFunc(repo+"/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number":1,"head":{"sha":"6dcb09b"},"base":{"ref":"master"}}`))
+	})
+	mux.HandleFunc(repo+"/git/commits/6dcb09b", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sha":"6dcb09b","commit":{"committer":{"date":"2020-06-15T12:00:00Z"}}}`))
+	})
+	mux.HandleFunc(repo+"/statuses/6dcb09b", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&f.status)
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc(repo+"/commits/6dcb09b/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"state":"pending","statuses":[{"context":"ci","status":"success"},{"context":"approvals/lgtm","status":"pending"}]}`))
+	})
+	mux.HandleFunc(repo+"/commits/6dcb09b/pull", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number":1,"merged":true}`))
+	})
+	mux.HandleFunc(repo+"/labels", fakeList(2, func(i int) string {
+		return []string{`{"id":1,"name":"lgtm/need 1"}`, `{"id":2,"name":"lgtm/done"}`}[i]
+	}))
+	mux.HandleFunc(repo+"/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		in := struct {
+			Labels []int64 `json:"labels"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&in)
+		f.labels = in.Labels
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc(repo+"/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&f.hook)
+			w.WriteHeader(201)
+		}
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc(repo+"/branch_protections", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&f.protection)
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc(repo+"/branch_protections/master", func(w http.ResponseWriter, r *http.Request) {
+		if !f.protected {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"message":"Branch protection not found"}`))
+			return
+		}
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&f.protection)
+		}
+		w.Write([]byte(`{"branch_name":"master","enable_status_check":true,"status_check_contexts":["ci"]}`))
+	})
+	return mux
+}
+
+// fakeList returns a handler that serves a page of total items rendered
+// by the item function.
+func fakeList(total int, item func(int) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		size, _ := strconv.Atoi(r.FormValue("limit"))
+		start, end := (page-1)*size, page*size
+		if end > total {
+			end = total
+		}
+		w.Write([]byte("["))
+		for i := start; i < end; i++ {
+			if i != start {
+				w.Write([]byte(","))
+			}
+			w.Write([]byte(item(i)))
+		}
+		w.Write([]byte("]"))
+	}
+}
+
+var fakeComment = `{
+  "action": "created",
+  "is_pull": true,
+  "issue": {"number": 1, "user": {"login": "octocat"}},
+  "comment": {"id": 7, "body": "LGTM", "user": {"login": "bradrydzewski"}},
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var fakeReview = `{
+  "action": "reviewed",
+  "number": 42,
+  "pull_request": {
+    "number": 42,
+    "title": "Update the README",
+    "user": {"login": "octocat"},
+    "base": {"ref": "master"},
+    "head": {"sha": "6dcb09b"}
+  },
+  "review": {"type": "pull_request_review_approved", "content": "LGTM"},
+  "sender": {"login": "bradrydzewski"},
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var fakeMerge = `{
+  "action": "closed",
+  "number": 42,
+  "pull_request": {
+    "number": 42,
+    "title": "Update the README",
+    "user": {"login": "octocat"},
+    "base": {"ref": "master"},
+    "head": {"sha": "6dcb09b"},
+    "merged": true,
+    "merged_at": "2020-06-15T12:00:00Z",
+    "merge_commit_sha": "a1b2c3d",
+    "merged_by": {"login": "bradrydzewski"}
+  },
+  "sender": {"login": "bradrydzewski"},
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var fakePush = `{
+  "ref": "refs/heads/master",
+  "after": "a1b2c3d",
+  "total_commits": 1,
+  "pusher": {"login": "octocat"},
+  "commits": [
+    {"id": "a1b2c3d", "message": "Add maintainers", "author": {"username": "octocat"}, "added": ["MAINTAINERS"], "removed": [], "modified": ["README.md"]}
+  ],
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "default_branch": "master",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var (
+	fakeUser = &model.User{Login: "octocat", Token: "cfcd2084"}
+	fakeRepo = &model.Repo{Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+)
@@ -0,0 +1,229 @@
+package gitea
+
+import "time"
+
+// Error represents an API error.
+type Error struct {
+	Status  int    `json:"-"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string  { return e.Message }
+func (e *Error) String() string { return e.Message }
+
+// User represents a Gitea user or organization.
+type User struct {
+	ID     int64  `json:"id"`
+	Login  string `json:"login"`
+	Avatar string `json:"avatar_url"`
+}
+
+// Org represents a Gitea organization.
+type Org struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar_url"`
+}
+
+// Team represents an organization team.
+type Team struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Repo represents a Gitea repository.
+type Repo struct {
+	ID            int64  `json:"id"`
+	Owner         *User  `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+	Permissions   *Perm  `json:"permissions"`
+}
+
+// Perm represents the repository permissions of the current user.
+type Perm struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// Permission represents the repository permission of a collaborator.
+type Permission struct {
+	Permission string `json:"permission"`
+}
+
+// Comment represents an issue comment.
+type Comment struct {
+	ID      int64     `json:"id"`
+	User    *User     `json:"user"`
+	Body    string    `json:"body"`
+	Created time.Time `json:"created_at"`
+}
+
+// Review represents a pull request review.
+type Review struct {
+	ID        int64     `json:"id"`
+	User      *User     `json:"user"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	CommitID  string    `json:"commit_id"`
+	Stale     bool      `json:"stale"`
+	Dismissed bool      `json:"dismissed"`
+	Submitted time.Time `json:"submitted_at"`
+}
+
+// ChangedFile represents a file changed by a pull request.
+type ChangedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+}
+
+// PullRequest represents a pull request.
+type PullRequest struct {
+	ID             int64      `json:"id"`
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	User           *User      `json:"user"`
+	Base           *PRBranch  `json:"base"`
+	Head           *PRBranch  `json:"head"`
+	Merged         bool       `json:"merged"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	MergedBy       *User      `json:"merged_by"`
+}
+
+// PRBranch represents the base or head branch of a pull request.
+type PRBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// Commit represents a git commit.
+type Commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// Status represents a commit status. Gitea reports the state of an
+// existing status as status, but expects state when creating one.
+type Status struct {
+	State   string `json:"state,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Context string `json:"context"`
+	Desc    string `json:"description"`
+	Target  string `json:"target_url,omitempty"`
+}
+
+// CombinedStatus represents the latest status of each context of a
+// commit.
+type CombinedStatus struct {
+	State    string    `json:"state"`
+	Statuses []*Status `json:"statuses"`
+}
+
+// Label represents a repository label.
+type Label struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Hook represents a repository webhook.
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// Branch represents a repository branch.
+type Branch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+}
+
+// BranchProtection represents the protection rule of a branch.
+type BranchProtection struct {
+	BranchName          string   `json:"branch_name"`
+	EnableStatusCheck   bool     `json:"enable_status_check"`
+	StatusCheckContexts []string `json:"status_check_contexts"`
+}
+
+// repoHook represents the repository of a webhook payload.
+type repoHook struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// commentHook represents a subset of the issue_comment and pull_request
+// payloads, including the pull request review payloads.
+type commentHook struct {
+	Action string `json:"action"`
+	IsPull bool   `json:"is_pull"`
+
+	Issue struct {
+		Number int `json:"number"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"issue"`
+
+	Comment struct {
+		ID   int64  `json:"id"`
+		Body string `json:"body"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"comment"`
+
+	PullRequest *PullRequest `json:"pull_request"`
+
+	Review struct {
+		Type    string `json:"type"`
+		Content string `json:"content"`
+	} `json:"review"`
+
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+
+	Repository repoHook `json:"repository"`
+}
+
+// pushHook represents a subset of the push payload.
+type pushHook struct {
+	Ref   string `json:"ref"`
+	After string `json:"after"`
+	Total int    `json:"total_commits"`
+
+	Pusher struct {
+		Login string `json:"login"`
+	} `json:"pusher"`
+
+	Commits []struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		URL       string    `json:"url"`
+		Timestamp time.Time `json:"timestamp"`
+		Author    struct {
+			Username string `json:"username"`
+		} `json:"author"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+
+	Repository repoHook `json:"repository"`
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/shared/token"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/go-github/v33/github"
+	"golang.org/x/net/context"
+)
+
+// tokenExpiry is the margin before the expiry of an installation token
+// after which a new token is minted.
+const tokenExpiry = 5 * time.Minute
+
+// App provides the configuration of a GitHub App. When configured, the
+// repositories are accessed with the token of the app installation,
+// instead of the token of the user who activated the repository.
+type App struct {
+	ID     int64
+	Key    *rsa.PrivateKey
+	Secret string
+
+	sync.Mutex
+	tokens map[string]*installToken
+}
+
+// installToken is an installation token with its expiry.
+type installToken struct {
+	token   string
+	expires time.Time
+}
+
+// NewApp returns the configuration of a GitHub App from its ID, its
+// private key in PEM format, and its webhook secret.
+func NewApp(id int64, key []byte, secret string) (*App, error) {
+	parsed, err := jwt.ParseRSAPrivateKeyFromPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the app private key. %s", err)
+	}
+	return &App{
+		ID:     id,
+		Key:    parsed,
+		Secret: secret,
+		tokens: map[string]*installToken{},
+	}, nil
+}
+
+// Sign returns a JWT authenticating as the app, valid for nine minutes.
+// The token is issued a minute in the past to allow for clock drift.
+func (a *App) Sign() (string, error) {
+	now := time.Now()
+	t := jwt.New(jwt.SigningMethodRS256)
+	t.Claims["iat"] = now.Add(-time.Minute).Unix()
+	t.Claims["exp"] = now.Add(9 * time.Minute).Unix()
+	t.Claims["iss"] = a.ID
+	return t.SignedString(a.Key)
+}
+
+// Token returns the installation token of the repository. The token is
+// cached per repository until shortly before it expires.
+func (a *App) Token(c context.Context, rawurl, owner, name string) (string, error) {
+	return a.token(c, rawurl, owner+"/"+name, func(client *github.Client) (*github.Installation, *github.Response, error) {
+		return client.Apps.FindRepositoryInstallation(c, owner, name)
+	})
+}
+
+// OrgToken returns the installation token of the organization, which is
+// used to list the members of its teams. The token is cached until
+// shortly before it expires.
+func (a *App) OrgToken(c context.Context, rawurl, org string) (string, error) {
+	return a.token(c, rawurl, org, func(client *github.Client) (*github.Installation, *github.Response, error) {
+		return client.Apps.FindOrganizationInstallation(c, org)
+	})
+}
+
+// token is a helper function that returns the cached installation token
+// of the key, or mints a new one for the installation returned by find.
+// The cache is not locked while minting, so that a slow request does not
+// hold back the other repositories.
+func (a *App) token(c context.Context, rawurl, key string, find func(*github.Client) (*github.Installation, *github.Response, error)) (string, error) {
+	a.Lock()
+	t, ok := a.tokens[key]
+	a.Unlock()
+	if ok && time.Now().Add(tokenExpiry).Before(t.expires) {
+		return t.token, nil
+	}
+
+	signed, err := a.Sign()
+	if err != nil {
+		return "", fmt.Errorf("Error signing the app token. %s", err)
+	}
+	client := setupClient(rawurl, signed)
+
+	install, resp, err := find(client)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", &notInstalledError{key: key}
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error fetching the app installation of %s. %s", key, err)
+	}
+	minted, _, err := client.Apps.CreateInstallationToken(c, install.GetID(), nil)
+	if err != nil {
+		return "", fmt.Errorf("Error creating the installation token of %s. %s", key, err)
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	if a.tokens == nil {
+		a.tokens = map[string]*installToken{}
+	}
+	a.tokens[key] = &installToken{
+		token:   minted.GetToken(),
+		expires: minted.GetExpiresAt(),
+	}
+	return minted.GetToken(), nil
+}
+
+// notInstalledError is returned when the app is not installed on the
+// repository or organization.
+type notInstalledError struct {
+	key string
+}
+
+func (e *notInstalledError) Error() string {
+	return fmt.Sprintf("The app is not installed on %s", e.key)
+}
+
+// CheckHook checks the signature of a hook delivered to the app with the
+// app webhook secret.
+func (g *Github) CheckHook(r *http.Request, payload []byte) error {
+	if g.App == nil {
+		return fmt.Errorf("Remote %s is not configured as an app", g.URL)
+	}
+	return token.CheckSignature(r, payload, g.App.Secret)
+}
+
+// repoToken is a helper function that returns the token used to access
+// the repository: the installation token when configured as an app, or
+// else the token of the user. The repositories activated by a user keep
+// using the token of the user until the app is installed on them.
+func (g *Github) repoToken(c context.Context, u *model.User, r *model.Repo) (string, error) {
+	if g.App == nil {
+		return u.Token, nil
+	}
+	token, err := g.App.Token(c, g.API, r.Owner, r.Name)
+	if _, ok := err.(*notInstalledError); ok && len(u.Token) != 0 {
+		return u.Token, nil
+	}
+	return token, err
+}
+
+// repoClient is a helper function that returns a client accessing the
+// repository with the token returned by repoToken.
+func (g *Github) repoClient(c context.Context, u *model.User, r *model.Repo) (*github.Client, error) {
+	token, err := g.repoToken(c, u, r)
+	if err != nil {
+		return nil, err
+	}
+	return setupClient(g.API, token), nil
+}
@@ -0,0 +1,261 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/franela/goblin"
+	"golang.org/x/net/context"
+)
+
+func TestApp(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("GitHub App", func() {
+		var server *httptest.Server
+		var fake *fakeApp
+		var remote *Github
+
+		key, _ := rsa.GenerateKey(rand.Reader, 1024)
+		pemKey := pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})
+
+		g.BeforeEach(func() {
+			fake = &fakeApp{key: &key.PublicKey, expires: time.Hour}
+			server = httptest.NewServer(fake.handler())
+			app, err := NewApp(42, pemKey, "webhook-secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			remote = &Github{URL: "https://github.com", API: server.URL + "/", App: app}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("Should fail to parse an invalid private key", func() {
+			_, err := NewApp(42, []byte("invalid"), "webhook-secret")
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should mint the installation token with a JWT of the app", func() {
+			token, err := remote.App.Token(context.Background(), remote.API, "octocat", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(token).Equal("installation-token-1")
+			g.Assert(fake.issuer).Equal(float64(42))
+		})
+
+		g.It("Should cache the installation token of the repository", func() {
+			remote.App.Token(context.Background(), remote.API, "octocat", "hello-world")
+			token, err := remote.App.Token(context.Background(), remote.API, "octocat", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(token).Equal("installation-token-1")
+			g.Assert(fake.minted).Equal(1)
+		})
+
+		g.It("Should mint a new installation token before it expires", func() {
+			fake.expires = time.Minute
+			remote.App.Token(context.Background(), remote.API, "octocat", "hello-world")
+			token, err := remote.App.Token(context.Background(), remote.API, "octocat", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(token).Equal("installation-token-2")
+			g.Assert(fake.minted).Equal(2)
+		})
+
+		g.It("Should fail without an installation on the repository", func() {
+			_, err := remote.App.Token(context.Background(), remote.API, "octocat", "spoon-knife")
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should use the user token without an installation on the repository", func() {
+			repo := &model.Repo{Owner: "octocat", Name: "spoon-knife", Slug: "octocat/spoon-knife"}
+			token, err := remote.repoToken(context.Background(), &model.User{Token: "cfcd2084"}, repo)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(token).Equal("cfcd2084")
+
+			// repositories activated by installing the app have no user.
+			_, err = remote.repoToken(context.Background(), &model.User{}, repo)
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should use the installation token over the user token", func() {
+			token, err := remote.repoToken(context.Background(), &model.User{Token: "cfcd2084"}, fakeRepo)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(token).Equal("installation-token-1")
+		})
+
+		g.It("Should post the status as the app", func() {
+			status := &model.Status{State: "success", Desc: "approved"}
+			err := remote.SetStatus(context.Background(), &model.User{}, fakeRepo, 1, status)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(fake.status).Equal("Bearer installation-token-1")
+		})
+
+		g.It("Should check the hook signature with the app secret", func() {
+			payload := []byte(`{"action":"created"}`)
+			mac := hmac.New(sha256.New, []byte("webhook-secret"))
+			mac.Write(payload)
+
+			r, _ := http.NewRequest("POST", "/hook", nil)
+			r.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+			g.Assert(remote.CheckHook(r, payload) == nil).IsTrue()
+			g.Assert(remote.CheckHook(r, []byte(`{"action":"deleted"}`)) != nil).IsTrue()
+			g.Assert(new(Github).CheckHook(r, payload) != nil).IsTrue()
+		})
+
+		g.It("Should not sign the hooks of repositories", func() {
+			g.Assert(remote.SignsHooks()).IsFalse()
+			g.Assert(new(Github).SignsHooks()).IsTrue()
+		})
+	})
+}
+
+func TestInstallHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Installation hook", func() {
+		var remote = &Github{URL: "https://github.com"}
+
+		g.It("Should return the repositories of a new installation", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeInstall))
+			r.Header.Set("X-Github-Event", "installation")
+			r.Header.Set("X-Github-Delivery", "72d3162e")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Delivery).Equal("72d3162e")
+			g.Assert(hook.Repo == nil).IsTrue()
+			g.Assert(hook.Install.Sender).Equal("octocat")
+			g.Assert(len(hook.Install.Added)).Equal(2)
+			g.Assert(len(hook.Install.Removed)).Equal(0)
+			g.Assert(hook.Install.Added[0].Owner).Equal("octocat")
+			g.Assert(hook.Install.Added[0].Name).Equal("hello-world")
+			g.Assert(hook.Install.Added[0].Slug).Equal("octocat/hello-world")
+			g.Assert(hook.Install.Added[0].Link).Equal("https://github.com/octocat/hello-world")
+			g.Assert(hook.Install.Added[1].Private).IsTrue()
+		})
+
+		g.It("Should return the repositories of a deleted installation", func() {
+			body := strings.Replace(fakeInstall, `"created"`, `"deleted"`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "installation")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(hook.Install.Added)).Equal(0)
+			g.Assert(len(hook.Install.Removed)).Equal(2)
+		})
+
+		g.It("Should ignore other installation actions", func() {
+			body := strings.Replace(fakeInstall, `"created"`, `"suspend"`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "installation")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+
+		g.It("Should return the repositories added to and removed from an installation", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeInstallRepos))
+			r.Header.Set("X-Github-Event", "installation_repositories")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(hook.Install.Added)).Equal(1)
+			g.Assert(hook.Install.Added[0].Slug).Equal("octocat/spoon-knife")
+			g.Assert(len(hook.Install.Removed)).Equal(1)
+			g.Assert(hook.Install.Removed[0].Slug).Equal("octocat/hello-world")
+		})
+	})
+}
+
+// fakeApp is a fake of the GitHub App endpoints, which is installed on
+// the octocat/hello-world repository.
+type fakeApp struct {
+	key     *rsa.PublicKey
+	expires time.Duration
+	issuer  interface{}
+	minted  int
+	status  string
+}
+
+func (f *fakeApp) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/octocat/hello-world/installation", func(w http.ResponseWriter, r *http.Request) {
+		if !f.authorize(r) {
+			w.WriteHeader(401)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+	mux.HandleFunc("/app/installations/1/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if !f.authorize(r) {
+			w.WriteHeader(401)
+			return
+		}
+		f.minted++
+		w.WriteHeader(201)
+		fmt.Fprintf(w, `{"token":"installation-token-%d","expires_at":"%s"}`,
+			f.minted, time.Now().Add(f.expires).UTC().Format(time.RFC3339))
+	})
+	mux.HandleFunc("/repos/octocat/hello-world/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number":1,"head":{"sha":"6dcb09b"}}`))
+	})
+	mux.HandleFunc("/repos/octocat/hello-world/statuses/6dcb09b", func(w http.ResponseWriter, r *http.Request) {
+		f.status = r.Header.Get("Authorization")
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	})
+	return mux
+}
+
+// authorize checks the request is authenticated with a JWT signed with
+// the app private key.
+func (f *fakeApp) authorize(r *http.Request) bool {
+	raw := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	t, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		return f.key, nil
+	})
+	if err != nil || !t.Valid {
+		return false
+	}
+	f.issuer = t.Claims["iss"]
+	return true
+}
+
+var fakeInstall = `{
+  "action": "created",
+  "installation": {"id": 1},
+  "repositories": [
+    {"id": 1, "name": "hello-world", "full_name": "octocat/hello-world", "private": false},
+    {"id": 2, "name": "spoon-knife", "full_name": "octocat/spoon-knife", "private": true}
+  ],
+  "sender": {"login": "octocat"}
+}`
+
+var fakeInstallRepos = `{
+  "action": "added",
+  "installation": {"id": 1},
+  "repository_selection": "selected",
+  "repositories_added": [
+    {"id": 2, "name": "spoon-knife", "full_name": "octocat/spoon-knife", "private": true}
+  ],
+  "repositories_removed": [
+    {"id": 1, "name": "hello-world", "full_name": "octocat/hello-world", "private": false}
+  ],
+  "sender": {"login": "octocat"}
+}`
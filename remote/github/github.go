@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-gitea/lgtm/model"
@@ -17,6 +18,10 @@ import (
 // name of the status message posted to GitHub
 const contextName = "approvals/lgtm"
 
+// maxPushCommits is the number of commits listed in a push hook, beyond
+// which the push may be truncated.
+const maxPushCommits = 20
+
 // Github provides the available configuration values.
 type Github struct {
 	URL    string
@@ -24,6 +29,14 @@ type Github struct {
 	Client string
 	Secret string
 	Scopes []string
+
+	// MaxPages is the upper bound of pages retrieved from list
+	// endpoints. Zero means no limit.
+	MaxPages int
+
+	// App authenticates as a GitHub App when configured, so that the
+	// repositories do not depend on the token of a user.
+	App *App
 }
 
 // GetUser retrieves the current user from the API.
@@ -82,7 +95,13 @@ func (g *Github) GetUserToken(c context.Context, token string) (string, error) {
 // GetTeams retrieves teams from the API.
 func (g *Github) GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
 	client := setupClient(g.API, user.Token)
-	orgs, _, err := client.Organizations.List(c, "", &github.ListOptions{PerPage: 100})
+
+	var orgs []*github.Organization
+	err := Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.Organizations.List(c, "", opts)
+		orgs = append(orgs, list...)
+		return resp, err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("Error fetching teams. %s", err)
 	}
@@ -98,17 +117,61 @@ func (g *Github) GetTeams(c context.Context, user *model.User) ([]*model.Team, e
 }
 
 // GetMembers retrieves members from the API.
-func (g *Github) GetMembers(c context.Context, user *model.User, org string) ([]*model.Member, error) {
-	client := setupClient(g.API, user.Token)
-	teammates, _, err := client.Teams.ListTeamMembersBySlug(c, org, "maintainers", &github.TeamListTeamMembersOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("Error fetching team members. %s", err)
+func (g *Github) GetMembers(c context.Context, user *model.User, org, team string) ([]*model.Member, error) {
+	token := user.Token
+	if g.App != nil {
+		var err error
+		token, err = g.App.OrgToken(c, g.API, org)
+		if _, ok := err.(*notInstalledError); ok && len(user.Token) != 0 {
+			token, err = user.Token, nil
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
+	client := setupClient(g.API, token)
+
 	var members []*model.Member
-	for _, teammate := range teammates {
-		members = append(members, &model.Member{
-			Login: *teammate.Login,
+	var logins = map[string]bool{}
+	var seen = map[string]bool{}
+	var teams = []string{team}
+
+	// walk the team and its nested child teams
+	for len(teams) != 0 {
+		slug := teams[0]
+		teams = teams[1:]
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		err := Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+			teammates, resp, err := client.Teams.ListTeamMembersBySlug(c, org, slug, &github.TeamListTeamMembersOptions{ListOptions: *opts})
+			for _, teammate := range teammates {
+				if logins[teammate.GetLogin()] {
+					continue
+				}
+				logins[teammate.GetLogin()] = true
+				members = append(members, &model.Member{
+					Login: teammate.GetLogin(),
+				})
+			}
+			return resp, err
 		})
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching team members. %s", err)
+		}
+
+		err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+			children, resp, err := client.Teams.ListChildTeamsByParentSlug(c, org, slug, opts)
+			for _, child := range children {
+				teams = append(teams, child.GetSlug())
+			}
+			return resp, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching child teams. %s", err)
+		}
 	}
 	return members, nil
 }
@@ -146,7 +209,7 @@ func (g *Github) GetPerm(c context.Context, user *model.User, owner, name string
 // GetRepos retrieves repositories from the API.
 func (g *Github) GetRepos(c context.Context, u *model.User) ([]*model.Repo, error) {
 	client := setupClient(g.API, u.Token)
-	all, err := GetUserRepos(c, client)
+	all, err := GetUserRepos(c, client, g.MaxPages)
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +234,10 @@ func (g *Github) GetRepos(c context.Context, u *model.User) ([]*model.Repo, erro
 
 // RemoveIssueLabels removes labels from an issue.
 func (g *Github) RemoveIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
-	client := setupClient(g.API, user.Token)
+	client, err := g.repoClient(c, user, repo)
+	if err != nil {
+		return err
+	}
 	for _, label := range labels {
 		_, err := client.Issues.RemoveLabelForIssue(c, repo.Owner, repo.Name, number, label)
 		if err != nil {
@@ -183,29 +249,84 @@ func (g *Github) RemoveIssueLabels(c context.Context, user *model.User, repo *mo
 
 // AddIssueLabels adds labels to an issue.
 func (g *Github) AddIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
-	client := setupClient(g.API, user.Token)
-	_, _, err := client.Issues.AddLabelsToIssue(c, repo.Owner, repo.Name, number, labels)
+	client, err := g.repoClient(c, user, repo)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.Issues.AddLabelsToIssue(c, repo.Owner, repo.Name, number, labels)
 	return err
 }
 
 // GetIssueLabels get all labels of issue
 func (g *Github) GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error) {
-	client := setupClient(g.API, user.Token)
-	labels, _, err := client.Issues.ListLabelsByIssue(c, repo.Owner, repo.Name, number, &github.ListOptions{})
+	client, err := g.repoClient(c, user, repo)
 	if err != nil {
 		return nil, err
 	}
-	var res = make([]string, len(labels))
-	for i := 0; i < len(labels); i++ {
-		res[i] = labels[i].GetName()
+
+	var res []string
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		labels, resp, err := client.Issues.ListLabelsByIssue(c, repo.Owner, repo.Name, number, opts)
+		for _, label := range labels {
+			res = append(res, label.GetName())
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateLabels creates the labels that do not exist in the repository.
+func (g *Github) CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	client, err := g.repoClient(c, user, repo)
+	if err != nil {
+		return err
+	}
+
+	var exists = map[string]bool{}
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.Issues.ListLabels(c, repo.Owner, repo.Name, opts)
+		for _, label := range list {
+			exists[label.GetName()] = true
+		}
+		return resp, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		if exists[label.Name] {
+			continue
+		}
+		_, _, err := client.Issues.CreateLabel(c, repo.Owner, repo.Name, &github.Label{
+			Name:  github.String(label.Name),
+			Color: github.String(label.Color),
+		})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return res, err
+// SignsHooks returns true, since the webhooks are created with the
+// repository secret, unless configured as an app.
+func (g *Github) SignsHooks() bool {
+	return g.App == nil
 }
 
-// SetHook injects a webhook through the API.
+// SetHook injects a webhook through the API. When configured as an app,
+// the app receives the hooks of the repositories it is installed on,
+// and only the branch protection is configured.
 func (g *Github) SetHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
-	client := setupClient(g.API, user.Token)
+	token, err := g.repoToken(c, user, repo)
+	if err != nil {
+		return err
+	}
+	client := setupClient(g.API, token)
 
 	currentRepo, _, err := client.Repositories.Get(c, repo.Owner, repo.Name)
 
@@ -213,18 +334,20 @@ func (g *Github) SetHook(c context.Context, user *model.User, repo *model.Repo,
 		return err
 	}
 
-	old, err := GetHook(c, client, repo.Owner, repo.Name, link)
-	if err == nil && old != nil {
-		client.Repositories.DeleteHook(c, repo.Owner, repo.Name, *old.ID)
-	}
+	if g.App == nil {
+		old, err := GetHook(c, client, repo.Owner, repo.Name, link, g.MaxPages)
+		if err == nil && old != nil {
+			client.Repositories.DeleteHook(c, repo.Owner, repo.Name, *old.ID)
+		}
 
-	_, err = CreateHook(c, client, repo.Owner, repo.Name, link)
-	if err != nil {
-		log.Debugf("Error creating the webhook at %s. %s", link, err)
-		return err
+		_, err = CreateHook(c, client, repo.Owner, repo.Name, link, repo.Secret)
+		if err != nil {
+			log.Debugf("Error creating the webhook at %s. %s", link, err)
+			return err
+		}
 	}
 
-	currentClient := NewClientToken(g.API, user.Token)
+	currentClient := NewClientToken(g.API, token)
 	statusChecks, err := currentClient.GetBranchStatusCheck(repo.Owner, repo.Name, *currentRepo.DefaultBranch)
 
 	// Branch not protected
@@ -257,19 +380,27 @@ func (g *Github) SetHook(c context.Context, user *model.User, repo *model.Repo,
 	return currentClient.PatchBranchStatusCheck(repo.Owner, repo.Name, *currentRepo.DefaultBranch, statusChecks)
 }
 
-// DelHook removes a webhook through the API.
+// DelHook removes a webhook through the API. When configured as an app,
+// there is no webhook to remove, and only the required status check is
+// removed from the branch protection.
 func (g *Github) DelHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
-	client := setupClient(g.API, user.Token)
-
-	hook, err := GetHook(c, client, repo.Owner, repo.Name, link)
+	token, err := g.repoToken(c, user, repo)
 	if err != nil {
 		return err
-	} else if hook == nil {
-		return nil
 	}
-	_, err = client.Repositories.DeleteHook(c, repo.Owner, repo.Name, *hook.ID)
-	if err != nil {
-		return err
+	client := setupClient(g.API, token)
+
+	if g.App == nil {
+		hook, err := GetHook(c, client, repo.Owner, repo.Name, link, g.MaxPages)
+		if err != nil {
+			return err
+		} else if hook == nil {
+			return nil
+		}
+		_, err = client.Repositories.DeleteHook(c, repo.Owner, repo.Name, *hook.ID)
+		if err != nil {
+			return err
+		}
 	}
 
 	currentRepo, _, err := client.Repositories.Get(c, repo.Owner, repo.Name)
@@ -277,7 +408,7 @@ func (g *Github) DelHook(c context.Context, user *model.User, repo *model.Repo,
 		return err
 	}
 
-	currentClient := NewClientToken(g.API, user.Token)
+	currentClient := NewClientToken(g.API, token)
 	statusChecks, _ := currentClient.GetBranchStatusCheck(repo.Owner, repo.Name, *currentRepo.DefaultBranch)
 	if len(statusChecks.Contexts) == 0 {
 		return nil
@@ -296,83 +427,271 @@ func (g *Github) DelHook(c context.Context, user *model.User, repo *model.Repo,
 
 // GetComments retrieves comments from the API.
 func (g *Github) GetComments(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Comment, error) {
-	client := setupClient(g.API, u.Token)
-
-	apiComments, _, err := client.Issues.ListComments(c, r.Owner, r.Name, num,
-		&github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}},
-	)
+	client, err := g.repoClient(c, u, r)
 	if err != nil {
 		return nil, err
 	}
 
 	comments := []*model.Comment{}
-	for _, comment := range apiComments {
-		comments = append(comments, &model.Comment{
-			Author: *comment.User.Login,
-			Body:   *comment.Body,
-		})
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		apiComments, resp, err := client.Issues.ListComments(c, r.Owner, r.Name, num,
+			&github.IssueListCommentsOptions{ListOptions: *opts},
+		)
+		for _, comment := range apiComments {
+			comments = append(comments, &model.Comment{
+				ID:      comment.GetID(),
+				Author:  *comment.User.Login,
+				Body:    *comment.Body,
+				Created: comment.GetCreatedAt(),
+			})
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
 	}
 	return comments, nil
 }
 
 // GetReviews retrieves reviews from the API.
 func (g *Github) GetReviews(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Review, error) {
-	client := setupClient(g.API, u.Token)
-
-	opts := github.ListOptions{PerPage: 100}
-	apiReviews, _, err := client.PullRequests.ListReviews(c, r.Owner, r.Name, num, &opts)
+	client, err := g.repoClient(c, u, r)
 	if err != nil {
 		return nil, err
 	}
+
 	reviews := []*model.Review{}
-	for _, review := range apiReviews {
-		reviews = append(reviews, &model.Review{
-			Author: *review.User.Login,
-			Body:   *review.Body,
-			State:  *review.State,
-		})
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		apiReviews, resp, err := client.PullRequests.ListReviews(c, r.Owner, r.Name, num, opts)
+		for _, review := range apiReviews {
+			reviews = append(reviews, &model.Review{
+				ID:        review.GetID(),
+				Author:    *review.User.Login,
+				Body:      *review.Body,
+				State:     *review.State,
+				CommitID:  review.GetCommitID(),
+				Submitted: review.GetSubmittedAt(),
+			})
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
 	}
 	return reviews, nil
 }
 
-// GetContents retrieves a file from the API.
-func (g *Github) GetContents(c context.Context, u *model.User, r *model.Repo, path string) ([]byte, error) {
-	client := setupClient(g.API, u.Token)
-	content, _, _, err := client.Repositories.GetContents(c, r.Owner, r.Name, path, nil)
+// GetFiles retrieves the pull request changed files from the API.
+func (g *Github) GetFiles(c context.Context, u *model.User, r *model.Repo, num int) ([]string, error) {
+	client, err := g.repoClient(c, u, r)
 	if err != nil {
 		return nil, err
 	}
 
-	str, err := content.GetContent()
+	var files []string
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.PullRequests.ListFiles(c, r.Owner, r.Name, num, opts)
+		for _, file := range list {
+			files = append(files, file.GetFilename())
+			// renamed files also change the previous path
+			if len(file.GetPreviousFilename()) != 0 {
+				files = append(files, file.GetPreviousFilename())
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
 
+// GetHeadCommit retrieves the pull request head commit from the API.
+func (g *Github) GetHeadCommit(c context.Context, u *model.User, r *model.Repo, num int) (*model.Commit, error) {
+	client, err := g.repoClient(c, u, r)
 	if err != nil {
 		return nil, err
 	}
 
-	return []byte(str), nil
+	pr, _, err := client.PullRequests.Get(c, r.Owner, r.Name, num)
+	if err != nil {
+		return nil, err
+	}
+	commit, _, err := client.Git.GetCommit(c, r.Owner, r.Name, pr.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	return &model.Commit{
+		SHA:     commit.GetSHA(),
+		Created: commit.GetCommitter().GetDate(),
+	}, nil
 }
 
-// SetStatus sets the pull request status through the API.
-func (g *Github) SetStatus(c context.Context, u *model.User, r *model.Repo, num, granted, required int) error {
-	client := setupClient(g.API, u.Token)
+// GetContents retrieves a file at the ref from the API.
+func (g *Github) GetContents(c context.Context, u *model.User, r *model.Repo, path, ref string) ([]byte, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return nil, err
+	}
+	return GetFile(c, client, r.Owner, r.Name, path, ref)
+}
+
+// GetPull retrieves a pull request from the API.
+func (g *Github) GetPull(c context.Context, u *model.User, r *model.Repo, num int) (*model.Issue, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return nil, err
+	}
 
 	pr, _, err := client.PullRequests.Get(c, r.Owner, r.Name, num)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return &model.Issue{
+		Number: pr.GetNumber(),
+		Title:  pr.GetTitle(),
+		Author: pr.GetUser().GetLogin(),
+		Base:   pr.GetBase().GetRef(),
+	}, nil
+}
+
+// GetPulls retrieves the open pull requests from the API.
+func (g *Github) GetPulls(c context.Context, u *model.User, r *model.Repo) ([]*model.Issue, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return nil, err
+	}
+
+	pulls := []*model.Issue{}
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.PullRequests.List(c, r.Owner, r.Name,
+			&github.PullRequestListOptions{State: "open", ListOptions: *opts},
+		)
+		for _, pr := range list {
+			pulls = append(pulls, &model.Issue{
+				Number: pr.GetNumber(),
+				Title:  pr.GetTitle(),
+				Author: pr.GetUser().GetLogin(),
+				Base:   pr.GetBase().GetRef(),
+			})
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pulls, nil
+}
+
+// GetStatus retrieves the approval status of the commit from the API.
+func (g *Github) GetStatus(c context.Context, u *model.User, r *model.Repo, sha string) (*model.Status, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return nil, err
+	}
+
+	status := new(model.Status)
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		combined, resp, err := client.Repositories.GetCombinedStatus(c, r.Owner, r.Name, sha, opts)
+		if err != nil {
+			return resp, err
+		}
+		for _, s := range combined.Statuses {
+			if s.GetContext() == contextName {
+				status.State = s.GetState()
+				status.Desc = s.GetDescription()
+			}
+		}
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// IsProtected checks if the branch is protected from the API.
+func (g *Github) IsProtected(c context.Context, u *model.User, r *model.Repo, branch string) (bool, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return false, err
+	}
+
+	b, _, err := client.Repositories.GetBranch(c, r.Owner, r.Name, branch)
+	if err != nil {
+		return false, err
+	}
+	return b.GetProtected(), nil
+}
+
+// IsMerged checks if the commit was merged through a pull request from
+// the API.
+func (g *Github) IsMerged(c context.Context, u *model.User, r *model.Repo, sha string) (bool, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return false, err
+	}
+
+	var merged bool
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		pulls, resp, err := client.PullRequests.ListPullRequestsWithCommit(c, r.Owner, r.Name, sha,
+			&github.PullRequestListOptions{State: "closed", ListOptions: *opts},
+		)
+		for _, pr := range pulls {
+			if pr.MergedAt != nil {
+				merged = true
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		return false, err
+	}
+	return merged, nil
+}
+
+// GetAdmins retrieves the repository administrators from the API.
+func (g *Github) GetAdmins(c context.Context, u *model.User, r *model.Repo) ([]*model.Member, error) {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var admins []*model.Member
+	err = Paginate(g.MaxPages, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.Repositories.ListCollaborators(c, r.Owner, r.Name, &github.ListCollaboratorsOptions{ListOptions: *opts})
+		for _, collaborator := range list {
+			if collaborator.Permissions == nil || !(*collaborator.Permissions)["admin"] {
+				continue
+			}
+			admins = append(admins, &model.Member{
+				Login: collaborator.GetLogin(),
+			})
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return admins, nil
+}
 
-	status := "success"
-	desc := "this commit looks good"
+// SetStatus sets the pull request status through the API.
+func (g *Github) SetStatus(c context.Context, u *model.User, r *model.Repo, num int, status *model.Status) error {
+	client, err := g.repoClient(c, u, r)
+	if err != nil {
+		return err
+	}
 
-	if granted < required {
-		status = "pending"
-		desc = fmt.Sprintf("%d of %d required approvals granted", granted, required)
+	pr, _, err := client.PullRequests.Get(c, r.Owner, r.Name, num)
+	if err != nil {
+		return err
 	}
 
 	data := github.RepoStatus{
 		Context:     github.String(contextName),
-		State:       github.String(status),
-		Description: github.String(desc),
+		State:       github.String(status.State),
+		Description: github.String(status.Desc),
 	}
 
 	_, _, err = client.Repositories.CreateStatus(c, r.Owner, r.Name, *pr.Head.SHA, &data)
@@ -381,9 +700,25 @@ func (g *Github) SetStatus(c context.Context, u *model.User, r *model.Repo, num,
 
 // GetHook gets a webhook from the API.
 func (g *Github) GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
-	// only process comment hooks
-	if r.Header.Get("X-Github-Event") != "issue_comment" &&
-		r.Header.Get("X-Github-Event") != "pull_request_review" {
+	event := r.Header.Get("X-Github-Event")
+
+	// pushes may bypass the approval of protected branches, and pushes
+	// to the default branch may change the approval policy of the open
+	// pull requests.
+	if event == "push" {
+		return getPushHook(r)
+	}
+
+	// installing the app on repositories activates them, and removing
+	// them from the installation deactivates them.
+	if event == "installation" || event == "installation_repositories" {
+		return g.getInstallHook(r, event)
+	}
+
+	// only process comment, review and pull request hooks
+	if event != "issue_comment" &&
+		event != "pull_request_review" &&
+		event != "pull_request" {
 		return nil, nil
 	}
 
@@ -393,12 +728,36 @@ func (g *Github) GetHook(c context.Context, r *http.Request) (*model.Hook, error
 		return nil, err
 	}
 
+	// only process pull request actions that require the status to be
+	// set or recalculated: a newly opened, reopened or undrafted pull
+	// request needs its initial status, and new commits may invalidate
+	// existing approvals. Draft pull requests are ignored until they
+	// are marked ready for review.
+	if event == "pull_request" {
+		switch data.Action {
+		case "opened", "reopened", "synchronize":
+			if data.PullRequest.Draft {
+				return nil, nil
+			}
+		case "ready_for_review":
+		case "closed":
+			if data.PullRequest.Merged {
+				return getMergeHook(r, &data), nil
+			}
+			return nil, nil
+		default:
+			return nil, nil
+		}
+	}
+
 	if len(data.Issue.PullRequest.Link) == 0 &&
 		len(data.PullRequest.URL) == 0 {
 		return nil, nil
 	}
 
 	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Github-Delivery")
+	hook.Event = event
 	hook.Issue = new(model.Issue)
 	hook.Issue.Number = data.Issue.Number
 	hook.Issue.Author = data.Issue.User.Login
@@ -411,13 +770,155 @@ func (g *Github) GetHook(c context.Context, r *http.Request) (*model.Hook, error
 	hook.Comment.Author = data.Comment.User.Login
 
 	hook.Review = new(model.Review)
+	hook.Review.ID = data.Review.ID
 	hook.Review.Body = data.Review.Body
 	hook.Review.Author = data.Review.User.Login
 	hook.Review.State = data.Review.State
 
 	if data.PullRequest.Number > 0 {
 		hook.Issue.Number = data.PullRequest.Number
+		hook.Issue.Author = data.PullRequest.User.Login
+		hook.Issue.Base = data.PullRequest.Base.Ref
+	}
+
+	// pull request hooks are delivered when the head commit is pushed,
+	// which is when stale approvals are dismissed.
+	if event == "pull_request" {
+		hook.Issue.Head = data.PullRequest.Head.SHA
+	}
+
+	return hook, nil
+}
+
+// getMergeHook is a helper function that returns the merge of a closed
+// and merged pull request.
+func getMergeHook(r *http.Request, data *commentHook) *model.Hook {
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Github-Delivery")
+	hook.Event = "pull_request"
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = data.Repository.Owner.Login
+	hook.Repo.Name = data.Repository.Name
+	hook.Repo.Slug = data.Repository.FullName
+	hook.Merge = new(model.Merge)
+	hook.Merge.Number = data.PullRequest.Number
+	hook.Merge.Title = data.PullRequest.Title
+	hook.Merge.Author = data.PullRequest.User.Login
+	hook.Merge.Base = data.PullRequest.Base.Ref
+	hook.Merge.SHA = data.PullRequest.MergeCommitSHA
+	hook.Merge.HeadSHA = data.PullRequest.Head.SHA
+	hook.Merge.MergedBy = data.PullRequest.MergedBy.Login
+	hook.Merge.Merged = data.PullRequest.MergedAt.Unix()
+	return hook
+}
+
+// getInstallHook is a helper function that parses an installation hook,
+// and returns the repositories added to or removed from the app
+// installation.
+func (g *Github) getInstallHook(r *http.Request, event string) (*model.Hook, error) {
+	install := new(model.Install)
+	if event == "installation" {
+		data := github.InstallationEvent{}
+		err := json.NewDecoder(r.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		install.Sender = data.GetSender().GetLogin()
+		switch data.GetAction() {
+		case "created":
+			install.Added = g.toRepos(data.Repositories)
+		case "deleted":
+			install.Removed = g.toRepos(data.Repositories)
+		default:
+			return nil, nil
+		}
+	} else {
+		data := github.InstallationRepositoriesEvent{}
+		err := json.NewDecoder(r.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		install.Sender = data.GetSender().GetLogin()
+		install.Added = g.toRepos(data.RepositoriesAdded)
+		install.Removed = g.toRepos(data.RepositoriesRemoved)
+	}
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Github-Delivery")
+	hook.Event = event
+	hook.Install = install
+	return hook, nil
+}
+
+// toRepos is a helper function that converts the repositories of an
+// installation hook, which only carry their full name.
+func (g *Github) toRepos(from []*github.Repository) []*model.Repo {
+	var repos []*model.Repo
+	for _, repo := range from {
+		parts := strings.SplitN(repo.GetFullName(), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		repos = append(repos, &model.Repo{
+			Owner:   parts[0],
+			Name:    parts[1],
+			Slug:    repo.GetFullName(),
+			Link:    fmt.Sprintf("%s/%s", g.URL, repo.GetFullName()),
+			Private: repo.GetPrivate(),
+		})
+	}
+	return repos
+}
+
+// getPushHook is a helper function that parses a push hook, and returns
+// the commits pushed and the files changed on the branch.
+func getPushHook(r *http.Request) (*model.Hook, error) {
+	data := pushHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
 	}
 
+	// only process pushes to branches, as opposed to tags, that were
+	// not deleted.
+	if !strings.HasPrefix(data.Ref, "refs/heads/") || data.Deleted {
+		return nil, nil
+	}
+	branch := strings.TrimPrefix(data.Ref, "refs/heads/")
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Github-Delivery")
+	hook.Event = "push"
+	hook.Repo = new(model.Repo)
+	hook.Repo.Owner = data.Repository.Owner.Login
+	hook.Repo.Name = data.Repository.Name
+	hook.Repo.Slug = data.Repository.FullName
+	hook.Push = new(model.Push)
+	hook.Push.Branch = branch
+	hook.Push.Default = branch == data.Repository.DefaultBranch
+	hook.Push.Pusher = data.Pusher.Name
+
+	// the payload lists a limited number of commits, and does not tell
+	// whether commits were left out.
+	hook.Push.Truncated = len(data.Commits) >= maxPushCommits
+
+	seen := map[string]bool{}
+	for _, commit := range data.Commits {
+		hook.Push.Commits = append(hook.Push.Commits, &model.Commit{
+			SHA:     commit.ID,
+			Author:  commit.Author.Username,
+			Message: commit.Message,
+			Link:    commit.URL,
+			Created: commit.Timestamp,
+		})
+		for _, files := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range files {
+				if !seen[file] {
+					seen[file] = true
+					hook.Push.Files = append(hook.Push.Files, file)
+				}
+			}
+		}
+	}
 	return hook, nil
 }
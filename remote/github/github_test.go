@@ -0,0 +1,404 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/franela/goblin"
+	"github.com/google/go-github/v33/github"
+	"golang.org/x/net/context"
+)
+
+func TestPaginate(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Pagination", func() {
+		var server *httptest.Server
+		var remote *Github
+
+		g.BeforeEach(func() {
+			server = httptest.NewServer(fakeAPI())
+			remote = &Github{API: server.URL + "/"}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("Should get comments from all pages", func() {
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(250)
+			g.Assert(comments[249].Body).Equal("comment 249")
+		})
+
+		g.It("Should get reviews from all pages", func() {
+			reviews, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(reviews)).Equal(250)
+		})
+
+		g.It("Should get open pull requests from all pages", func() {
+			pulls, err := remote.GetPulls(context.Background(), fakeUser, fakeRepo)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(pulls)).Equal(250)
+			g.Assert(pulls[249].Number).Equal(249)
+			g.Assert(pulls[249].Base).Equal("master")
+		})
+
+		g.It("Should get labels from all pages", func() {
+			labels, err := remote.GetIssueLabels(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(labels)).Equal(250)
+		})
+
+		g.It("Should get teams from all pages", func() {
+			teams, err := remote.GetTeams(context.Background(), fakeUser)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(teams)).Equal(250)
+		})
+
+		g.It("Should get members from all pages", func() {
+			members, err := remote.GetMembers(context.Background(), fakeUser, "octocat", "maintainers")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(members)).Equal(251)
+			g.Assert(members[249].Login).Equal("member-249")
+		})
+
+		g.It("Should get members of child teams once", func() {
+			members, err := remote.GetMembers(context.Background(), fakeUser, "octocat", "maintainers")
+			g.Assert(err == nil).IsTrue()
+			logins := map[string]int{}
+			for _, member := range members {
+				logins[member.Login]++
+			}
+			g.Assert(logins["reviewer-0"]).Equal(1)
+			g.Assert(logins["member-0"]).Equal(1)
+			g.Assert(logins["member-249"]).Equal(1)
+			g.Assert(members[250].Login).Equal("reviewer-0")
+		})
+
+		g.It("Should get the hook from the last page", func() {
+			client := setupClient(server.URL+"/", fakeUser.Token)
+			hook, err := GetHook(context.Background(), client, "octocat", "hello-world", "http://hook-249.example.com/hook", 0)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.GetID()).Equal(int64(249))
+		})
+
+		g.It("Should fail at the page limit", func() {
+			remote.MaxPages = 2
+			_, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should get the last page at the page limit", func() {
+			remote.MaxPages = 3
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(250)
+		})
+
+		g.It("Should return the page error", func() {
+			client := setupClient(server.URL+"/", fakeUser.Token)
+			err := Paginate(0, func(opts *github.ListOptions) (*github.Response, error) {
+				_, resp, err := client.Issues.ListComments(context.Background(), "octocat", "missing", 1,
+					&github.IssueListCommentsOptions{ListOptions: *opts},
+				)
+				return resp, err
+			})
+			g.Assert(err != nil).IsTrue()
+		})
+	})
+}
+
+func TestPushHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Push hook", func() {
+		var remote = new(Github)
+
+		g.It("Should return the files changed on the default branch", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakePush))
+			r.Header.Set("X-Github-Event", "push")
+			r.Header.Set("X-Github-Delivery", "72d3162e")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Delivery).Equal("72d3162e")
+			g.Assert(hook.Repo.Slug).Equal("octocat/hello-world")
+			g.Assert(hook.Push.Branch).Equal("master")
+			g.Assert(hook.Push.Files).Equal([]string{"MAINTAINERS", "README.md", ".lgtm"})
+		})
+
+		g.It("Should return the commits pushed", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakePush))
+			r.Header.Set("X-Github-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Push.Default).IsTrue()
+			g.Assert(hook.Push.Pusher).Equal("octocat")
+			g.Assert(len(hook.Push.Commits)).Equal(2)
+			g.Assert(hook.Push.Commits[1].SHA).Equal("a1b2c3d")
+			g.Assert(hook.Push.Commits[1].Author).Equal("bradrydzewski")
+			g.Assert(hook.Push.Commits[1].Message).Equal("Update the policy")
+		})
+
+		g.It("Should flag pushes to other branches", func() {
+			body := strings.Replace(fakePush, "refs/heads/master", "refs/heads/feature", 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Push.Branch).Equal("feature")
+			g.Assert(hook.Push.Default).IsFalse()
+		})
+
+		g.It("Should flag pushes that may be truncated", func() {
+			commits := make([]string, maxPushCommits)
+			for i := range commits {
+				commits[i] = fmt.Sprintf(`{"id": "%d", "message": "Commit %d", "author": {"username": "octocat"}}`, i, i)
+			}
+			body := fmt.Sprintf(`{"ref": "refs/heads/master", "commits": [%s], "repository": {"default_branch": "master"}}`, strings.Join(commits, ","))
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(hook.Push.Commits)).Equal(maxPushCommits)
+			g.Assert(hook.Push.Truncated).IsTrue()
+
+			r, _ = http.NewRequest("POST", "/hook", strings.NewReader(fakePush))
+			r.Header.Set("X-Github-Event", "push")
+			hook, _ = remote.GetHook(context.Background(), r)
+			g.Assert(hook.Push.Truncated).IsFalse()
+		})
+
+		g.It("Should ignore pushes of tags", func() {
+			body := strings.Replace(fakePush, "refs/heads/master", "refs/tags/v1.0.0", 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "push")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+func TestPullRequestHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Pull request hook", func() {
+		var remote = new(Github)
+
+		g.It("Should return the pull request of the actions setting the status", func() {
+			for _, action := range []string{"opened", "reopened", "ready_for_review", "synchronize"} {
+				body := strings.Replace(fakePullRequest, `"opened"`, `"`+action+`"`, 1)
+				r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+				r.Header.Set("X-Github-Event", "pull_request")
+				r.Header.Set("X-Github-Delivery", "72d3162e")
+				hook, err := remote.GetHook(context.Background(), r)
+				g.Assert(err == nil).IsTrue()
+				g.Assert(hook.Delivery).Equal("72d3162e")
+				g.Assert(hook.Event).Equal("pull_request")
+				g.Assert(hook.Repo.Slug).Equal("octocat/hello-world")
+				g.Assert(hook.Issue.Number).Equal(42)
+				g.Assert(hook.Issue.Author).Equal("octocat")
+				g.Assert(hook.Issue.Base).Equal("master")
+				g.Assert(hook.Issue.Head).Equal("6dcb09b")
+			}
+		})
+
+		g.It("Should ignore draft pull requests", func() {
+			for _, action := range []string{"opened", "reopened", "synchronize"} {
+				body := strings.Replace(fakePullRequest, `"opened"`, `"`+action+`"`, 1)
+				body = strings.Replace(body, `"draft": false`, `"draft": true`, 1)
+				r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+				r.Header.Set("X-Github-Event", "pull_request")
+				hook, err := remote.GetHook(context.Background(), r)
+				g.Assert(err == nil).IsTrue()
+				g.Assert(hook == nil).IsTrue()
+			}
+		})
+
+		g.It("Should return draft pull requests marked ready for review", func() {
+			body := strings.Replace(fakePullRequest, `"opened"`, `"ready_for_review"`, 1)
+			body = strings.Replace(body, `"draft": false`, `"draft": true`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "pull_request")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue.Number).Equal(42)
+		})
+
+		g.It("Should ignore other pull request actions", func() {
+			body := strings.Replace(fakePullRequest, `"opened"`, `"labeled"`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "pull_request")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+func TestMergeHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Merge hook", func() {
+		var remote = new(Github)
+
+		g.It("Should return the merge of a closed pull request", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeMerge))
+			r.Header.Set("X-Github-Event", "pull_request")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue == nil).IsTrue()
+			g.Assert(hook.Merge.Number).Equal(42)
+			g.Assert(hook.Merge.Base).Equal("master")
+			g.Assert(hook.Merge.SHA).Equal("a1b2c3d")
+			g.Assert(hook.Merge.HeadSHA).Equal("6dcb09b")
+			g.Assert(hook.Merge.MergedBy).Equal("bradrydzewski")
+			g.Assert(hook.Merge.Merged).Equal(int64(1592222400))
+		})
+
+		g.It("Should ignore pull requests closed without merging", func() {
+			body := strings.Replace(fakeMerge, `"merged": true`, `"merged": false`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Github-Event", "pull_request")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+// fakeAPI returns a fake of the GitHub API, which serves 250 items
+// from each list endpoint in pages of at most 100 items. The maintainers
+// team has a reviewers child team, which shares some of its members.
+func fakeAPI() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/octocat/hello-world/issues/1/comments", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"body":"comment %d","user":{"login":"octocat"}}`, i, i)
+	}))
+	mux.HandleFunc("/repos/octocat/hello-world/pulls/1/reviews", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"body":"","state":"COMMENTED","user":{"login":"octocat"}}`, i)
+	}))
+	mux.HandleFunc("/repos/octocat/hello-world/pulls", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"number":%d,"user":{"login":"octocat"},"base":{"ref":"master"}}`, i)
+	}))
+	mux.HandleFunc("/repos/octocat/hello-world/issues/1/labels", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"name":"label %d"}`, i, i)
+	}))
+	mux.HandleFunc("/repos/octocat/hello-world/hooks", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"config":{"url":"http://hook-%d.example.com/hook"}}`, i, i)
+	}))
+	mux.HandleFunc("/user/orgs", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"login":"org-%d","avatar_url":""}`, i)
+	}))
+	mux.HandleFunc("/orgs/octocat/teams/maintainers/members", fakeList(func(i int) string {
+		return fmt.Sprintf(`{"login":"member-%d"}`, i)
+	}))
+	mux.HandleFunc("/orgs/octocat/teams/maintainers/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"slug":"reviewers"}]`))
+	})
+	mux.HandleFunc("/orgs/octocat/teams/reviewers/members", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"login":"member-0"},{"login":"reviewer-0"},{"login":"member-249"}]`))
+	})
+	mux.HandleFunc("/orgs/octocat/teams/reviewers/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"slug":"maintainers"}]`))
+	})
+	return mux
+}
+
+// fakeList returns a handler that serves a page of 250 items rendered
+// by the item function, with the pagination Link header.
+func fakeList(item func(int) string) http.HandlerFunc {
+	const total = 250
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		size, _ := strconv.Atoi(r.FormValue("per_page"))
+		if page == 0 {
+			page = 1
+		}
+		if size == 0 {
+			size = 30
+		}
+		start, end := (page-1)*size, page*size
+		if end >= total {
+			end = total
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d&per_page=%d>; rel="next"`, r.Host, r.URL.Path, page+1, size))
+		}
+		w.Write([]byte("["))
+		for i := start; i < end; i++ {
+			if i != start {
+				w.Write([]byte(","))
+			}
+			w.Write([]byte(item(i)))
+		}
+		w.Write([]byte("]"))
+	}
+}
+
+var fakePush = `{
+  "ref": "refs/heads/master",
+  "pusher": {"name": "octocat"},
+  "commits": [
+    {"id": "6dcb09b", "message": "Add maintainers", "author": {"username": "octocat"}, "added": ["MAINTAINERS"], "removed": [], "modified": ["README.md"]},
+    {"id": "a1b2c3d", "message": "Update the policy", "author": {"username": "bradrydzewski"}, "added": [], "removed": [], "modified": [".lgtm", "README.md"]}
+  ],
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "default_branch": "master",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var fakePullRequest = `{
+  "action": "opened",
+  "pull_request": {
+    "url": "https://api.github.com/repos/octocat/hello-world/pulls/42",
+    "number": 42,
+    "title": "Update the README",
+    "user": {"login": "octocat"},
+    "base": {"ref": "master"},
+    "head": {"sha": "6dcb09b"},
+    "draft": false
+  },
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var fakeMerge = `{
+  "action": "closed",
+  "pull_request": {
+    "url": "https://api.github.com/repos/octocat/hello-world/pulls/42",
+    "number": 42,
+    "title": "Update the README",
+    "user": {"login": "octocat"},
+    "base": {"ref": "master"},
+    "head": {"sha": "6dcb09b"},
+    "merged": true,
+    "merged_at": "2020-06-15T12:00:00Z",
+    "merge_commit_sha": "a1b2c3d",
+    "merged_by": {"login": "bradrydzewski"}
+  },
+  "repository": {
+    "name": "hello-world",
+    "full_name": "octocat/hello-world",
+    "owner": {"login": "octocat"}
+  }
+}`
+
+var (
+	fakeUser = &model.User{Login: "octocat", Token: "cfcd2084"}
+	fakeRepo = &model.Repo{Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+)
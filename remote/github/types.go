@@ -1,5 +1,7 @@
 package github
 
+import "time"
+
 // Error represents an API error.
 type Error struct {
 	Message string `json:"message"`
@@ -43,8 +45,11 @@ type Restrictions struct {
 	Teams []string `json:"teams"`
 }
 
-// commentHook represents a subset of the issue_comment payload.
+// commentHook represents a subset of the issue_comment, pull_request_review
+// and pull_request payloads.
 type commentHook struct {
+	Action string `json:"action"`
+
 	Issue struct {
 		Link   string `json:"html_url"`
 		Number int    `json:"number"`
@@ -77,7 +82,7 @@ type commentHook struct {
 	} `json:"repository"`
 
 	Review struct {
-		ID   int `json:"id"`
+		ID   int64 `json:"id"`
 		User struct {
 			Login string `json:"login"`
 			ID    int    `json:"id"`
@@ -91,5 +96,53 @@ type commentHook struct {
 		ID       int    `json:"id"`
 		IssueURL string `json:"issue_url"`
 		Number   int    `json:"number"`
+		Title    string `json:"title"`
+		User     struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+		Draft          bool      `json:"draft"`
+		Merged         bool      `json:"merged"`
+		MergedAt       time.Time `json:"merged_at"`
+		MergeCommitSHA string    `json:"merge_commit_sha"`
+		MergedBy       struct {
+			Login string `json:"login"`
+		} `json:"merged_by"`
 	} `json:"pull_request"`
 }
+
+type pushHook struct {
+	Ref     string `json:"ref"`
+	Deleted bool   `json:"deleted"`
+
+	Pusher struct {
+		Name string `json:"name"`
+	} `json:"pusher"`
+
+	Commits []struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		URL       string    `json:"url"`
+		Timestamp time.Time `json:"timestamp"`
+		Author    struct {
+			Username string `json:"username"`
+		} `json:"author"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+
+	Repository struct {
+		Name          string `json:"name"`
+		FullName      string `json:"full_name"`
+		DefaultBranch string `json:"default_branch"`
+		Owner         struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
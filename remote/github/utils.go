@@ -9,6 +9,30 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// perPage is the number of items requested per page.
+const perPage = 100
+
+// Paginate is a helper function that calls the list function for each
+// page of results, until the last page is retrieved. If the limit is
+// greater than zero, at most limit pages are retrieved, and an error is
+// returned if more pages remain.
+func Paginate(limit int, list func(opts *github.ListOptions) (*github.Response, error)) error {
+	var opts = github.ListOptions{PerPage: perPage, Page: 1}
+	for pages := 0; opts.Page > 0; pages++ {
+		if limit > 0 && pages == limit {
+			return fmt.Errorf("Reached the limit of %d pages. Remaining results are not retrieved.", limit)
+		}
+		resp, err := list(&opts)
+		if err != nil {
+			return err
+		}
+
+		// increment the next page to retrieve
+		opts.Page = resp.NextPage
+	}
+	return nil
+}
+
 func setupClient(rawurl, accessToken string) *github.Client {
 	token := oauth2.Token{AccessToken: accessToken}
 	source := oauth2.StaticTokenSource(&token)
@@ -21,8 +45,13 @@ func setupClient(rawurl, accessToken string) *github.Client {
 // GetHook is a helper function that retrieves a hook by
 // hostname. To do this, it will retrieve a list of all hooks
 // and iterate through the list.
-func GetHook(c context.Context, client *github.Client, owner, name, rawurl string) (*github.Hook, error) {
-	hooks, _, err := client.Repositories.ListHooks(c, owner, name, nil)
+func GetHook(c context.Context, client *github.Client, owner, name, rawurl string, limit int) (*github.Hook, error) {
+	var hooks []*github.Hook
+	err := Paginate(limit, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.Repositories.ListHooks(c, owner, name, opts)
+		hooks = append(hooks, list...)
+		return resp, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +79,8 @@ func GetHook(c context.Context, client *github.Client, owner, name, rawurl strin
 
 // DeleteHook is a helper function that deletes a post-commit hook
 // for the specified repository.
-func DeleteHook(c context.Context, client *github.Client, owner, name, url string) error {
-	hook, err := GetHook(c, client, owner, name, url)
+func DeleteHook(c context.Context, client *github.Client, owner, name, url string, limit int) error {
+	hook, err := GetHook(c, client, owner, name, url, limit)
 	if err != nil {
 		return err
 	}
@@ -63,13 +92,14 @@ func DeleteHook(c context.Context, client *github.Client, owner, name, url strin
 }
 
 // CreateHook is a helper function that creates a post-commit hook
-// for the specified repository.
-func CreateHook(c context.Context, client *github.Client, owner, name, url string) (*github.Hook, error) {
+// for the specified repository. Payloads are signed with the secret.
+func CreateHook(c context.Context, client *github.Client, owner, name, url, secret string) (*github.Hook, error) {
 	var hook = new(github.Hook)
-	hook.Events = []string{"issue_comment", "pull_request_review"}
+	hook.Events = []string{"issue_comment", "pull_request_review", "pull_request", "push"}
 	hook.Config = map[string]interface{}{}
 	hook.Config["url"] = url
 	hook.Config["content_type"] = "json"
+	hook.Config["secret"] = secret
 	created, _, err := client.Repositories.CreateHook(c, owner, name, hook)
 	return created, err
 }
@@ -95,23 +125,15 @@ func GetFile(c context.Context, client *github.Client, owner, name, path, ref st
 // GetUserRepos is a helper function that returns a list of
 // all user repositories. Paginated results are aggregated into
 // a single list.
-func GetUserRepos(c context.Context, client *github.Client) ([]*github.Repository, error) {
+func GetUserRepos(c context.Context, client *github.Client, limit int) ([]*github.Repository, error) {
 	var repos []*github.Repository
-	var opts = github.RepositoryListOptions{}
-	opts.PerPage = 100
-	opts.Page = 1
-
-	// loop through user repository list
-	for opts.Page > 0 {
-		list, resp, err := client.Repositories.List(c, "", &opts)
-		if err != nil {
-			return nil, err
-		}
+	err := Paginate(limit, func(opts *github.ListOptions) (*github.Response, error) {
+		list, resp, err := client.Repositories.List(c, "", &github.RepositoryListOptions{ListOptions: *opts})
 		repos = append(repos, list...)
-
-		// increment the next page to retrieve
-		opts.Page = resp.NextPage
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return repos, nil
 }
@@ -0,0 +1,425 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	pathUser           = "%s/api/v4/user"
+	pathGroups         = "%s/api/v4/groups?min_access_level=10&page=%d&per_page=%d"
+	pathGroupMembers   = "%s/api/v4/groups/%s/members/all?page=%d&per_page=%d"
+	pathProjects       = "%s/api/v4/projects?membership=true&min_access_level=40&page=%d&per_page=%d"
+	pathProject        = "%s/api/v4/projects/%s"
+	pathProjectMembers = "%s/api/v4/projects/%s/members/all?page=%d&per_page=%d"
+	pathRaw            = "%s/api/v4/projects/%s/repository/files/%s/raw?ref=%s"
+	pathCommit         = "%s/api/v4/projects/%s/repository/commits/%s"
+	pathCommitMRs      = "%s/api/v4/projects/%s/repository/commits/%s/merge_requests"
+	pathCommitStatuses = "%s/api/v4/projects/%s/repository/commits/%s/statuses?name=%s&all=true&page=%d&per_page=%d"
+	pathStatus         = "%s/api/v4/projects/%s/statuses/%s"
+	pathBranch         = "%s/api/v4/projects/%s/repository/branches/%s"
+	pathProtected      = "%s/api/v4/projects/%s/protected_branches/%s"
+	pathProtect        = "%s/api/v4/projects/%s/protected_branches"
+	pathMergeRequests  = "%s/api/v4/projects/%s/merge_requests?state=opened&page=%d&per_page=%d"
+	pathMergeRequest   = "%s/api/v4/projects/%s/merge_requests/%d"
+	pathNotes          = "%s/api/v4/projects/%s/merge_requests/%d/notes?sort=asc&order_by=created_at&page=%d&per_page=%d"
+	pathApprovals      = "%s/api/v4/projects/%s/merge_requests/%d/approvals"
+	pathDiffs          = "%s/api/v4/projects/%s/merge_requests/%d/diffs?page=%d&per_page=%d"
+	pathLabels         = "%s/api/v4/projects/%s/labels?page=%d&per_page=%d"
+	pathLabel          = "%s/api/v4/projects/%s/labels"
+	pathHooks          = "%s/api/v4/projects/%s/hooks?page=%d&per_page=%d"
+	pathHook           = "%s/api/v4/projects/%s/hooks/%d"
+	pathHookCreate     = "%s/api/v4/projects/%s/hooks"
+)
+
+// perPage is the number of items requested per page.
+const perPage = 100
+
+// Paginate is a helper function that calls the list function for each
+// page of results, until the last page is retrieved. The list function
+// returns the next page, or zero for the last page. If the limit is
+// greater than zero, at most limit pages are retrieved, and an error is
+// returned if more pages remain.
+func Paginate(limit int, list func(page int) (int, error)) error {
+	for page, pages := 1, 0; page > 0; pages++ {
+		if limit > 0 && pages == limit {
+			return fmt.Errorf("Reached the limit of %d pages. Remaining results are not retrieved.", limit)
+		}
+		next, err := list(page)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+	return nil
+}
+
+// Client represents the simple HTTP client for the GitLab API.
+type Client struct {
+	client *http.Client
+	base   string // base url
+}
+
+// NewClient returns a client at the specified url.
+func NewClient(uri string) *Client {
+	return &Client{http.DefaultClient, strings.TrimSuffix(uri, "/")}
+}
+
+// NewClientToken returns a client at the specified url that
+// authenticates all outbound requests with the given token.
+func NewClientToken(uri, token string) *Client {
+	config := new(oauth2.Config)
+	auther := config.Client(oauth2.NoContext, &oauth2.Token{AccessToken: token})
+	return &Client{auther, strings.TrimSuffix(uri, "/")}
+}
+
+// SetClient sets the default http client. This should be
+// used in conjunction with golang.org/x/oauth2 to
+// authenticate requests to the server.
+func (c *Client) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// GetUser retrieves the currently authenticated user.
+func (c *Client) GetUser() (*User, error) {
+	out := new(User)
+	uri := fmt.Sprintf(pathUser, c.base)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetGroups retrieves a page of the groups of the currently
+// authenticated user.
+func (c *Client) GetGroups(page int) ([]*Group, int, error) {
+	var out []*Group
+	uri := fmt.Sprintf(pathGroups, c.base, page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetGroupMembers retrieves a page of the group members, including the
+// members inherited from the parent groups.
+func (c *Client) GetGroupMembers(group string, page int) ([]*Member, int, error) {
+	var out []*Member
+	uri := fmt.Sprintf(pathGroupMembers, c.base, encode(group), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetProjects retrieves a page of the projects the currently
+// authenticated user maintains.
+func (c *Client) GetProjects(page int) ([]*Project, int, error) {
+	var out []*Project
+	uri := fmt.Sprintf(pathProjects, c.base, page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetProject retrieves a project, including the permissions of the
+// currently authenticated user.
+func (c *Client) GetProject(project string) (*Project, error) {
+	out := new(Project)
+	uri := fmt.Sprintf(pathProject, c.base, encode(project))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// EnablePipelineCheck requires the pipeline of a merge request to
+// succeed before it can be merged.
+func (c *Client) EnablePipelineCheck(project string) error {
+	in := map[string]bool{"only_allow_merge_if_pipeline_succeeds": true}
+	uri := fmt.Sprintf(pathProject, c.base, encode(project))
+	return c.put(uri, in, nil)
+}
+
+// GetProjectMembers retrieves a page of the project members, including
+// the members inherited from the groups.
+func (c *Client) GetProjectMembers(project string, page int) ([]*Member, int, error) {
+	var out []*Member
+	uri := fmt.Sprintf(pathProjectMembers, c.base, encode(project), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetRaw retrieves the raw contents of a file at the ref.
+func (c *Client) GetRaw(project, path, ref string) ([]byte, error) {
+	uri := fmt.Sprintf(pathRaw, c.base, encode(project), encode(path), url.QueryEscape(ref))
+	body, err := c.stream(uri, "GET", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// GetCommit retrieves a commit.
+func (c *Client) GetCommit(project, sha string) (*Commit, error) {
+	out := new(Commit)
+	uri := fmt.Sprintf(pathCommit, c.base, encode(project), sha)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetCommitMergeRequests retrieves the merge requests that include the
+// commit.
+func (c *Client) GetCommitMergeRequests(project, sha string) ([]*MergeRequest, error) {
+	var out []*MergeRequest
+	uri := fmt.Sprintf(pathCommitMRs, c.base, encode(project), sha)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// GetCommitStatuses retrieves a page of the commit statuses with the
+// name.
+func (c *Client) GetCommitStatuses(project, sha, name string, page int) ([]*Status, int, error) {
+	var out []*Status
+	uri := fmt.Sprintf(pathCommitStatuses, c.base, encode(project), sha, url.QueryEscape(name), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateStatus creates a commit status.
+func (c *Client) CreateStatus(project, sha string, in *Status) error {
+	uri := fmt.Sprintf(pathStatus, c.base, encode(project), sha)
+	return c.post(uri, in, nil)
+}
+
+// GetBranch retrieves a repository branch.
+func (c *Client) GetBranch(project, branch string) (*Branch, error) {
+	out := new(Branch)
+	uri := fmt.Sprintf(pathBranch, c.base, encode(project), encode(branch))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetProtectedBranch retrieves the protection of the branch.
+func (c *Client) GetProtectedBranch(project, branch string) (*Branch, error) {
+	out := new(Branch)
+	uri := fmt.Sprintf(pathProtected, c.base, encode(project), encode(branch))
+	err := c.get(uri, out)
+	return out, err
+}
+
+// ProtectBranch protects the branch with the default access levels.
+func (c *Client) ProtectBranch(project, branch string) error {
+	in := map[string]string{"name": branch}
+	uri := fmt.Sprintf(pathProtect, c.base, encode(project))
+	return c.post(uri, in, nil)
+}
+
+// GetMergeRequests retrieves a page of the open merge requests.
+func (c *Client) GetMergeRequests(project string, page int) ([]*MergeRequest, int, error) {
+	var out []*MergeRequest
+	uri := fmt.Sprintf(pathMergeRequests, c.base, encode(project), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetMergeRequest retrieves a merge request.
+func (c *Client) GetMergeRequest(project string, iid int) (*MergeRequest, error) {
+	out := new(MergeRequest)
+	uri := fmt.Sprintf(pathMergeRequest, c.base, encode(project), iid)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// UpdateMergeRequestLabels adds and removes the labels of the merge
+// request.
+func (c *Client) UpdateMergeRequestLabels(project string, iid int, add, remove []string) error {
+	in := map[string]string{}
+	if len(add) != 0 {
+		in["add_labels"] = strings.Join(add, ",")
+	}
+	if len(remove) != 0 {
+		in["remove_labels"] = strings.Join(remove, ",")
+	}
+	uri := fmt.Sprintf(pathMergeRequest, c.base, encode(project), iid)
+	return c.put(uri, in, nil)
+}
+
+// GetNotes retrieves a page of the merge request notes, from oldest to
+// newest.
+func (c *Client) GetNotes(project string, iid, page int) ([]*Note, int, error) {
+	var out []*Note
+	uri := fmt.Sprintf(pathNotes, c.base, encode(project), iid, page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetApprovals retrieves the merge request approvals.
+func (c *Client) GetApprovals(project string, iid int) (*Approvals, error) {
+	out := new(Approvals)
+	uri := fmt.Sprintf(pathApprovals, c.base, encode(project), iid)
+	err := c.get(uri, out)
+	return out, err
+}
+
+// GetDiffs retrieves a page of the merge request changed files.
+func (c *Client) GetDiffs(project string, iid, page int) ([]*Diff, int, error) {
+	var out []*Diff
+	uri := fmt.Sprintf(pathDiffs, c.base, encode(project), iid, page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// GetLabels retrieves a page of the project labels.
+func (c *Client) GetLabels(project string, page int) ([]*Label, int, error) {
+	var out []*Label
+	uri := fmt.Sprintf(pathLabels, c.base, encode(project), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateLabel creates a project label.
+func (c *Client) CreateLabel(project string, in *Label) error {
+	uri := fmt.Sprintf(pathLabel, c.base, encode(project))
+	return c.post(uri, in, nil)
+}
+
+// GetHooks retrieves a page of the project webhooks.
+func (c *Client) GetHooks(project string, page int) ([]*Hook, int, error) {
+	var out []*Hook
+	uri := fmt.Sprintf(pathHooks, c.base, encode(project), page, perPage)
+	next, err := c.list(uri, &out)
+	return out, next, err
+}
+
+// CreateHook creates a project webhook.
+func (c *Client) CreateHook(project string, in *Hook) error {
+	uri := fmt.Sprintf(pathHookCreate, c.base, encode(project))
+	return c.post(uri, in, nil)
+}
+
+// DeleteHook deletes a project webhook.
+func (c *Client) DeleteHook(project string, id int64) error {
+	uri := fmt.Sprintf(pathHook, c.base, encode(project), id)
+	return c.delete(uri)
+}
+
+// encode is a helper function that encodes a project, group, branch or
+// file path as a single path segment.
+func encode(path string) string {
+	return strings.Replace(url.PathEscape(path), "/", "%2F", -1)
+}
+
+//
+// http request helper functions
+//
+
+// helper function for making an http GET request.
+func (c *Client) get(rawurl string, out interface{}) error {
+	return c.do(rawurl, "GET", nil, out)
+}
+
+// helper function for making an http GET request of a paginated list,
+// which returns the next page.
+func (c *Client) list(rawurl string, out interface{}) (int, error) {
+	var header http.Header
+	body, err := c.stream(rawurl, "GET", nil, &header)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	if err := json.NewDecoder(body).Decode(out); err != nil {
+		return 0, err
+	}
+	next, _ := strconv.Atoi(header.Get("X-Next-Page"))
+	return next, nil
+}
+
+// helper function for making an http POST request.
+func (c *Client) post(rawurl string, in, out interface{}) error {
+	return c.do(rawurl, "POST", in, out)
+}
+
+// helper function for making an http PUT request.
+func (c *Client) put(rawurl string, in, out interface{}) error {
+	return c.do(rawurl, "PUT", in, out)
+}
+
+// helper function for making an http DELETE request.
+func (c *Client) delete(rawurl string) error {
+	return c.do(rawurl, "DELETE", nil, nil)
+}
+
+// helper function to make an http request
+func (c *Client) do(rawurl, method string, in, out interface{}) error {
+	// executes the http request and returns the body as
+	// and io.ReadCloser
+	body, err := c.stream(rawurl, method, in, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// if a json response is expected, parse and return
+	// the json response.
+	if out != nil {
+		return json.NewDecoder(body).Decode(out)
+	}
+	return nil
+}
+
+// helper function to stream an http request. The response headers are
+// stored in header, if not nil.
+func (c *Client) stream(rawurl, method string, in interface{}, header *http.Header) (io.ReadCloser, error) {
+	uri, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	// if we are posting or putting data, we need to
+	// write it to the body of the request.
+	var buf io.ReadWriter
+	if in != nil {
+		buf = new(bytes.Buffer)
+		err := json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// creates a new http request to gitlab.
+	req, err := http.NewRequest(method, uri.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode > http.StatusPartialContent {
+		defer resp.Body.Close()
+		out, _ := ioutil.ReadAll(resp.Body)
+		apiErr := &Error{Status: resp.StatusCode}
+		if json.Unmarshal(out, apiErr) != nil || len(apiErr.Message) == 0 {
+			apiErr.Message = string(out)
+		}
+		return nil, apiErr
+	}
+	if header != nil {
+		*header = resp.Header
+	}
+	return resp.Body, nil
+}
+
+// isNotFound is a helper function that checks if the error is an API
+// error for a missing resource.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,821 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/shared/httputil"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const contextName = "approvals/lgtm"
+
+// approvedNote is the body of the system note recorded when a user
+// approves a merge request.
+const approvedNote = "approved this merge request"
+
+// Gitlab provides the available configuration values.
+type Gitlab struct {
+	URL    string
+	Client string
+	Secret string
+	Scopes []string
+
+	// MaxPages is the upper bound of pages retrieved from list
+	// endpoints. Zero means no limit.
+	MaxPages int
+}
+
+// GetUser retrieves the current user from the API.
+func (g *Gitlab) GetUser(c context.Context, res http.ResponseWriter, req *http.Request) (*model.User, error) {
+
+	var config = &oauth2.Config{
+		ClientID:     g.Client,
+		ClientSecret: g.Secret,
+		RedirectURL:  fmt.Sprintf("%s/login", httputil.GetURL(req)),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  fmt.Sprintf("%s/oauth/authorize", g.URL),
+			TokenURL: fmt.Sprintf("%s/oauth/token", g.URL),
+		},
+		Scopes: g.Scopes,
+	}
+
+	// get the oauth code from the incoming request. if no code is present
+	// redirec the user to GitLab login to retrieve a code.
+	var code = req.FormValue("code")
+	if len(code) == 0 {
+		state := fmt.Sprintln(time.Now().Unix())
+		http.Redirect(res, req, config.AuthCodeURL(state), http.StatusSeeOther)
+		return nil, nil
+	}
+
+	// exchanges the oauth2 code for an access token
+	token, err := config.Exchange(oauth2.NoContext, code)
+	if err != nil {
+		return nil, fmt.Errorf("Error exchanging token. %s", err)
+	}
+
+	// get the currently authenticated user details for the access token
+	client := NewClientToken(g.URL, token.AccessToken)
+	user, err := client.GetUser()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching user. %s", err)
+	}
+
+	return &model.User{
+		Login:  user.Username,
+		Token:  token.AccessToken,
+		Avatar: user.Avatar,
+	}, nil
+}
+
+// GetUserToken retrieves a user token from the API.
+func (g *Gitlab) GetUserToken(c context.Context, token string) (string, error) {
+	client := NewClientToken(g.URL, token)
+	user, err := client.GetUser()
+	if err != nil {
+		return "", fmt.Errorf("Error fetching user. %s", err)
+	}
+	return user.Username, nil
+}
+
+// GetTeams retrieves teams from the API. The groups of the user are
+// returned as teams.
+func (g *Gitlab) GetTeams(c context.Context, user *model.User) ([]*model.Team, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	teams := []*model.Team{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		groups, next, err := client.GetGroups(page)
+		for _, group := range groups {
+			teams = append(teams, &model.Team{
+				Login:  group.FullPath,
+				Avatar: group.Avatar,
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching teams. %s", err)
+	}
+	return teams, nil
+}
+
+// GetMembers retrieves members from the API. GitLab has no teams, so
+// the team is the subgroup of the same name, including the members
+// inherited from the parent group.
+func (g *Gitlab) GetMembers(c context.Context, user *model.User, org, team string) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	var members []*model.Member
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetGroupMembers(org+"/"+team, page)
+		for _, member := range list {
+			members = append(members, &model.Member{
+				Login: member.Username,
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching team members. %s", err)
+	}
+	return members, nil
+}
+
+// GetRepo retrieves a repository from the API.
+func (g *Gitlab) GetRepo(c context.Context, user *model.User, owner, name string) (*model.Repo, error) {
+	client := NewClientToken(g.URL, user.Token)
+	project, err := client.GetProject(owner + "/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+	return &model.Repo{
+		Owner:   owner,
+		Name:    name,
+		Slug:    project.PathWithNamespace,
+		Link:    project.WebURL,
+		Private: project.Visibility != "public",
+	}, nil
+}
+
+// GetPerm retrieves permissions from the API.
+func (g *Gitlab) GetPerm(c context.Context, user *model.User, owner, name string) (*model.Perm, error) {
+	client := NewClientToken(g.URL, user.Token)
+	project, err := client.GetProject(owner + "/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching repository. %s", err)
+	}
+
+	// the access level is granted either through the project or
+	// through the group, whichever is higher.
+	var level int
+	if project.Permissions != nil {
+		for _, access := range []*Access{project.Permissions.ProjectAccess, project.Permissions.GroupAccess} {
+			if access != nil && access.AccessLevel > level {
+				level = access.AccessLevel
+			}
+		}
+	}
+	m := &model.Perm{}
+	m.Admin = level >= accessMaintainer
+	m.Push = level >= accessDeveloper
+	m.Pull = level >= accessGuest || project.Visibility != "private"
+	return m, nil
+}
+
+// GetRepos retrieves repositories from the API.
+func (g *Gitlab) GetRepos(c context.Context, u *model.User) ([]*model.Repo, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	// only list repositories that I can admin
+	repos := []*model.Repo{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetProjects(page)
+		for _, project := range list {
+			repos = append(repos, &model.Repo{
+				Owner:   project.Namespace.FullPath,
+				Name:    project.Path,
+				Slug:    project.PathWithNamespace,
+				Link:    project.WebURL,
+				Private: project.Visibility != "public",
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// RemoveIssueLabels removes labels from an issue.
+func (g *Gitlab) RemoveIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	client := NewClientToken(g.URL, user.Token)
+	return client.UpdateMergeRequestLabels(project(repo), number, nil, labels)
+}
+
+// AddIssueLabels adds labels to an issue.
+func (g *Gitlab) AddIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
+	client := NewClientToken(g.URL, user.Token)
+	return client.UpdateMergeRequestLabels(project(repo), number, labels, nil)
+}
+
+// GetIssueLabels get all labels of issue
+func (g *Gitlab) GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error) {
+	client := NewClientToken(g.URL, user.Token)
+
+	mr, err := client.GetMergeRequest(project(repo), number)
+	if err != nil {
+		return nil, err
+	}
+	return mr.Labels, nil
+}
+
+// CreateLabels creates the labels that do not exist in the repository.
+func (g *Gitlab) CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	var exists = map[string]bool{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetLabels(project(repo), page)
+		for _, label := range list {
+			exists[label.Name] = true
+		}
+		return next, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		if exists[label.Name] {
+			continue
+		}
+		err := client.CreateLabel(project(repo), &Label{
+			Name:  label.Name,
+			Color: "#" + strings.TrimPrefix(label.Color, "#"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetHook injects a webhook through the API. GitLab CE has no required
+// status checks, so the default branch is protected and merge requests
+// are required to have a successful pipeline instead. The approval
+// status is attached to the head pipeline of the merge request, which
+// fails the pipeline until the merge request is approved.
+func (g *Gitlab) SetHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	currentRepo, err := client.GetProject(project(repo))
+	if err != nil {
+		return err
+	}
+
+	old, err := g.getHook(client, project(repo), link)
+	if err == nil && old != nil {
+		client.DeleteHook(project(repo), old.ID)
+	}
+
+	err = client.CreateHook(project(repo), &Hook{
+		URL:                 link,
+		Token:               repo.Secret,
+		PushEvents:          true,
+		MergeRequestsEvents: true,
+		NoteEvents:          true,
+		EnableSSL:           true,
+	})
+	if err != nil {
+		log.Debugf("Error creating the webhook at %s. %s", link, err)
+		return err
+	}
+
+	branch := currentRepo.DefaultBranch
+	_, err = client.GetProtectedBranch(project(repo), branch)
+
+	// Branch not protected
+	if isNotFound(err) {
+		err = client.ProtectBranch(project(repo), branch)
+	}
+	if err != nil {
+		log.Warnf("Error configuring protected branch for %s@%s. %s", repo.Slug, branch, err)
+		return err
+	}
+
+	if currentRepo.OnlyAllowMergeIfPipelineSucceeds {
+		return nil
+	}
+	return client.EnablePipelineCheck(project(repo))
+}
+
+// DelHook removes a webhook through the API. The pipeline requirement is
+// left in place, since the project pipelines may rely on it.
+func (g *Gitlab) DelHook(c context.Context, user *model.User, repo *model.Repo, link string) error {
+	client := NewClientToken(g.URL, user.Token)
+
+	hook, err := g.getHook(client, project(repo), link)
+	if err != nil {
+		return err
+	} else if hook == nil {
+		return nil
+	}
+	return client.DeleteHook(project(repo), hook.ID)
+}
+
+// getHook is a helper function that retrieves a hook by hostname. To do
+// this, it will retrieve a list of all hooks and iterate through the
+// list.
+func (g *Gitlab) getHook(client *Client, project, rawurl string) (*Hook, error) {
+	newurl, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Hook
+	err = Paginate(g.MaxPages, func(page int) (int, error) {
+		hooks, next, err := client.GetHooks(project, page)
+		for _, hook := range hooks {
+			oldurl, err := url.Parse(hook.URL)
+			if err != nil {
+				continue
+			}
+			if found == nil && newurl.Host == oldurl.Host {
+				found = hook
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// GetComments retrieves comments from the API. System notes, which
+// record merge request events, are not comments.
+func (g *Gitlab) GetComments(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Comment, error) {
+	notes, err := g.getNotes(u, r, num)
+	if err != nil {
+		return nil, err
+	}
+	comments := []*model.Comment{}
+	for _, note := range notes {
+		if note.System {
+			continue
+		}
+		comments = append(comments, &model.Comment{
+			ID:      note.ID,
+			Author:  note.Author.Username,
+			Body:    note.Body,
+			Created: note.Created,
+		})
+	}
+	return comments, nil
+}
+
+// GetReviews retrieves reviews from the API. The current approvals of
+// the merge request are returned as approved reviews, submitted at the
+// time of the most recent approval note of the approver.
+func (g *Gitlab) GetReviews(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Review, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	approvals, err := client.GetApprovals(project(r), num)
+	if err != nil {
+		return nil, err
+	}
+	if len(approvals.ApprovedBy) == 0 {
+		return []*model.Review{}, nil
+	}
+
+	notes, err := g.getNotes(u, r, num)
+	if err != nil {
+		return nil, err
+	}
+	approved := map[string]*Note{}
+	for _, note := range notes {
+		if note.System && note.Body == approvedNote {
+			approved[note.Author.Username] = note
+		}
+	}
+
+	reviews := []*model.Review{}
+	for _, approval := range approvals.ApprovedBy {
+		review := &model.Review{
+			Author: approval.User.Username,
+			State:  "APPROVED",
+		}
+		if note, ok := approved[review.Author]; ok {
+			review.ID = note.ID
+			review.Submitted = note.Created
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// getNotes is a helper function that retrieves the merge request notes,
+// from oldest to newest.
+func (g *Gitlab) getNotes(u *model.User, r *model.Repo, num int) ([]*Note, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var notes []*Note
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetNotes(project(r), num, page)
+		notes = append(notes, list...)
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetFiles retrieves the pull request changed files from the API.
+func (g *Gitlab) GetFiles(c context.Context, u *model.User, r *model.Repo, num int) ([]string, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var files []string
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		diffs, next, err := client.GetDiffs(project(r), num, page)
+		for _, diff := range diffs {
+			files = append(files, diff.NewPath)
+			// renamed files also change the previous path
+			if diff.OldPath != diff.NewPath {
+				files = append(files, diff.OldPath)
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetHeadCommit retrieves the pull request head commit from the API.
+func (g *Gitlab) GetHeadCommit(c context.Context, u *model.User, r *model.Repo, num int) (*model.Commit, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	mr, err := client.GetMergeRequest(project(r), num)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := client.GetCommit(project(r), mr.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Commit{
+		SHA:     commit.ID,
+		Created: commit.CommittedDate,
+	}, nil
+}
+
+// GetContents retrieves a file at the ref from the API.
+func (g *Gitlab) GetContents(c context.Context, u *model.User, r *model.Repo, path, ref string) ([]byte, error) {
+	client := NewClientToken(g.URL, u.Token)
+	if len(ref) == 0 {
+		ref = "HEAD"
+	}
+	return client.GetRaw(project(r), path, ref)
+}
+
+// GetPull retrieves a pull request from the API.
+func (g *Gitlab) GetPull(c context.Context, u *model.User, r *model.Repo, num int) (*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	mr, err := client.GetMergeRequest(project(r), num)
+	if err != nil {
+		return nil, err
+	}
+	return toIssue(mr), nil
+}
+
+// GetPulls retrieves the open pull requests from the API.
+func (g *Gitlab) GetPulls(c context.Context, u *model.User, r *model.Repo) ([]*model.Issue, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	pulls := []*model.Issue{}
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetMergeRequests(project(r), page)
+		for _, mr := range list {
+			pulls = append(pulls, toIssue(mr))
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pulls, nil
+}
+
+// GetStatus retrieves the approval status of the commit from the API.
+func (g *Gitlab) GetStatus(c context.Context, u *model.User, r *model.Repo, sha string) (*model.Status, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	// the commit may have a status in several pipelines, of which the
+	// most recent one is used.
+	var latest *Status
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetCommitStatuses(project(r), sha, contextName, page)
+		for _, s := range list {
+			if latest == nil || s.ID > latest.ID {
+				latest = s
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := new(model.Status)
+	if latest != nil {
+		status.State = fromState(latest.Status)
+		status.Desc = latest.Desc
+	}
+	return status, nil
+}
+
+// IsProtected checks if the branch is protected from the API.
+func (g *Gitlab) IsProtected(c context.Context, u *model.User, r *model.Repo, branch string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	b, err := client.GetBranch(project(r), branch)
+	if err != nil {
+		return false, err
+	}
+	return b.Protected, nil
+}
+
+// IsMerged checks if the commit was merged through a pull request from
+// the API.
+func (g *Gitlab) IsMerged(c context.Context, u *model.User, r *model.Repo, sha string) (bool, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	mrs, err := client.GetCommitMergeRequests(project(r), sha)
+	if err != nil {
+		return false, err
+	}
+	for _, mr := range mrs {
+		if mr.State == "merged" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetAdmins retrieves the repository administrators from the API.
+func (g *Gitlab) GetAdmins(c context.Context, u *model.User, r *model.Repo) ([]*model.Member, error) {
+	client := NewClientToken(g.URL, u.Token)
+
+	var admins []*model.Member
+	err := Paginate(g.MaxPages, func(page int) (int, error) {
+		list, next, err := client.GetProjectMembers(project(r), page)
+		for _, member := range list {
+			if member.AccessLevel < accessMaintainer {
+				continue
+			}
+			admins = append(admins, &model.Member{
+				Login: member.Username,
+			})
+		}
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return admins, nil
+}
+
+// SetStatus sets the pull request status through the API. The status is
+// attached to the head pipeline of the merge request, if any.
+func (g *Gitlab) SetStatus(c context.Context, u *model.User, r *model.Repo, num int, status *model.Status) error {
+	client := NewClientToken(g.URL, u.Token)
+
+	mr, err := client.GetMergeRequest(project(r), num)
+	if err != nil {
+		return err
+	}
+
+	in := &Status{
+		State: toState(status.State),
+		Name:  contextName,
+		Desc:  status.Desc,
+	}
+	sha := mr.SHA
+	if mr.HeadPipeline != nil {
+		sha = mr.HeadPipeline.SHA
+		in.PipelineID = mr.HeadPipeline.ID
+	}
+	return client.CreateStatus(project(r), sha, in)
+}
+
+// GetHook gets a webhook from the API.
+func (g *Gitlab) GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
+	switch r.Header.Get("X-Gitlab-Event") {
+	case "Note Hook":
+		return getNoteHook(r)
+	case "Merge Request Hook":
+		return getMergeRequestHook(r)
+	case "Push Hook":
+		return getPushHook(r)
+	}
+	return nil, nil
+}
+
+// getNoteHook is a helper function that parses a note hook, and returns
+// the merge request of a comment.
+func getNoteHook(r *http.Request) (*model.Hook, error) {
+	data := noteHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	// only process comments on merge requests, as opposed to comments
+	// on issues, commits and snippets.
+	if data.ObjectAttributes.NoteableType != "MergeRequest" {
+		return nil, nil
+	}
+
+	// the payload only includes the id of the merge request author,
+	// which is retrieved with the merge request when it is evaluated.
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitlab-Event-UUID")
+	hook.Event = "note"
+	hook.Repo = toRepo(&data.Project)
+	hook.Issue = new(model.Issue)
+	hook.Issue.Number = data.MergeRequest.IID
+	hook.Issue.Title = data.MergeRequest.Title
+	hook.Issue.Base = data.MergeRequest.TargetBranch
+	hook.Comment = new(model.Comment)
+	hook.Comment.ID = data.ObjectAttributes.ID
+	hook.Comment.Body = data.ObjectAttributes.Note
+	hook.Comment.Author = data.User.Username
+	hook.Review = new(model.Review)
+	return hook, nil
+}
+
+// getMergeRequestHook is a helper function that parses a merge request
+// hook, and returns the merge request of the actions that require the
+// status to be set or recalculated, or the merge of a merged request.
+func getMergeRequestHook(r *http.Request) (*model.Hook, error) {
+	data := mergeRequestHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+	attrs := data.ObjectAttributes
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitlab-Event-UUID")
+	hook.Event = "merge_request"
+	hook.Repo = toRepo(&data.Project)
+	hook.Comment = new(model.Comment)
+	hook.Review = new(model.Review)
+
+	// a newly opened or reopened merge request needs its initial
+	// status, new commits may invalidate existing approvals, and
+	// approvals change the status. other updates, such as a new
+	// title, are ignored.
+	switch attrs.Action {
+	case "open", "reopen":
+	case "update":
+		if len(attrs.OldRev) == 0 {
+			return nil, nil
+		}
+	case "approved", "approval":
+		hook.Review.Author = data.User.Username
+		hook.Review.State = "APPROVED"
+	case "unapproved", "unapproval":
+		hook.Review.Author = data.User.Username
+		hook.Review.State = "DISMISSED"
+	case "merge":
+		return getMergeHook(r, &data), nil
+	default:
+		return nil, nil
+	}
+
+	hook.Issue = new(model.Issue)
+	hook.Issue.Number = attrs.IID
+	hook.Issue.Title = attrs.Title
+	hook.Issue.Base = attrs.TargetBranch
+	return hook, nil
+}
+
+// getMergeHook is a helper function that returns the merge of a merged
+// request. The payload only includes the id of the merge request
+// author, which is retrieved when the merge is recorded.
+func getMergeHook(r *http.Request, data *mergeRequestHook) *model.Hook {
+	attrs := data.ObjectAttributes
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitlab-Event-UUID")
+	hook.Event = "merge_request"
+	hook.Repo = toRepo(&data.Project)
+	hook.Merge = new(model.Merge)
+	hook.Merge.Number = attrs.IID
+	hook.Merge.Title = attrs.Title
+	hook.Merge.Base = attrs.TargetBranch
+	hook.Merge.SHA = attrs.MergeCommitSHA
+	hook.Merge.HeadSHA = attrs.LastCommit.ID
+	hook.Merge.MergedBy = data.User.Username
+	hook.Merge.Merged = time.Now().Unix()
+	if merged, err := time.Parse(timeFormat, attrs.UpdatedAt); err == nil {
+		hook.Merge.Merged = merged.Unix()
+	}
+	return hook
+}
+
+// timeFormat is the format of the timestamps in hook payloads.
+const timeFormat = "2006-01-02 15:04:05 MST"
+
+// getPushHook is a helper function that parses a push hook, and returns
+// the commits pushed and the files changed on the branch.
+func getPushHook(r *http.Request) (*model.Hook, error) {
+	data := pushHook{}
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	// only process pushes to branches that were not deleted. tags are
+	// sent as a separate event.
+	if !strings.HasPrefix(data.Ref, "refs/heads/") || strings.Trim(data.After, "0") == "" {
+		return nil, nil
+	}
+	branch := strings.TrimPrefix(data.Ref, "refs/heads/")
+
+	hook := new(model.Hook)
+	hook.Delivery = r.Header.Get("X-Gitlab-Event-UUID")
+	hook.Event = "push"
+	hook.Repo = toRepo(&data.Project)
+	hook.Push = new(model.Push)
+	hook.Push.Branch = branch
+	hook.Push.Default = branch == data.Project.DefaultBranch
+	hook.Push.Pusher = data.Username
+	hook.Push.Truncated = data.Total > len(data.Commits)
+
+	seen := map[string]bool{}
+	for _, commit := range data.Commits {
+		hook.Push.Commits = append(hook.Push.Commits, &model.Commit{
+			SHA:     commit.ID,
+			Author:  commit.Author.Name,
+			Message: commit.Message,
+			Link:    commit.URL,
+			Created: commit.Timestamp,
+		})
+		for _, files := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range files {
+				if !seen[file] {
+					seen[file] = true
+					hook.Push.Files = append(hook.Push.Files, file)
+				}
+			}
+		}
+	}
+	return hook, nil
+}
+
+// project is a helper function that returns the project path of the
+// repository.
+func project(r *model.Repo) string {
+	return r.Owner + "/" + r.Name
+}
+
+// toRepo is a helper function that converts the project of a hook to a
+// repository. The owner is the namespace, which may include subgroups.
+func toRepo(p *projectHook) *model.Repo {
+	repo := &model.Repo{Slug: p.PathWithNamespace}
+	if i := strings.LastIndex(p.PathWithNamespace, "/"); i != -1 {
+		repo.Owner = p.PathWithNamespace[:i]
+		repo.Name = p.PathWithNamespace[i+1:]
+	}
+	return repo
+}
+
+// toIssue is a helper function that converts the merge request to an
+// issue.
+func toIssue(mr *MergeRequest) *model.Issue {
+	return &model.Issue{
+		Number: mr.IID,
+		Title:  mr.Title,
+		Author: mr.Author.Username,
+		Base:   mr.TargetBranch,
+	}
+}
+
+// toState is a helper function that converts the status state to the
+// gitlab commit status state.
+func toState(state string) string {
+	switch state {
+	case model.StatusFailure, model.StatusError:
+		return "failed"
+	}
+	return state
+}
+
+// fromState is a helper function that converts the gitlab commit status
+// state to the status state.
+func fromState(state string) string {
+	switch state {
+	case "failed":
+		return model.StatusFailure
+	case "canceled":
+		return model.StatusError
+	case "created", "running":
+		return model.StatusPending
+	}
+	return state
+}
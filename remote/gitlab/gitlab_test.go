@@ -0,0 +1,325 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/franela/goblin"
+	"golang.org/x/net/context"
+)
+
+func TestGitlab(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Gitlab", func() {
+		var server *httptest.Server
+		var remote *Gitlab
+		var api *fakeGitlab
+
+		g.BeforeEach(func() {
+			api = &fakeGitlab{}
+			server = httptest.NewServer(api)
+			remote = &Gitlab{URL: server.URL}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("Should get comments from all pages", func() {
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(249)
+			g.Assert(comments[0].Body).Equal("comment 1")
+			g.Assert(comments[0].Author).Equal("octocat")
+		})
+
+		g.It("Should fail at the page limit", func() {
+			remote.MaxPages = 1
+			_, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should get the last page at the page limit", func() {
+			remote.MaxPages = 3
+			comments, err := remote.GetComments(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(comments)).Equal(249)
+		})
+
+		g.It("Should get approvals as reviews", func() {
+			reviews, err := remote.GetReviews(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(reviews)).Equal(1)
+			g.Assert(reviews[0].Author).Equal("bradrydzewski")
+			g.Assert(reviews[0].State).Equal("APPROVED")
+			g.Assert(reviews[0].ID).Equal(int64(0))
+			g.Assert(reviews[0].Submitted.Unix()).Equal(int64(1592222400))
+		})
+
+		g.It("Should get the merge request", func() {
+			issue, err := remote.GetPull(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(issue.Number).Equal(1)
+			g.Assert(issue.Author).Equal("octocat")
+			g.Assert(issue.Base).Equal("master")
+		})
+
+		g.It("Should get the changed files", func() {
+			files, err := remote.GetFiles(context.Background(), fakeUser, fakeRepo, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(files).Equal([]string{"README.md", "docs/README.md", "MAINTAINERS"})
+		})
+
+		g.It("Should get the raw file contents", func() {
+			file, err := remote.GetContents(context.Background(), fakeUser, fakeRepo, "docs/MAINTAINERS", "")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(string(file)).Equal("octocat\n")
+		})
+
+		g.It("Should get the permissions from the group access", func() {
+			perm, err := remote.GetPerm(context.Background(), fakeUser, "octocat", "hello-world")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(perm.Admin).IsTrue()
+			g.Assert(perm.Push).IsTrue()
+			g.Assert(perm.Pull).IsTrue()
+		})
+
+		g.It("Should set the status on the head pipeline", func() {
+			err := remote.SetStatus(context.Background(), fakeUser, fakeRepo, 1, &model.Status{State: "failure", Desc: "needs approval"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.statusSHA).Equal("a1b2c3d")
+			g.Assert(api.status.State).Equal("failed")
+			g.Assert(api.status.Name).Equal(contextName)
+			g.Assert(api.status.PipelineID).Equal(int64(42))
+		})
+
+		g.It("Should get the most recent status of the commit", func() {
+			status, err := remote.GetStatus(context.Background(), fakeUser, fakeRepo, "a1b2c3d")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(status.State).Equal("success")
+		})
+
+		g.It("Should add and remove labels by name", func() {
+			err := remote.AddIssueLabels(context.Background(), fakeUser, fakeRepo, 1, []string{"lgtm/done", "reviewed"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.labels["add_labels"]).Equal("lgtm/done,reviewed")
+
+			err = remote.RemoveIssueLabels(context.Background(), fakeUser, fakeRepo, 1, []string{"lgtm/need 1"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.labels["remove_labels"]).Equal("lgtm/need 1")
+		})
+
+		g.It("Should check if the commit was merged", func() {
+			merged, err := remote.IsMerged(context.Background(), fakeUser, fakeRepo, "a1b2c3d")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(merged).IsTrue()
+		})
+
+		g.It("Should protect the default branch and require pipelines", func() {
+			err := remote.SetHook(context.Background(), fakeUser, fakeRepo, "http://lgtm.example.com/hook")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(api.hook.URL).Equal("http://lgtm.example.com/hook")
+			g.Assert(api.hook.NoteEvents).IsTrue()
+			g.Assert(api.hook.MergeRequestsEvents).IsTrue()
+			g.Assert(api.protected).Equal("master")
+			g.Assert(api.pipelines).IsTrue()
+		})
+	})
+}
+
+func TestHook(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Hook", func() {
+		var remote = new(Gitlab)
+
+		g.It("Should return the merge request of a note", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeNote))
+			r.Header.Set("X-Gitlab-Event", "Note Hook")
+			r.Header.Set("X-Gitlab-Event-UUID", "72d3162e")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Delivery).Equal("72d3162e")
+			g.Assert(hook.Repo.Owner).Equal("octocat/group")
+			g.Assert(hook.Repo.Name).Equal("hello-world")
+			g.Assert(hook.Repo.Slug).Equal("octocat/group/hello-world")
+			g.Assert(hook.Issue.Number).Equal(1)
+			g.Assert(hook.Issue.Base).Equal("master")
+			g.Assert(hook.Comment.Body).Equal("LGTM")
+			g.Assert(hook.Comment.Author).Equal("bradrydzewski")
+		})
+
+		g.It("Should ignore notes on issues", func() {
+			body := strings.Replace(fakeNote, `"MergeRequest"`, `"Issue"`, 1)
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(body))
+			r.Header.Set("X-Gitlab-Event", "Note Hook")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+
+		g.It("Should return the merge request of an approval", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeMergeRequest("approved")))
+			r.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue.Number).Equal(1)
+			g.Assert(hook.Review.Author).Equal("bradrydzewski")
+			g.Assert(hook.Review.State).Equal("APPROVED")
+		})
+
+		g.It("Should ignore updates without new commits", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeMergeRequest("update")))
+			r.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+
+		g.It("Should return the merge of a merged request", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader(fakeMergeRequest("merge")))
+			r.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook.Issue == nil).IsTrue()
+			g.Assert(hook.Merge.Number).Equal(1)
+			g.Assert(hook.Merge.SHA).Equal("6dcb09b")
+			g.Assert(hook.Merge.HeadSHA).Equal("a1b2c3d")
+			g.Assert(hook.Merge.MergedBy).Equal("bradrydzewski")
+			g.Assert(hook.Merge.Merged).Equal(int64(1592222400))
+		})
+
+		g.It("Should ignore other events", func() {
+			r, _ := http.NewRequest("POST", "/hook", strings.NewReader("{}"))
+			r.Header.Set("X-Gitlab-Event", "Tag Push Hook")
+			hook, err := remote.GetHook(context.Background(), r)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(hook == nil).IsTrue()
+		})
+	})
+}
+
+// fakeGitlab is a fake of the GitLab API, which records the statuses,
+// labels, hooks and branch protections written to it.
+type fakeGitlab struct {
+	status    Status
+	statusSHA string
+	labels    map[string]string
+	hook      Hook
+	protected string
+	pipelines bool
+}
+
+func (f *fakeGitlab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const project = "/api/v4/projects/octocat%2Fhello-world"
+
+	switch path := r.URL.EscapedPath(); {
+	case path == project && r.Method == "PUT":
+		f.pipelines = true
+		w.Write([]byte(`{}`))
+	case path == project:
+		w.Write([]byte(`{"default_branch":"master","visibility":"private","permissions":{"project_access":null,"group_access":{"access_level":40}}}`))
+	case path == project+"/merge_requests/1/notes":
+		fakeList(w, r, 250, func(i int) string {
+			// the first note records the approval
+			if i == 0 {
+				return `{"id":0,"body":"approved this merge request","system":true,"author":{"username":"bradrydzewski"},"created_at":"2020-06-15T12:00:00Z"}`
+			}
+			return fmt.Sprintf(`{"id":%d,"body":"comment %d","author":{"username":"octocat"}}`, i, i)
+		})
+	case path == project+"/merge_requests/1/approvals":
+		w.Write([]byte(`{"approved_by":[{"user":{"username":"bradrydzewski"}}]}`))
+	case path == project+"/merge_requests/1/diffs":
+		w.Write([]byte(`[{"old_path":"README.md","new_path":"README.md"},{"old_path":"MAINTAINERS","new_path":"docs/README.md"}]`))
+	case path == project+"/merge_requests/1" && r.Method == "PUT":
+		json.NewDecoder(r.Body).Decode(&f.labels)
+		w.Write([]byte(`{}`))
+	case path == project+"/merge_requests/1":
+		w.Write([]byte(`{"iid":1,"author":{"username":"octocat"},"target_branch":"master","sha":"6dcb09b","head_pipeline":{"id":42,"sha":"a1b2c3d"}}`))
+	case path == project+"/repository/files/docs%2FMAINTAINERS/raw" && r.FormValue("ref") == "HEAD":
+		w.Write([]byte("octocat\n"))
+	case path == project+"/statuses/a1b2c3d":
+		f.statusSHA = "a1b2c3d"
+		json.NewDecoder(r.Body).Decode(&f.status)
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	case path == project+"/repository/commits/a1b2c3d/statuses" && r.FormValue("name") == contextName:
+		w.Write([]byte(`[{"id":2,"status":"success"},{"id":1,"status":"failed"}]`))
+	case path == project+"/repository/commits/a1b2c3d/merge_requests":
+		w.Write([]byte(`[{"iid":1,"state":"merged"}]`))
+	case path == project+"/hooks" && r.Method == "POST":
+		json.NewDecoder(r.Body).Decode(&f.hook)
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	case path == project+"/hooks":
+		w.Write([]byte(`[]`))
+	case path == project+"/protected_branches" && r.Method == "POST":
+		in := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&in)
+		f.protected = in["name"]
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(404)
+		w.Write([]byte(`{"message":"404 Not Found"}`))
+	}
+}
+
+// fakeList writes a page of total items rendered by the item function,
+// with the pagination X-Next-Page header.
+func fakeList(w http.ResponseWriter, r *http.Request, total int, item func(int) string) {
+	page, _ := strconv.Atoi(r.FormValue("page"))
+	size, _ := strconv.Atoi(r.FormValue("per_page"))
+	start, end := (page-1)*size, page*size
+	if end >= total {
+		end = total
+	} else {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	w.Write([]byte("["))
+	for i := start; i < end; i++ {
+		if i != start {
+			w.Write([]byte(","))
+		}
+		w.Write([]byte(item(i)))
+	}
+	w.Write([]byte("]"))
+}
+
+var fakeNote = `{
+  "object_kind": "note",
+  "user": {"username": "bradrydzewski"},
+  "project": {"path_with_namespace": "octocat/group/hello-world", "default_branch": "master"},
+  "object_attributes": {"id": 7, "note": "LGTM", "noteable_type": "MergeRequest"},
+  "merge_request": {"iid": 1, "title": "Update the README", "target_branch": "master"}
+}`
+
+func fakeMergeRequest(action string) string {
+	return fmt.Sprintf(`{
+  "object_kind": "merge_request",
+  "user": {"username": "bradrydzewski"},
+  "project": {"path_with_namespace": "octocat/hello-world", "default_branch": "master"},
+  "object_attributes": {
+    "iid": 1,
+    "title": "Update the README",
+    "action": %q,
+    "target_branch": "master",
+    "merge_commit_sha": "6dcb09b",
+    "updated_at": "2020-06-15 12:00:00 UTC",
+    "last_commit": {"id": "a1b2c3d"}
+  }
+}`, action)
+}
+
+var (
+	fakeUser = &model.User{Login: "octocat", Token: "cfcd2084"}
+	fakeRepo = &model.Repo{Owner: "octocat", Name: "hello-world", Slug: "octocat/hello-world"}
+)
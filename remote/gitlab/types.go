@@ -0,0 +1,220 @@
+package gitlab
+
+import "time"
+
+// Error represents an API error.
+type Error struct {
+	Status  int    `json:"-"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string  { return e.Message }
+func (e *Error) String() string { return e.Message }
+
+// Access levels of project and group members.
+const (
+	accessGuest      = 10
+	accessDeveloper  = 30
+	accessMaintainer = 40
+)
+
+// User represents a GitLab user.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Avatar   string `json:"avatar_url"`
+}
+
+// Member represents a project or group member.
+type Member struct {
+	User
+	AccessLevel int `json:"access_level"`
+}
+
+// Group represents a GitLab group.
+type Group struct {
+	ID       int64  `json:"id"`
+	FullPath string `json:"full_path"`
+	Avatar   string `json:"avatar_url"`
+}
+
+// Project represents a GitLab project.
+type Project struct {
+	ID                int64  `json:"id"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	Visibility        string `json:"visibility"`
+	DefaultBranch     string `json:"default_branch"`
+	Namespace         struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+	Permissions *struct {
+		ProjectAccess *Access `json:"project_access"`
+		GroupAccess   *Access `json:"group_access"`
+	} `json:"permissions"`
+	OnlyAllowMergeIfPipelineSucceeds bool `json:"only_allow_merge_if_pipeline_succeeds"`
+}
+
+// Access represents the access level of the current user.
+type Access struct {
+	AccessLevel int `json:"access_level"`
+}
+
+// Note represents a merge request note. System notes record events,
+// such as approvals, as opposed to user comments.
+type Note struct {
+	ID      int64     `json:"id"`
+	Body    string    `json:"body"`
+	Author  User      `json:"author"`
+	System  bool      `json:"system"`
+	Created time.Time `json:"created_at"`
+}
+
+// Approvals represents the approvals of a merge request.
+type Approvals struct {
+	ApprovedBy []struct {
+		User User `json:"user"`
+	} `json:"approved_by"`
+}
+
+// Diff represents a file changed by a merge request.
+type Diff struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+}
+
+// MergeRequest represents a merge request.
+type MergeRequest struct {
+	ID             int64      `json:"id"`
+	IID            int        `json:"iid"`
+	Title          string     `json:"title"`
+	State          string     `json:"state"`
+	Author         User       `json:"author"`
+	TargetBranch   string     `json:"target_branch"`
+	SHA            string     `json:"sha"`
+	Labels         []string   `json:"labels"`
+	HeadPipeline   *Pipeline  `json:"head_pipeline"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+}
+
+// Pipeline represents a pipeline.
+type Pipeline struct {
+	ID  int64  `json:"id"`
+	SHA string `json:"sha"`
+}
+
+// Commit represents a git commit.
+type Commit struct {
+	ID            string    `json:"id"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+// Status represents a commit status. The status is named after the
+// context, and may be attached to an existing pipeline.
+type Status struct {
+	ID         int64  `json:"id,omitempty"`
+	State      string `json:"state,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Name       string `json:"name"`
+	Desc       string `json:"description"`
+	PipelineID int64  `json:"pipeline_id,omitempty"`
+}
+
+// Label represents a project label.
+type Label struct {
+	ID    int64  `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Hook represents a project webhook.
+type Hook struct {
+	ID                  int64  `json:"id,omitempty"`
+	URL                 string `json:"url"`
+	Token               string `json:"token,omitempty"`
+	PushEvents          bool   `json:"push_events"`
+	MergeRequestsEvents bool   `json:"merge_requests_events"`
+	NoteEvents          bool   `json:"note_events"`
+	EnableSSL           bool   `json:"enable_ssl_verification"`
+}
+
+// Branch represents a repository branch.
+type Branch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+}
+
+// projectHook represents the project of a webhook payload.
+type projectHook struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+// noteHook represents a subset of the Note Hook payload.
+type noteHook struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+
+	ObjectAttributes struct {
+		ID           int64  `json:"id"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+	} `json:"object_attributes"`
+
+	MergeRequest struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		TargetBranch string `json:"target_branch"`
+	} `json:"merge_request"`
+
+	Project projectHook `json:"project"`
+}
+
+// mergeRequestHook represents a subset of the Merge Request Hook
+// payload.
+type mergeRequestHook struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+
+	ObjectAttributes struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		TargetBranch   string `json:"target_branch"`
+		OldRev         string `json:"oldrev"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		UpdatedAt      string `json:"updated_at"`
+		LastCommit     struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+
+	Project projectHook `json:"project"`
+}
+
+// pushHook represents a subset of the Push Hook payload.
+type pushHook struct {
+	Ref      string `json:"ref"`
+	After    string `json:"after"`
+	Username string `json:"user_username"`
+	Total    int    `json:"total_commits_count"`
+
+	Commits []struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		URL       string    `json:"url"`
+		Timestamp time.Time `json:"timestamp"`
+		Author    struct {
+			Name string `json:"name"`
+		} `json:"author"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+
+	Project projectHook `json:"project"`
+}
@@ -66,29 +66,6 @@ func (_m *Remote) GetComments(c context.Context, _a0 *model.User, _a1 *model.Rep
 	return r0, r1
 }
 
-// GetContents provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Remote) GetContents(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 string) ([]byte, error) {
-	ret := _m.Called(c, _a0, _a1, _a2)
-
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, string) []byte); ok {
-		r0 = rf(c, _a0, _a1, _a2)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, string) error); ok {
-		r1 = rf(c, _a0, _a1, _a2)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetHook provides a mock function with given fields: r
 func (_m *Remote) GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
 	ret := _m.Called(c, r)
@@ -112,29 +89,6 @@ func (_m *Remote) GetHook(c context.Context, r *http.Request) (*model.Hook, erro
 	return r0, r1
 }
 
-// GetMembers provides a mock function with given fields: _a0, _a1
-func (_m *Remote) GetMembers(c context.Context, _a0 *model.User, _a1 string) ([]*model.Member, error) {
-	ret := _m.Called(c, _a0, _a1)
-
-	var r0 []*model.Member
-	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) []*model.Member); ok {
-		r0 = rf(c, _a0, _a1)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Member)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
-		r1 = rf(c, _a0, _a1)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetPerm provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Remote) GetPerm(c context.Context, _a0 *model.User, _a1 string, _a2 string) (*model.Perm, error) {
 	ret := _m.Called(c, _a0, _a1, _a2)
@@ -320,13 +274,13 @@ func (_m *Remote) SetHook(c context.Context, _a0 *model.User, _a1 *model.Repo, _
 	return r0
 }
 
-// SetStatus provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
-func (_m *Remote) SetStatus(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 int, _a3 int, _a4 int) error {
-	ret := _m.Called(c, _a0, _a1, _a2, _a3, _a4)
+// SetStatus provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Remote) SetStatus(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 int, _a3 *model.Status) error {
+	ret := _m.Called(c, _a0, _a1, _a2, _a3)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, int, int, int) error); ok {
-		r0 = rf(c, _a0, _a1, _a2, _a3, _a4)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, int, *model.Status) error); ok {
+		r0 = rf(c, _a0, _a1, _a2, _a3)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -358,3 +312,243 @@ func (_m *Remote) GetReviews(c context.Context, _a0 *model.User, _a1 *model.Repo
 }
 
 var _ remote.Remote = (*Remote)(nil)
+
+// GetHeadCommit provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) GetHeadCommit(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 int) (*model.Commit, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 *model.Commit
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, int) *model.Commit); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Commit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, int) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFiles provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) GetFiles(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 int) ([]string, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, int) []string); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, int) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) GetMembers(c context.Context, _a0 *model.User, _a1 string, _a2 string) ([]*model.Member, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 []*model.Member
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string, string) []*model.Member); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Member)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string, string) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLabels provides a mock function with given fields: user, repo, labels
+func (_m *Remote) CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	ret := _m.Called(c, user, repo, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, []*model.Label) error); ok {
+		r0 = rf(c, user, repo, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetContents provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Remote) GetContents(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 string, _a3 string) ([]byte, error) {
+	ret := _m.Called(c, _a0, _a1, _a2, _a3)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, string, string) []byte); ok {
+		r0 = rf(c, _a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, string, string) error); ok {
+		r1 = rf(c, _a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPull provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) GetPull(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 int) (*model.Issue, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 *model.Issue
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, int) *model.Issue); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Issue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, int) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdmins provides a mock function with given fields: _a0, _a1
+func (_m *Remote) GetAdmins(c context.Context, _a0 *model.User, _a1 *model.Repo) ([]*model.Member, error) {
+	ret := _m.Called(c, _a0, _a1)
+
+	var r0 []*model.Member
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo) []*model.Member); ok {
+		r0 = rf(c, _a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Member)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo) error); ok {
+		r1 = rf(c, _a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPulls provides a mock function with given fields: _a0, _a1
+func (_m *Remote) GetPulls(c context.Context, _a0 *model.User, _a1 *model.Repo) ([]*model.Issue, error) {
+	ret := _m.Called(c, _a0, _a1)
+
+	var r0 []*model.Issue
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo) []*model.Issue); ok {
+		r0 = rf(c, _a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Issue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo) error); ok {
+		r1 = rf(c, _a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) GetStatus(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 string) (*model.Status, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 *model.Status
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, string) *model.Status); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Status)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, string) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsProtected provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) IsProtected(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 string) (bool, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, string) bool); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, string) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsMerged provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Remote) IsMerged(c context.Context, _a0 *model.User, _a1 *model.Repo, _a2 string) (bool, error) {
+	ret := _m.Called(c, _a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Repo, string) bool); ok {
+		r0 = rf(c, _a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Repo, string) error); ok {
+		r1 = rf(c, _a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,44 @@
+package remote
+
+// Registry is a set of remotes keyed by host. The first registered
+// remote is the default remote, which serves the users and repositories
+// that do not carry a remote, such as the ones created before multiple
+// remotes were supported.
+type Registry struct {
+	hosts   []string
+	remotes map[string]Remote
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{remotes: map[string]Remote{}}
+}
+
+// Register adds the remote for the host to the registry.
+func (r *Registry) Register(host string, remote Remote) {
+	if _, ok := r.remotes[host]; !ok {
+		r.hosts = append(r.hosts, host)
+	}
+	r.remotes[host] = remote
+}
+
+// Lookup returns the remote for the host, or nil if the host is not
+// registered. An empty host refers to the default remote.
+func (r *Registry) Lookup(host string) Remote {
+	return r.remotes[r.Host(host)]
+}
+
+// Host returns the registered host of the remote. An empty host refers
+// to the default remote.
+func (r *Registry) Host(host string) string {
+	if len(host) == 0 && len(r.hosts) != 0 {
+		return r.hosts[0]
+	}
+	return host
+}
+
+// Hosts returns the hosts of the registered remotes, in registration
+// order.
+func (r *Registry) Hosts() []string {
+	return r.hosts
+}
@@ -0,0 +1,71 @@
+package remote_test
+
+import (
+	"testing"
+
+	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/remote/mock"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegistry(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("Registry", func() {
+		var c *gin.Context
+		var github, enterprise *mocks.Remote
+
+		g.BeforeEach(func() {
+			github = new(mocks.Remote)
+			enterprise = new(mocks.Remote)
+
+			registry := remote.NewRegistry()
+			registry.Register("github.com", github)
+			registry.Register("ghe.example.com", enterprise)
+
+			c = new(gin.Context)
+			remote.RegistryToContext(c, registry)
+		})
+
+		g.It("Should list the hosts in registration order", func() {
+			g.Assert(remote.Hosts(c)).Equal([]string{"github.com", "ghe.example.com"})
+		})
+
+		g.It("Should resolve the empty host to the default remote", func() {
+			g.Assert(remote.Host(c, "")).Equal("github.com")
+			r, err := remote.FromHost(c, "")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(r == github).IsTrue()
+		})
+
+		g.It("Should resolve the remote of the repository", func() {
+			r, err := remote.FromRepo(c, &model.Repo{Slug: "octocat/hello-world", Remote: "ghe.example.com"})
+			g.Assert(err == nil).IsTrue()
+			g.Assert(r == enterprise).IsTrue()
+		})
+
+		g.It("Should resolve the remote of the current user", func() {
+			c.Set("user", &model.User{Login: "octocat", Remote: "ghe.example.com"})
+			g.Assert(remote.FromContext(c) == enterprise).IsTrue()
+		})
+
+		g.It("Should fail to resolve an unknown remote", func() {
+			_, err := remote.FromUser(c, &model.User{Login: "octocat", Remote: "gitlab.com"})
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should route remote calls to the remote of the repository", func() {
+			user := &model.User{Login: "octocat", Remote: "ghe.example.com"}
+			repo := &model.Repo{Slug: "octocat/hello-world", Remote: "ghe.example.com"}
+			enterprise.On("GetPulls", c, user, repo).Return([]*model.Issue{}, nil).Once()
+
+			_, err := remote.GetPulls(c, user, repo)
+			g.Assert(err == nil).IsTrue()
+			enterprise.AssertExpectations(t)
+			github.AssertExpectations(t)
+		})
+	})
+}
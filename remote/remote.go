@@ -21,8 +21,8 @@ type Remote interface {
 	// GetTeams gets a team list from the remote system.
 	GetTeams(context.Context, *model.User) ([]*model.Team, error)
 
-	// GetMembers gets a team member list from the remote system.
-	GetMembers(context.Context, *model.User, string) ([]*model.Member, error)
+	// GetMembers gets an org team member list from the remote system.
+	GetMembers(context.Context, *model.User, string, string) ([]*model.Member, error)
 
 	// GetRepo gets a repository from the remote system.
 	GetRepo(context.Context, *model.User, string, string) (*model.Repo, error)
@@ -45,11 +45,38 @@ type Remote interface {
 	// GetComments gets pull request comments from the remote system.
 	GetReviews(context.Context, *model.User, *model.Repo, int) ([]*model.Review, error)
 
-	// GetContents gets the file contents from the remote system.
-	GetContents(context.Context, *model.User, *model.Repo, string) ([]byte, error)
+	// GetFiles gets the pull request changed files from the remote system.
+	GetFiles(context.Context, *model.User, *model.Repo, int) ([]string, error)
+
+	// GetHeadCommit gets the head commit of a pull request from the remote system.
+	GetHeadCommit(context.Context, *model.User, *model.Repo, int) (*model.Commit, error)
+
+	// GetContents gets the file contents at the ref from the remote system.
+	// An empty ref refers to the default branch.
+	GetContents(context.Context, *model.User, *model.Repo, string, string) ([]byte, error)
+
+	// GetPull gets the pull request from the remote system.
+	GetPull(context.Context, *model.User, *model.Repo, int) (*model.Issue, error)
+
+	// GetPulls gets the open pull requests from the remote system.
+	GetPulls(context.Context, *model.User, *model.Repo) ([]*model.Issue, error)
+
+	// GetStatus gets the approval status of the commit from the remote
+	// system. The state is empty if no status was set.
+	GetStatus(context.Context, *model.User, *model.Repo, string) (*model.Status, error)
+
+	// IsProtected checks if the branch is protected in the remote system.
+	IsProtected(context.Context, *model.User, *model.Repo, string) (bool, error)
+
+	// IsMerged checks if the commit was merged through a pull request in
+	// the remote system.
+	IsMerged(context.Context, *model.User, *model.Repo, string) (bool, error)
+
+	// GetAdmins gets the repository administrators from the remote system.
+	GetAdmins(context.Context, *model.User, *model.Repo) ([]*model.Member, error)
 
 	// SetStatus adds or updates the pull request status in the remote system.
-	SetStatus(context.Context, *model.User, *model.Repo, int, int, int) error
+	SetStatus(context.Context, *model.User, *model.Repo, int, *model.Status) error
 
 	// GetHook gets the hook from the http Request.
 	GetHook(c context.Context, r *http.Request) (*model.Hook, error)
@@ -62,6 +89,26 @@ type Remote interface {
 
 	// GetIssueLabels get all the labels of an issue
 	GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error)
+
+	// CreateLabels creates the labels that do not exist in the repository
+	CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error
+}
+
+// App is implemented by remotes that may act as an app installed on the
+// repositories, instead of acting with the token of a user. The hooks
+// delivered to the app carry no repository access token.
+type App interface {
+	// CheckHook checks the signature of a hook delivered to the app,
+	// and fails if the remote is not configured as an app.
+	CheckHook(*http.Request, []byte) error
+}
+
+// Signer is implemented by remotes that sign the payload of the hooks
+// added by SetHook with the repository secret, in the X-Hub-Signature-256
+// header.
+type Signer interface {
+	// SignsHooks returns true if the hooks added by SetHook are signed.
+	SignsHooks() bool
 }
 
 // GetUser authenticates a user with the remote system.
@@ -77,57 +124,186 @@ func GetUserToken(c context.Context, token string) (string, error) {
 
 // GetTeams gets a team list from the remote system.
 func GetTeams(c context.Context, u *model.User) ([]*model.Team, error) {
-	return FromContext(c).GetTeams(c, u)
+	remote, err := FromUser(c, u)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetTeams(c, u)
 }
 
-// GetMembers gets a team members list from the remote system.
-func GetMembers(c context.Context, u *model.User, team string) ([]*model.Member, error) {
-	return FromContext(c).GetMembers(c, u, team)
+// GetMembers gets an org team members list from the remote system.
+func GetMembers(c context.Context, u *model.User, org, team string) ([]*model.Member, error) {
+	remote, err := FromUser(c, u)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetMembers(c, u, org, team)
 }
 
 // GetRepo gets a repository from the remote system.
 func GetRepo(c context.Context, u *model.User, owner, name string) (*model.Repo, error) {
-	return FromContext(c).GetRepo(c, u, owner, name)
+	remote, err := FromUser(c, u)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetRepo(c, u, owner, name)
 }
 
 // GetPerm gets a repository permission from the remote system.
 func GetPerm(c context.Context, u *model.User, owner, name string) (*model.Perm, error) {
-	return FromContext(c).GetPerm(c, u, owner, name)
+	remote, err := FromUser(c, u)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetPerm(c, u, owner, name)
 }
 
 // GetRepos gets a repository list from the remote system.
 func GetRepos(c context.Context, u *model.User) ([]*model.Repo, error) {
-	return FromContext(c).GetRepos(c, u)
+	remote, err := FromUser(c, u)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetRepos(c, u)
 }
 
 // GetComments gets pull request comments from the remote system.
 func GetComments(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Comment, error) {
-	return FromContext(c).GetComments(c, u, r, num)
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetComments(c, u, r, num)
 }
 
 // GetReviews gets pull request reviews from the remote system.
 func GetReviews(c context.Context, u *model.User, r *model.Repo, num int) ([]*model.Review, error) {
-	return FromContext(c).GetReviews(c, u, r, num)
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetReviews(c, u, r, num)
+}
+
+// GetFiles gets the pull request changed files from the remote system.
+func GetFiles(c context.Context, u *model.User, r *model.Repo, num int) ([]string, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetFiles(c, u, r, num)
+}
+
+// GetHeadCommit gets the head commit of a pull request from the remote system.
+func GetHeadCommit(c context.Context, u *model.User, r *model.Repo, num int) (*model.Commit, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetHeadCommit(c, u, r, num)
 }
 
-// GetContents gets the file contents from the remote system.
-func GetContents(c context.Context, u *model.User, r *model.Repo, path string) ([]byte, error) {
-	return FromContext(c).GetContents(c, u, r, path)
+// GetContents gets the file contents at the ref from the remote system.
+func GetContents(c context.Context, u *model.User, r *model.Repo, path, ref string) ([]byte, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetContents(c, u, r, path, ref)
+}
+
+// GetPull gets the pull request from the remote system.
+func GetPull(c context.Context, u *model.User, r *model.Repo, num int) (*model.Issue, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetPull(c, u, r, num)
+}
+
+// GetPulls gets the open pull requests from the remote system.
+func GetPulls(c context.Context, u *model.User, r *model.Repo) ([]*model.Issue, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetPulls(c, u, r)
+}
+
+// GetStatus gets the approval status of the commit from the remote
+// system. The state is empty if no status was set.
+func GetStatus(c context.Context, u *model.User, r *model.Repo, sha string) (*model.Status, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetStatus(c, u, r, sha)
+}
+
+// IsProtected checks if the branch is protected in the remote system.
+func IsProtected(c context.Context, u *model.User, r *model.Repo, branch string) (bool, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return false, err
+	}
+	return remote.IsProtected(c, u, r, branch)
+}
+
+// IsMerged checks if the commit was merged through a pull request in
+// the remote system.
+func IsMerged(c context.Context, u *model.User, r *model.Repo, sha string) (bool, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return false, err
+	}
+	return remote.IsMerged(c, u, r, sha)
+}
+
+// GetAdmins gets the repository administrators from the remote system.
+func GetAdmins(c context.Context, u *model.User, r *model.Repo) ([]*model.Member, error) {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetAdmins(c, u, r)
 }
 
 // SetHook adds a webhook to the remote repository.
 func SetHook(c context.Context, u *model.User, r *model.Repo, hook string) error {
-	return FromContext(c).SetHook(c, u, r, hook)
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return err
+	}
+	return remote.SetHook(c, u, r, hook)
+}
+
+// SignsHooks returns true if the remote of the repository signs the
+// payload of the hooks added by SetHook.
+func SignsHooks(c context.Context, r *model.Repo) bool {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return false
+	}
+	signer, ok := remote.(Signer)
+	return ok && signer.SignsHooks()
 }
 
 // DelHook deletes a webhook from the remote repository.
 func DelHook(c context.Context, u *model.User, r *model.Repo, hook string) error {
-	return FromContext(c).DelHook(c, u, r, hook)
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return err
+	}
+	return remote.DelHook(c, u, r, hook)
 }
 
 // SetStatus adds or updates the pull request status in the remote system.
-func SetStatus(c context.Context, u *model.User, r *model.Repo, num, granted, required int) error {
-	return FromContext(c).SetStatus(c, u, r, num, granted, required)
+func SetStatus(c context.Context, u *model.User, r *model.Repo, num int, status *model.Status) error {
+	remote, err := FromRepo(c, r)
+	if err != nil {
+		return err
+	}
+	return remote.SetStatus(c, u, r, num, status)
 }
 
 // GetHook gets the hook from the http Request.
@@ -137,15 +313,36 @@ func GetHook(c context.Context, r *http.Request) (*model.Hook, error) {
 
 // RemoveIssueLabels remove the labels of some issue.
 func RemoveIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
-	return FromContext(c).RemoveIssueLabels(c, user, repo, number, labels)
+	remote, err := FromRepo(c, repo)
+	if err != nil {
+		return err
+	}
+	return remote.RemoveIssueLabels(c, user, repo, number, labels)
 }
 
 // GetIssueLabels get all the labels of an issue
 func GetIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int) ([]string, error) {
-	return FromContext(c).GetIssueLabels(c, user, repo, number)
+	remote, err := FromRepo(c, repo)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetIssueLabels(c, user, repo, number)
 }
 
 // AddIssueLabels writes labels for the requirements of reviews.
 func AddIssueLabels(c context.Context, user *model.User, repo *model.Repo, number int, labels []string) error {
-	return FromContext(c).AddIssueLabels(c, user, repo, number, labels)
+	remote, err := FromRepo(c, repo)
+	if err != nil {
+		return err
+	}
+	return remote.AddIssueLabels(c, user, repo, number, labels)
+}
+
+// CreateLabels creates the labels that do not exist in the repository.
+func CreateLabels(c context.Context, user *model.User, repo *model.Repo, labels []*model.Label) error {
+	remote, err := FromRepo(c, repo)
+	if err != nil {
+		return err
+	}
+	return remote.CreateLabels(c, user, repo, labels)
 }
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/go-gitea/lgtm/queue"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianschenck/envflag"
+)
+
+var (
+	workers  = envflag.Int("QUEUE_WORKERS", 4, "")
+	retries  = envflag.Int("QUEUE_RETRIES", 5, "")
+	backoff  = envflag.Duration("QUEUE_BACKOFF", time.Second*30, "")
+	interval = envflag.Duration("QUEUE_INTERVAL", time.Second*10, "")
+)
+
+// Queue is a middleware that starts the hook queue workers. The workers
+// run outside of any request, so the middleware that provide the store,
+// remote and cache are applied to a background context for their use.
+func Queue(middleware ...gin.HandlerFunc) gin.HandlerFunc {
+	background := new(gin.Context)
+	for _, handler := range middleware {
+		handler(background)
+	}
+
+	q := queue.New(*workers, *retries, *backoff, *interval)
+	q.Start(background)
+
+	return func(c *gin.Context) {
+		queue.ToContext(c, q)
+		c.Next()
+	}
+}
@@ -1,12 +1,21 @@
 package middleware
 
 import (
+	"io/ioutil"
+	"net/url"
 	"strings"
 
+	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/remote/bitbucketserver"
+	"github.com/go-gitea/lgtm/remote/gitea"
 	"github.com/go-gitea/lgtm/remote/github"
+	"github.com/go-gitea/lgtm/remote/gitlab"
+	"github.com/go-gitea/lgtm/store"
 
+	"github.com/BurntSushi/toml"
 	"github.com/gin-gonic/gin"
 	"github.com/ianschenck/envflag"
+	log "github.com/sirupsen/logrus"
 )
 
 const (
@@ -18,30 +27,284 @@ const (
 
 	// DefaultScope defines the standard scope for the remote.
 	DefaultScope = "user:email,read:org,public_repo"
+
+	// DefaultGitlabURL defines the standard GitLab remote URL.
+	DefaultGitlabURL = "https://gitlab.com"
+
+	// DefaultGitlabScope defines the standard scope for the GitLab
+	// remote.
+	DefaultGitlabScope = "api"
+
+	// DefaultDriver defines the standard remote driver.
+	DefaultDriver = "github"
+
+	// DefaultMaxPages defines the standard upper bound of pages
+	// retrieved from list endpoints.
+	DefaultMaxPages = 100
 )
 
 var (
+	remoteDriver     = envflag.String("REMOTE_DRIVER", DefaultDriver, "")
+	remoteConfigFile = envflag.String("REMOTE_CONFIG", "", "")
+
 	server = envflag.String("GITHUB_URL", DefaultURL, "")
 	client = envflag.String("GITHUB_CLIENT", "", "")
 	secret = envflag.String("GITHUB_SECRET", "", "")
 	scope  = envflag.String("GITHUB_SCOPE", DefaultScope, "")
+	pages  = envflag.Int("GITHUB_MAX_PAGES", DefaultMaxPages, "")
+
+	appID     = envflag.Int64("GITHUB_APP_ID", 0, "")
+	appKey    = envflag.String("GITHUB_APP_KEY", "", "")
+	appSecret = envflag.String("GITHUB_APP_SECRET", "", "")
+
+	giteaServer = envflag.String("GITEA_URL", "", "")
+	giteaClient = envflag.String("GITEA_CLIENT", "", "")
+	giteaSecret = envflag.String("GITEA_SECRET", "", "")
+	giteaPages  = envflag.Int("GITEA_MAX_PAGES", DefaultMaxPages, "")
+
+	gitlabServer = envflag.String("GITLAB_URL", DefaultGitlabURL, "")
+	gitlabClient = envflag.String("GITLAB_CLIENT", "", "")
+	gitlabSecret = envflag.String("GITLAB_SECRET", "", "")
+	gitlabScope  = envflag.String("GITLAB_SCOPE", DefaultGitlabScope, "")
+	gitlabPages  = envflag.Int("GITLAB_MAX_PAGES", DefaultMaxPages, "")
+
+	bitbucketServer = envflag.String("BITBUCKET_URL", "", "")
+	bitbucketPages  = envflag.Int("BITBUCKET_MAX_PAGES", DefaultMaxPages, "")
 )
 
+// remoteConfig represents a remote of the remotes configuration file.
+type remoteConfig struct {
+	Driver   string `toml:"driver"`
+	URL      string `toml:"url"`
+	Client   string `toml:"client"`
+	Secret   string `toml:"secret"`
+	Scope    string `toml:"scope"`
+	MaxPages int    `toml:"max_pages"`
+
+	// the GitHub App, which acts on the repositories it is installed
+	// on instead of the user who activated them.
+	AppID     int64  `toml:"app_id"`
+	AppKey    string `toml:"app_key"`
+	AppSecret string `toml:"app_secret"`
+}
+
 // Remote is a simple middleware which configures the remote authentication.
+// The remotes are read from the REMOTE_CONFIG file, or the remote of the
+// REMOTE_DRIVER is configured from the environment, and registered by host.
 func Remote() gin.HandlerFunc {
+	var configs []*remoteConfig
+	if len(*remoteConfigFile) != 0 {
+		configs = loadRemotes(*remoteConfigFile)
+	} else {
+		configs = []*remoteConfig{envRemote()}
+	}
+
+	registry := remote.NewRegistry()
+	for _, config := range configs {
+		r, link := setupRemote(config)
+		uri, err := url.Parse(link)
+		if err != nil || len(uri.Host) == 0 {
+			log.Fatalf("Invalid %s remote url %s.", config.Driver, link)
+		}
+		if registry.Lookup(uri.Host) != nil {
+			log.Fatalf("Duplicate remote %s.", uri.Host)
+		}
+		registry.Register(uri.Host, r)
+	}
+	return func(c *gin.Context) {
+		remote.RegistryToContext(c, registry)
+		c.Next()
+	}
+}
+
+// DefaultRemote assigns the default remote to the users and repositories
+// that were activated before several remotes were supported, since they
+// are looked up by remote. The store and remote middleware are applied
+// to a background context for its use.
+func DefaultRemote(middleware ...gin.HandlerFunc) {
+	background := new(gin.Context)
+	for _, handler := range middleware {
+		handler(background)
+	}
+	if err := store.UpdateRemote(background, remote.Host(background, "")); err != nil {
+		log.Fatalf("Error assigning the default remote. %s", err)
+	}
+}
+
+// loadRemotes is a helper function that reads the remotes from the
+// configuration file.
+func loadRemotes(path string) []*remoteConfig {
+	var file struct {
+		Remotes []*remoteConfig `toml:"remote"`
+	}
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		log.Fatalf("Error reading remotes from %s. %s", path, err)
+	}
+	if len(file.Remotes) == 0 {
+		log.Fatalf("Error reading remotes from %s. No remote configured.", path)
+	}
+	for _, config := range file.Remotes {
+		if config.MaxPages == 0 {
+			config.MaxPages = DefaultMaxPages
+		}
+	}
+	return file.Remotes
+}
+
+// envRemote is a helper function that reads the remote of the
+// REMOTE_DRIVER from the environment.
+func envRemote() *remoteConfig {
+	switch *remoteDriver {
+	case "gitea":
+		if len(*giteaServer) == 0 {
+			log.Fatalf("GITEA_URL is required by the gitea remote driver.")
+		}
+		return &remoteConfig{
+			Driver:   "gitea",
+			URL:      *giteaServer,
+			Client:   *giteaClient,
+			Secret:   *giteaSecret,
+			MaxPages: *giteaPages,
+		}
+	case "gitlab":
+		return &remoteConfig{
+			Driver:   "gitlab",
+			URL:      *gitlabServer,
+			Client:   *gitlabClient,
+			Secret:   *gitlabSecret,
+			Scope:    *gitlabScope,
+			MaxPages: *gitlabPages,
+		}
+	case "bitbucketserver":
+		if len(*bitbucketServer) == 0 {
+			log.Fatalf("BITBUCKET_URL is required by the bitbucketserver remote driver.")
+		}
+		return &remoteConfig{
+			Driver:   "bitbucketserver",
+			URL:      *bitbucketServer,
+			MaxPages: *bitbucketPages,
+		}
+	}
+	return &remoteConfig{
+		Driver:   *remoteDriver,
+		URL:      *server,
+		Client:   *client,
+		Secret:   *secret,
+		Scope:    *scope,
+		MaxPages: *pages,
+
+		AppID:     *appID,
+		AppKey:    *appKey,
+		AppSecret: *appSecret,
+	}
+}
+
+// setupRemote is a helper function that configures the remote of the
+// driver, and returns it with its url.
+func setupRemote(config *remoteConfig) (remote.Remote, string) {
+	switch config.Driver {
+	case "gitea":
+		r := setupGitea(config)
+		return r, r.URL
+	case "gitlab":
+		r := setupGitlab(config)
+		return r, r.URL
+	case "bitbucketserver":
+		r := setupBitbucketServer(config)
+		return r, r.URL
+	case "github":
+		r := setupGithub(config)
+		return r, r.URL
+	}
+	log.Fatalf("Unknown remote driver %s.", config.Driver)
+	return nil, ""
+}
+
+// setupGithub is a helper function that configures the GitHub remote.
+func setupGithub(config *remoteConfig) *github.Github {
+	if len(config.URL) == 0 {
+		config.URL = DefaultURL
+	}
+	if len(config.Scope) == 0 {
+		config.Scope = DefaultScope
+	}
 	remote := &github.Github{
 		API:    DefaultAPI,
-		URL:    *server,
-		Client: *client,
-		Secret: *secret,
-		Scopes: strings.Split(*scope, ","),
+		URL:    config.URL,
+		Client: config.Client,
+		Secret: config.Secret,
+		Scopes: strings.Split(config.Scope, ","),
+
+		MaxPages: config.MaxPages,
 	}
 	if remote.URL != DefaultURL {
 		remote.URL = strings.TrimSuffix(remote.URL, "/")
 		remote.API = remote.URL + "/api/v3/"
 	}
-	return func(c *gin.Context) {
-		c.Set("remote", remote)
-		c.Next()
+	if config.AppID != 0 {
+		remote.App = setupGithubApp(config)
+	}
+	return remote
+}
+
+// setupGithubApp is a helper function that configures the GitHub App
+// with the private key read from the app_key file.
+func setupGithubApp(config *remoteConfig) *github.App {
+	if len(config.AppKey) == 0 || len(config.AppSecret) == 0 {
+		log.Fatalf("The app key and the app secret are required by the GitHub App %d.", config.AppID)
+	}
+	key, err := ioutil.ReadFile(config.AppKey)
+	if err != nil {
+		log.Fatalf("Error reading the private key of the GitHub App %d. %s", config.AppID, err)
+	}
+	app, err := github.NewApp(config.AppID, key, config.AppSecret)
+	if err != nil {
+		log.Fatalf("Error configuring the GitHub App %d. %s", config.AppID, err)
+	}
+	return app
+}
+
+// setupGitea is a helper function that configures the Gitea remote.
+func setupGitea(config *remoteConfig) *gitea.Gitea {
+	if len(config.URL) == 0 {
+		log.Fatalf("The url is required by the gitea remote driver.")
+	}
+	return &gitea.Gitea{
+		URL:    strings.TrimSuffix(config.URL, "/"),
+		Client: config.Client,
+		Secret: config.Secret,
+
+		MaxPages: config.MaxPages,
+	}
+}
+
+// setupGitlab is a helper function that configures the GitLab remote.
+func setupGitlab(config *remoteConfig) *gitlab.Gitlab {
+	if len(config.URL) == 0 {
+		config.URL = DefaultGitlabURL
+	}
+	if len(config.Scope) == 0 {
+		config.Scope = DefaultGitlabScope
+	}
+	return &gitlab.Gitlab{
+		URL:    strings.TrimSuffix(config.URL, "/"),
+		Client: config.Client,
+		Secret: config.Secret,
+		Scopes: strings.Split(config.Scope, ","),
+
+		MaxPages: config.MaxPages,
+	}
+}
+
+// setupBitbucketServer is a helper function that configures the
+// Bitbucket Server remote.
+func setupBitbucketServer(config *remoteConfig) *bitbucketserver.Bitbucket {
+	if len(config.URL) == 0 {
+		log.Fatalf("The url is required by the bitbucketserver remote driver.")
+	}
+	return &bitbucketserver.Bitbucket{
+		URL: strings.TrimSuffix(config.URL, "/"),
+
+		MaxPages: config.MaxPages,
 	}
 }
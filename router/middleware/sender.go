@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/go-gitea/lgtm/notifier"
+	"github.com/go-gitea/lgtm/notifier/slack"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianschenck/envflag"
+)
+
+var slackWebhook = envflag.String("SLACK_WEBHOOK", "", "")
+
+// Sender is a middleware function that initializes the notification
+// sender and attaches to the context of every http.Request. Notifications
+// are dropped unless a Slack incoming webhook is configured.
+func Sender() gin.HandlerFunc {
+	var sender notifier.Sender
+	if len(*slackWebhook) != 0 {
+		sender = slack.New(*slackWebhook)
+	}
+	return func(c *gin.Context) {
+		if sender != nil {
+			notifier.ToContext(c, sender)
+		}
+		c.Next()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/remote"
 	"github.com/go-gitea/lgtm/shared/token"
 	"github.com/go-gitea/lgtm/store"
 
@@ -40,10 +41,11 @@ func SetUser(c *gin.Context) {
 	var user *model.User
 
 	// authenticates the user via an authentication cookie
-	// or an auth token.
+	// or an auth token. Tokens without a remote were issued to
+	// users of the default remote.
 	t, err := token.ParseRequest(c.Request, func(t *token.Token) (string, error) {
 		var err error
-		user, err = store.GetUserLogin(c, t.Text)
+		user, err = store.GetUserLogin(c, remote.Host(c, t.Remote), t.Text)
 		return user.Secret, err
 	})
 
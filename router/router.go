@@ -30,13 +30,22 @@ func Load(middleware ...gin.HandlerFunc) http.Handler {
 	e.GET("/api/user", session.UserMust, api.GetUser)
 	e.GET("/api/user/teams", session.UserMust, api.GetTeams)
 	e.GET("/api/user/repos", session.UserMust, api.GetRepos)
+	e.GET("/api/orgs/:owner/report", session.UserMust, api.GetOrgReport)
 	e.GET("/api/repos/:owner/:repo", session.UserMust, access.RepoPull, api.GetRepo)
 	e.POST("/api/repos/:owner/:repo", session.UserMust, access.RepoAdmin, api.PostRepo)
 	e.DELETE("/api/repos/:owner/:repo", session.UserMust, access.RepoAdmin, api.DeleteRepo)
 	e.GET("/api/repos/:owner/:repo/maintainers", session.UserMust, access.RepoPull, api.GetMaintainer)
 	e.GET("/api/repos/:owner/:repo/maintainers/:org", session.UserMust, access.RepoPull, api.GetMaintainerOrg)
+	e.POST("/api/repos/:owner/:repo/sync", session.UserMust, access.RepoAdmin, api.PostSync)
+	e.GET("/api/repos/:owner/:repo/pulls/:number/explain", session.UserMust, access.RepoPull, api.GetPullExplain)
+	e.POST("/api/repos/:owner/:repo/pulls/:number/sync", session.UserMust, access.RepoAdmin, api.PostPullSync)
+	e.GET("/api/repos/:owner/:repo/report", session.UserMust, access.RepoPull, api.GetReport)
+	e.GET("/api/repos/:owner/:repo/bypasses", session.UserMust, access.RepoPull, api.GetBypasses)
+	e.GET("/api/repos/:owner/:repo/hooks", session.UserMust, access.RepoAdmin, api.GetHooks)
+	e.POST("/api/repos/:owner/:repo/hooks/:id/replay", session.UserMust, access.RepoAdmin, api.PostHookReplay)
 
 	e.POST("/hook", web.Hook)
+	e.POST("/hook/:remote", web.Hook)
 	e.GET("/login", web.Login)
 	e.POST("/login", web.LoginToken)
 	e.GET("/logout", web.Logout)
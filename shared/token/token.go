@@ -1,8 +1,13 @@
 package token
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 )
@@ -27,10 +32,12 @@ const (
 // SignerAlgo defines the default algorithm used to sign JWT tokens.
 const SignerAlgo = "HS256"
 
-// Token represents our simple JWT.
+// Token represents our simple JWT. The remote of a user token is empty
+// for tokens issued before several remotes were supported.
 type Token struct {
-	Kind string
-	Text string
+	Kind   string
+	Text   string
+	Remote string
 }
 
 // Parse parses a raw JWT.
@@ -89,6 +96,25 @@ func CheckCsrf(r *http.Request, fn SecretFunc) error {
 	return err
 }
 
+// CheckSignature checks the HMAC-SHA256 signature of a hook payload, as
+// sent by the remote system in the X-Hub-Signature-256 header.
+func CheckSignature(r *http.Request, payload []byte, secret string) error {
+	sig := r.Header.Get("X-Hub-Signature-256")
+	if !strings.HasPrefix(sig, "sha256=") {
+		return errors.New("missing or malformed payload signature")
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("payload signature check failed")
+	}
+	return nil
+}
+
 // New initializes a new JWT.
 func New(kind, text string) *Token {
 	return &Token{Kind: kind, Text: text}
@@ -106,6 +132,9 @@ func (t *Token) SignExpires(secret string, exp int64) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 	token.Claims["type"] = t.Kind
 	token.Claims["text"] = t.Text
+	if len(t.Remote) != 0 {
+		token.Claims["remote"] = t.Remote
+	}
 	if exp > 0 {
 		token.Claims["exp"] = float64(exp)
 	}
@@ -135,6 +164,11 @@ func keyFunc(token *Token, fn SecretFunc) jwt.Keyfunc {
 		}
 		token.Text, _ = textv.(string)
 
+		// extract the optional token remote.
+		if remotev, ok := t.Claims["remote"]; ok {
+			token.Remote, _ = remotev.(string)
+		}
+
 		// invoke the callback function to retrieve
 		// the secret key used to verify
 		secret, err := fn(token)
@@ -0,0 +1,62 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+)
+
+func TestCheckSignature(t *testing.T) {
+	var payload = []byte(`{"action":"created"}`)
+	var tests = []struct {
+		header string
+		valid  bool
+	}{
+		{"sha256=9b1bc1b77fd6b6c13a5ab9f6dc22d1fdb0c0b0a5b07cc1f2ee1a7d5a1d9f8f3b", false},
+		{"sha256=" + sign(payload, "secret"), true},
+		{"sha256=" + sign(payload, "other"), false},
+		{"sha1=" + sign(payload, "secret"), false},
+		{"sha256=zz", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("POST", "/hook", nil)
+		r.Header.Set("X-Hub-Signature-256", test.header)
+		err := CheckSignature(r, payload, "secret")
+		if test.valid && err != nil {
+			t.Errorf("Wanted signature %q to be valid, got %s", test.header, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Wanted signature %q to be invalid", test.header)
+		}
+	}
+}
+
+func sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestRemote(t *testing.T) {
+	var tests = []string{"", "github.example.com"}
+	for _, remote := range tests {
+		token := New(SessToken, "octocat")
+		token.Remote = remote
+		raw, err := token.Sign("secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := Parse(raw, func(*Token) (string, error) {
+			return "secret", nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Text != "octocat" || parsed.Remote != remote {
+			t.Errorf("Wanted octocat of remote %q, got %s of remote %q", remote, parsed.Text, parsed.Remote)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetApprovalList(repo int64, number int) ([]*model.Approval, error) {
+	var approvals = []*model.Approval{}
+	var err = meddler.QueryAll(db, &approvals, rebind(approvalListQuery), repo, number)
+	return approvals, err
+}
+
+func (db *datastore) GetApprovalActive(repo int64, number int) ([]*model.Approval, error) {
+	var approvals = []*model.Approval{}
+	var err = meddler.QueryAll(db, &approvals, rebind(approvalActiveQuery), repo, number)
+	return approvals, err
+}
+
+func (db *datastore) CreateApproval(approval *model.Approval) error {
+	return meddler.Insert(db, approvalTable, approval)
+}
+
+func (db *datastore) UpdateApproval(approval *model.Approval) error {
+	return meddler.Update(db, approvalTable, approval)
+}
+
+const approvalTable = "approvals"
+
+const approvalListQuery = `
+SELECT *
+FROM approvals
+WHERE approval_repo_id = ?
+  AND approval_number = ?
+ORDER BY approval_granted, approval_id
+`
+
+const approvalActiveQuery = `
+SELECT *
+FROM approvals
+WHERE approval_repo_id = ?
+  AND approval_number = ?
+  AND approval_revoked = 0
+ORDER BY approval_granted, approval_id
+`
@@ -0,0 +1,72 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_approvalstore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Approval", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM approvals")
+		})
+
+		g.It("Should Add an Approval", func() {
+			approval := model.Approval{
+				RepoID:   1,
+				Number:   42,
+				SHA:      "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Login:    "octocat",
+				Source:   model.ApprovalReview,
+				SourceID: 80,
+				Granted:  1000,
+			}
+			err := s.CreateApproval(&approval)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(approval.ID != 0).IsTrue()
+
+			approvals, err := s.GetApprovalList(1, 42)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(approvals)).Equal(1)
+			g.Assert(approvals[0].SHA).Equal(approval.SHA)
+			g.Assert(approvals[0].Source).Equal(model.ApprovalReview)
+			g.Assert(approvals[0].SourceID).Equal(int64(80))
+		})
+
+		g.It("Should Get the Active Approvals", func() {
+			approvals := []*model.Approval{
+				{RepoID: 1, Number: 42, Login: "octocat", Granted: 1000, Revoked: 2000},
+				{RepoID: 1, Number: 42, Login: "octocat", Granted: 3000},
+				{RepoID: 1, Number: 43, Login: "octocat", Granted: 1000},
+				{RepoID: 2, Number: 42, Login: "octocat", Granted: 1000},
+			}
+			for _, approval := range approvals {
+				s.CreateApproval(approval)
+			}
+
+			active, err := s.GetApprovalActive(1, 42)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(active)).Equal(1)
+			g.Assert(active[0].ID).Equal(approvals[1].ID)
+
+			active[0].Revoked = 4000
+			err = s.UpdateApproval(active[0])
+			g.Assert(err == nil).IsTrue()
+
+			active, _ = s.GetApprovalActive(1, 42)
+			g.Assert(len(active)).Equal(0)
+			history, _ := s.GetApprovalList(1, 42)
+			g.Assert(len(history)).Equal(2)
+		})
+	})
+}
@@ -0,0 +1,41 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetBypassSHA(repo int64, sha string) (*model.Bypass, error) {
+	var bypass = new(model.Bypass)
+	var err = meddler.QueryRow(db, bypass, rebind(bypassSHAQuery), repo, sha)
+	return bypass, err
+}
+
+func (db *datastore) GetBypassList(repo int64, limit, offset int) ([]*model.Bypass, error) {
+	var bypasses = []*model.Bypass{}
+	var err = meddler.QueryAll(db, &bypasses, rebind(bypassListQuery), repo, limit, offset)
+	return bypasses, err
+}
+
+func (db *datastore) CreateBypass(bypass *model.Bypass) error {
+	return meddler.Insert(db, bypassTable, bypass)
+}
+
+const bypassTable = "bypasses"
+
+const bypassSHAQuery = `
+SELECT *
+FROM bypasses
+WHERE bypass_repo_id = ?
+  AND bypass_sha = ?
+LIMIT 1
+`
+
+const bypassListQuery = `
+SELECT *
+FROM bypasses
+WHERE bypass_repo_id = ?
+ORDER BY bypass_created DESC, bypass_id DESC
+LIMIT ? OFFSET ?
+`
@@ -0,0 +1,63 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_bypassstore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Bypass", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM bypasses")
+		})
+
+		g.It("Should Add a Bypass", func() {
+			bypass := model.Bypass{
+				RepoID:  1,
+				Kind:    model.BypassPush,
+				Branch:  "master",
+				SHA:     "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Login:   "octocat",
+				Created: 1000,
+			}
+			err := s.CreateBypass(&bypass)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(bypass.ID != 0).IsTrue()
+
+			getbypass, err := s.GetBypassSHA(1, bypass.SHA)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getbypass.Kind).Equal(model.BypassPush)
+			g.Assert(getbypass.Login).Equal("octocat")
+		})
+
+		g.It("Should Enforce Unique Commit", func() {
+			err1 := s.CreateBypass(&model.Bypass{RepoID: 1, SHA: "6dcb09b"})
+			err2 := s.CreateBypass(&model.Bypass{RepoID: 1, SHA: "6dcb09b"})
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsFalse()
+		})
+
+		g.It("Should Get a Bypass List", func() {
+			for i, sha := range []string{"a", "b", "c"} {
+				s.CreateBypass(&model.Bypass{RepoID: 1, SHA: sha, Created: int64(i)})
+			}
+			s.CreateBypass(&model.Bypass{RepoID: 2, SHA: "d"})
+
+			bypasses, err := s.GetBypassList(1, 2, 0)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(bypasses)).Equal(2)
+			g.Assert(bypasses[0].SHA).Equal("c")
+			g.Assert(bypasses[1].SHA).Equal("b")
+		})
+	})
+}
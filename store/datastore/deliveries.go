@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetDelivery(id int64) (*model.Delivery, error) {
+	var delivery = new(model.Delivery)
+	var err = meddler.Load(db, deliveryTable, delivery, id)
+	return delivery, err
+}
+
+func (db *datastore) GetDeliveryGUID(guid string) (*model.Delivery, error) {
+	var delivery = new(model.Delivery)
+	var err = meddler.QueryRow(db, delivery, rebind(deliveryGUIDQuery), guid)
+	return delivery, err
+}
+
+func (db *datastore) GetDeliveryList(repo int64, limit, offset int) ([]*model.Delivery, error) {
+	var deliveries = []*model.Delivery{}
+	var err = meddler.QueryAll(db, &deliveries, rebind(deliveryListQuery), repo, limit, offset)
+	return deliveries, err
+}
+
+func (db *datastore) GetDeliveryJob(job int64) ([]*model.Delivery, error) {
+	var deliveries = []*model.Delivery{}
+	var err = meddler.QueryAll(db, &deliveries, rebind(deliveryJobQuery), job)
+	return deliveries, err
+}
+
+func (db *datastore) CreateDelivery(delivery *model.Delivery) error {
+	return meddler.Insert(db, deliveryTable, delivery)
+}
+
+func (db *datastore) UpdateDelivery(delivery *model.Delivery) error {
+	return meddler.Update(db, deliveryTable, delivery)
+}
+
+const deliveryTable = "deliveries"
+
+const deliveryGUIDQuery = `
+SELECT *
+FROM deliveries
+WHERE delivery_guid = ?
+ORDER BY delivery_id
+LIMIT 1
+`
+
+const deliveryListQuery = `
+SELECT *
+FROM deliveries
+WHERE delivery_repo_id = ?
+ORDER BY delivery_id DESC
+LIMIT ? OFFSET ?
+`
+
+const deliveryJobQuery = `
+SELECT *
+FROM deliveries
+WHERE delivery_job_id = ?
+ORDER BY delivery_id
+`
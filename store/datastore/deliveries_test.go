@@ -0,0 +1,99 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_deliverystore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Delivery", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM deliveries")
+		})
+
+		g.It("Should Add a Delivery", func() {
+			delivery := model.Delivery{
+				RepoID: 1,
+				JobID:  2,
+				GUID:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				Event:  "issue_comment",
+				Number: 42,
+				Status: model.JobPending,
+			}
+			err := s.CreateDelivery(&delivery)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(delivery.ID != 0).IsTrue()
+
+			getdelivery, err := s.GetDelivery(delivery.ID)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getdelivery.GUID).Equal(delivery.GUID)
+			g.Assert(getdelivery.Number).Equal(42)
+			g.Assert(getdelivery.Result == nil).IsTrue()
+		})
+
+		g.It("Should Update a Delivery with its Result", func() {
+			delivery := model.Delivery{RepoID: 1, JobID: 2, Status: model.JobPending}
+			s.CreateDelivery(&delivery)
+
+			delivery.Status = model.JobSuccess
+			delivery.Result = map[string]interface{}{"approved_by": []string{"octocat"}}
+			err := s.UpdateDelivery(&delivery)
+			g.Assert(err == nil).IsTrue()
+
+			getdelivery, err := s.GetDelivery(delivery.ID)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getdelivery.Status).Equal(model.JobSuccess)
+			result := getdelivery.Result.(map[string]interface{})
+			g.Assert(result["approved_by"]).Equal([]interface{}{"octocat"})
+		})
+
+		g.It("Should Get a Delivery by GUID", func() {
+			s.CreateDelivery(&model.Delivery{RepoID: 1, GUID: "a"})
+			s.CreateDelivery(&model.Delivery{RepoID: 1, GUID: "b"})
+
+			getdelivery, err := s.GetDeliveryGUID("b")
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getdelivery.GUID).Equal("b")
+
+			_, err = s.GetDeliveryGUID("c")
+			g.Assert(err != nil).IsTrue()
+		})
+
+		g.It("Should Get a Delivery List", func() {
+			for i := 1; i <= 3; i++ {
+				s.CreateDelivery(&model.Delivery{RepoID: 1, Number: i})
+			}
+			s.CreateDelivery(&model.Delivery{RepoID: 2, Number: 4})
+
+			deliveries, err := s.GetDeliveryList(1, 2, 0)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(deliveries)).Equal(2)
+			g.Assert(deliveries[0].Number).Equal(3)
+			g.Assert(deliveries[1].Number).Equal(2)
+
+			deliveries, _ = s.GetDeliveryList(1, 2, 2)
+			g.Assert(len(deliveries)).Equal(1)
+			g.Assert(deliveries[0].Number).Equal(1)
+		})
+
+		g.It("Should Get the Deliveries of a Job", func() {
+			s.CreateDelivery(&model.Delivery{RepoID: 1, JobID: 1})
+			s.CreateDelivery(&model.Delivery{RepoID: 1, JobID: 2})
+			s.CreateDelivery(&model.Delivery{RepoID: 1, JobID: 2})
+
+			deliveries, err := s.GetDeliveryJob(2)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(deliveries)).Equal(2)
+		})
+	})
+}
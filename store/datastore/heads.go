@@ -0,0 +1,28 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetHead(repo int64, number int, sha string) (*model.Head, error) {
+	var head = new(model.Head)
+	var err = meddler.QueryRow(db, head, rebind(headQuery), repo, number, sha)
+	return head, err
+}
+
+func (db *datastore) CreateHead(head *model.Head) error {
+	return meddler.Insert(db, headTable, head)
+}
+
+const headTable = "heads"
+
+const headQuery = `
+SELECT *
+FROM heads
+WHERE head_repo_id = ?
+  AND head_number = ?
+  AND head_sha = ?
+LIMIT 1
+`
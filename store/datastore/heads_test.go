@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_headstore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Head", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM heads")
+		})
+
+		g.It("Should Add a Head", func() {
+			head := model.Head{
+				RepoID: 1,
+				Number: 42,
+				SHA:    "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Pushed: 1000,
+			}
+			err := s.CreateHead(&head)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(head.ID != 0).IsTrue()
+
+			gethead, err := s.GetHead(1, 42, head.SHA)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(gethead.Pushed).Equal(int64(1000))
+		})
+
+		g.It("Should Enforce Unique Commit per Pull Request", func() {
+			err1 := s.CreateHead(&model.Head{RepoID: 1, Number: 42, SHA: "6dcb09b"})
+			err2 := s.CreateHead(&model.Head{RepoID: 1, Number: 42, SHA: "6dcb09b"})
+			err3 := s.CreateHead(&model.Head{RepoID: 1, Number: 43, SHA: "6dcb09b"})
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsFalse()
+			g.Assert(err3 == nil).IsTrue()
+		})
+	})
+}
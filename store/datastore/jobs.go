@@ -0,0 +1,140 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetJob(id int64) (*model.Job, error) {
+	var job = new(model.Job)
+	var err = meddler.Load(db, jobTable, job, id)
+	return job, err
+}
+
+func (db *datastore) GetJobQueue(now int64, limit int) ([]*model.Job, error) {
+	var jobs = []*model.Job{}
+	var err = meddler.QueryAll(db, &jobs, rebind(jobQueueQuery), now, limit)
+	return jobs, err
+}
+
+func (db *datastore) GetJobPending(repo int64, number int) (*model.Job, error) {
+	var job = new(model.Job)
+	var err = meddler.QueryRow(db, job, rebind(jobPendingQuery), repo, number)
+	return job, err
+}
+
+func (db *datastore) GetJobRetrying(repo int64, number int) ([]*model.Job, error) {
+	var jobs = []*model.Job{}
+	var err = meddler.QueryAll(db, &jobs, rebind(jobRetryingQuery), repo, number)
+	return jobs, err
+}
+
+func (db *datastore) CreateJob(job *model.Job) error {
+	return meddler.Insert(db, jobTable, job)
+}
+
+func (db *datastore) UpdateJob(job *model.Job) error {
+	return meddler.Update(db, jobTable, job)
+}
+
+func (db *datastore) ClaimJob(job *model.Job) (bool, error) {
+	res, err := db.Exec(rebind(jobClaimStmt), job.Updated, job.ID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows == 0 {
+		return false, err
+	}
+	job.Status = model.JobRunning
+	return true, nil
+}
+
+func (db *datastore) SupersedeJob(job *model.Job) (bool, error) {
+	res, err := db.Exec(rebind(jobSupersedeStmt), job.Error, job.Updated, job.ID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows == 0 {
+		return false, err
+	}
+	job.Status = model.JobSuperseded
+	return true, nil
+}
+
+func (db *datastore) ResetJobs(before int64) error {
+	var _, err = db.Exec(rebind(jobResetStmt), before)
+	return err
+}
+
+const jobTable = "jobs"
+
+// jobQueueQuery selects the pending jobs that are ready to run. A pull
+// job is held back while an older pull job of the same pull request is
+// unfinished, so that the jobs of a pull request are processed in order,
+// while a failing pull request waiting to be retried does not hold back
+// the other pull requests of the repository. Push and merge jobs are
+// independent of each other, and are never held back.
+const jobQueueQuery = `
+SELECT *
+FROM jobs j
+WHERE j.job_status = 'pending'
+  AND j.job_next_run <= ?
+  AND (j.job_kind <> 'pull' OR NOT EXISTS (
+    SELECT 1
+    FROM jobs k
+    WHERE k.job_repo_id = j.job_repo_id
+      AND k.job_number = j.job_number
+      AND k.job_kind = 'pull'
+      AND k.job_id < j.job_id
+      AND k.job_status IN ('pending', 'running')
+  ))
+ORDER BY j.job_id
+LIMIT ?
+`
+
+const jobPendingQuery = `
+SELECT *
+FROM jobs
+WHERE job_repo_id = ?
+  AND job_number = ?
+  AND job_kind = 'pull'
+  AND job_status = 'pending'
+  AND job_attempts = 0
+ORDER BY job_id DESC
+LIMIT 1
+`
+
+const jobRetryingQuery = `
+SELECT *
+FROM jobs
+WHERE job_repo_id = ?
+  AND job_number = ?
+  AND job_kind = 'pull'
+  AND job_status = 'pending'
+  AND job_attempts > 0
+ORDER BY job_id
+`
+
+const jobClaimStmt = `
+UPDATE jobs
+SET job_status = 'running', job_updated = ?
+WHERE job_id = ?
+  AND job_status = 'pending'
+`
+
+const jobResetStmt = `
+UPDATE jobs
+SET job_status = 'pending'
+WHERE job_status = 'running'
+  AND job_updated < ?
+`
+
+const jobSupersedeStmt = `
+UPDATE jobs
+SET job_status = 'superseded', job_error = ?, job_updated = ?
+WHERE job_id = ?
+  AND job_status = 'pending'
+`
@@ -0,0 +1,185 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_jobstore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Job", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM jobs")
+		})
+
+		g.It("Should Add a Job", func() {
+			job := model.Job{RepoID: 1, Number: 42, Status: model.JobPending}
+			err := s.CreateJob(&job)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(job.ID != 0).IsTrue()
+
+			getjob, err := s.GetJob(job.ID)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getjob.Number).Equal(42)
+		})
+
+		g.It("Should Add a Job with a payload", func() {
+			push := &model.Push{Branch: "master", Files: []string{".lgtm"}}
+			job := model.Job{RepoID: 1, Kind: model.JobPush, Push: push, Status: model.JobPending}
+			err := s.CreateJob(&job)
+			g.Assert(err == nil).IsTrue()
+
+			getjob, err := s.GetJob(job.ID)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getjob.Kind).Equal(model.JobPush)
+			g.Assert(getjob.Push.Branch).Equal("master")
+			g.Assert(getjob.Push.Files).Equal([]string{".lgtm"})
+			g.Assert(getjob.Merge == nil).IsTrue()
+		})
+
+		g.It("Should Get the Job Queue in order", func() {
+			jobs := []*model.Job{
+				{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending},
+				{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending},
+				{RepoID: 2, Kind: model.JobPull, Number: 1, Status: model.JobPending, NextRun: 100},
+				{RepoID: 3, Kind: model.JobPull, Number: 1, Status: model.JobDead},
+				{RepoID: 3, Kind: model.JobPull, Number: 2, Status: model.JobPending},
+			}
+			for _, job := range jobs {
+				s.CreateJob(job)
+			}
+			queue, err := s.GetJobQueue(50, 10)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(queue)).Equal(2)
+			g.Assert(queue[0].ID).Equal(jobs[0].ID)
+			g.Assert(queue[1].ID).Equal(jobs[4].ID)
+
+			// the next job of the pull request is held back while the
+			// previous job is running.
+			claimed, err := s.ClaimJob(jobs[0])
+			g.Assert(err == nil).IsTrue()
+			g.Assert(claimed).IsTrue()
+			queue, _ = s.GetJobQueue(100, 10)
+			g.Assert(len(queue)).Equal(2)
+			g.Assert(queue[0].ID).Equal(jobs[2].ID)
+
+			jobs[0].Status = model.JobSuccess
+			s.UpdateJob(jobs[0])
+			queue, _ = s.GetJobQueue(100, 10)
+			g.Assert(len(queue)).Equal(3)
+			g.Assert(queue[0].ID).Equal(jobs[1].ID)
+		})
+
+		g.It("Should not hold back other pull requests of the repository", func() {
+			retried := model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending, Attempts: 1, NextRun: 100}
+			other := model.Job{RepoID: 1, Kind: model.JobPull, Number: 2, Status: model.JobPending}
+			next := model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending}
+			s.CreateJob(&retried)
+			s.CreateJob(&other)
+			s.CreateJob(&next)
+
+			queue, err := s.GetJobQueue(50, 10)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(queue)).Equal(1)
+			g.Assert(queue[0].ID).Equal(other.ID)
+		})
+
+		g.It("Should not hold back push and merge Jobs", func() {
+			retried := model.Job{RepoID: 1, Kind: model.JobPush, Status: model.JobPending, Attempts: 1, NextRun: 100}
+			running := model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobRunning}
+			push := model.Job{RepoID: 1, Kind: model.JobPush, Status: model.JobPending}
+			merge := model.Job{RepoID: 1, Kind: model.JobMerge, Number: 1, Status: model.JobPending}
+			s.CreateJob(&retried)
+			s.CreateJob(&running)
+			s.CreateJob(&push)
+			s.CreateJob(&merge)
+
+			queue, err := s.GetJobQueue(50, 10)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(queue)).Equal(2)
+			g.Assert(queue[0].ID).Equal(push.ID)
+			g.Assert(queue[1].ID).Equal(merge.ID)
+		})
+
+		g.It("Should Claim a Job once", func() {
+			job := model.Job{RepoID: 1, Number: 1, Status: model.JobPending}
+			s.CreateJob(&job)
+			claimed, _ := s.ClaimJob(&job)
+			g.Assert(claimed).IsTrue()
+			claimed, _ = s.ClaimJob(&job)
+			g.Assert(claimed).IsFalse()
+		})
+
+		g.It("Should Get the pending Job of a pull request", func() {
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobRunning})
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobMerge, Number: 1, Status: model.JobPending})
+			_, err := s.GetJobPending(1, 1)
+			g.Assert(err != nil).IsTrue()
+
+			job := model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending}
+			s.CreateJob(&job)
+			getjob, err := s.GetJobPending(1, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getjob.ID).Equal(job.ID)
+		})
+
+		g.It("Should Get the retrying Jobs of a pull request", func() {
+			retried := model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending, Attempts: 1}
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobDead, Attempts: 5})
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobPull, Number: 1, Status: model.JobPending})
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobPull, Number: 2, Status: model.JobPending, Attempts: 1})
+			s.CreateJob(&model.Job{RepoID: 1, Kind: model.JobMerge, Number: 1, Status: model.JobPending, Attempts: 1})
+			s.CreateJob(&retried)
+			jobs, err := s.GetJobRetrying(1, 1)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(jobs)).Equal(1)
+			g.Assert(jobs[0].ID).Equal(retried.ID)
+		})
+
+		g.It("Should Supersede a pending Job once", func() {
+			job := model.Job{RepoID: 1, Number: 1, Status: model.JobPending, Attempts: 1}
+			s.CreateJob(&job)
+			job.Error = "Superseded by job 2."
+			superseded, err := s.SupersedeJob(&job)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(superseded).IsTrue()
+			g.Assert(job.Status).Equal(model.JobSuperseded)
+			superseded, _ = s.SupersedeJob(&job)
+			g.Assert(superseded).IsFalse()
+
+			getjob, _ := s.GetJob(job.ID)
+			g.Assert(getjob.Status).Equal(model.JobSuperseded)
+			g.Assert(getjob.Error).Equal("Superseded by job 2.")
+
+			// the superseded job no longer holds back the jobs of the
+			// pull request.
+			next := model.Job{RepoID: 1, Number: 1, Status: model.JobPending}
+			s.CreateJob(&next)
+			queue, _ := s.GetJobQueue(50, 10)
+			g.Assert(len(queue)).Equal(1)
+			g.Assert(queue[0].ID).Equal(next.ID)
+		})
+
+		g.It("Should Reset timed out running Jobs", func() {
+			job := model.Job{RepoID: 1, Number: 1, Status: model.JobRunning, Updated: 100}
+			running := model.Job{RepoID: 1, Number: 2, Status: model.JobRunning, Updated: 200}
+			s.CreateJob(&job)
+			s.CreateJob(&running)
+			err := s.ResetJobs(150)
+			g.Assert(err == nil).IsTrue()
+			getjob, _ := s.GetJob(job.ID)
+			g.Assert(getjob.Status).Equal(model.JobPending)
+			getjob, _ = s.GetJob(running.ID)
+			g.Assert(getjob.Status).Equal(model.JobRunning)
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package datastore
+
+func (db *datastore) Lock(name, owner string, now, expires int64) (bool, error) {
+	// an expired lock is taken over, since its owner either crashed or
+	// exceeded the timeout.
+	res, err := db.Exec(rebind(lockUpdateStmt), owner, expires, name, now)
+	if err != nil {
+		return false, err
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows != 0 {
+		return true, nil
+	}
+	_, err = db.Exec(rebind(lockInsertStmt), name, owner, expires)
+	if isUnique(err) {
+		// the unique lock name is held by another owner.
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *datastore) RenewLock(name, owner string, expires int64) (bool, error) {
+	res, err := db.Exec(rebind(lockRenewStmt), expires, name, owner)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	return rows != 0, err
+}
+
+func (db *datastore) Unlock(name, owner string) error {
+	var _, err = db.Exec(rebind(lockDeleteStmt), name, owner)
+	return err
+}
+
+const lockUpdateStmt = `
+UPDATE locks
+SET lock_owner = ?, lock_expires = ?
+WHERE lock_name = ?
+  AND lock_expires < ?
+`
+
+const lockRenewStmt = `
+UPDATE locks
+SET lock_expires = ?
+WHERE lock_name = ?
+  AND lock_owner = ?
+`
+
+const lockInsertStmt = `
+INSERT INTO locks (lock_name, lock_owner, lock_expires)
+VALUES (?, ?, ?)
+`
+
+const lockDeleteStmt = `
+DELETE FROM locks
+WHERE lock_name = ?
+  AND lock_owner = ?
+`
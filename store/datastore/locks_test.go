@@ -0,0 +1,79 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+)
+
+func Test_lockstore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Lock", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM locks")
+		})
+
+		g.It("Should Lock once", func() {
+			ok, err := s.Lock("octocat/hello-world#1", "a", 100, 200)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(ok).IsTrue()
+
+			ok, err = s.Lock("octocat/hello-world#1", "b", 100, 200)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(ok).IsFalse()
+
+			ok, _ = s.Lock("octocat/hello-world#2", "b", 100, 200)
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should Unlock", func() {
+			s.Lock("octocat/hello-world#1", "a", 100, 200)
+			err := s.Unlock("octocat/hello-world#1", "b")
+			g.Assert(err == nil).IsTrue()
+			ok, _ := s.Lock("octocat/hello-world#1", "b", 100, 200)
+			g.Assert(ok).IsFalse()
+
+			s.Unlock("octocat/hello-world#1", "a")
+			ok, _ = s.Lock("octocat/hello-world#1", "b", 100, 200)
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should take over an expired Lock", func() {
+			s.Lock("octocat/hello-world#1", "a", 100, 200)
+			ok, _ := s.Lock("octocat/hello-world#1", "b", 300, 400)
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should Renew a Lock", func() {
+			s.Lock("octocat/hello-world#1", "a", 100, 200)
+			ok, err := s.RenewLock("octocat/hello-world#1", "a", 400)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(ok).IsTrue()
+
+			ok, _ = s.Lock("octocat/hello-world#1", "b", 300, 500)
+			g.Assert(ok).IsFalse()
+		})
+
+		g.It("Should not Renew a Lock of another owner", func() {
+			s.Lock("octocat/hello-world#1", "a", 100, 200)
+			ok, err := s.RenewLock("octocat/hello-world#1", "b", 400)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(ok).IsFalse()
+		})
+
+		g.It("Should return errors other than a held Lock", func() {
+			db.Exec("CREATE TRIGGER tr_lock_fail BEFORE INSERT ON locks BEGIN SELECT RAISE(ABORT, 'failure'); END")
+			defer db.Exec("DROP TRIGGER tr_lock_fail")
+			ok, err := s.Lock("octocat/hello-world#1", "a", 100, 200)
+			g.Assert(err != nil).IsTrue()
+			g.Assert(ok).IsFalse()
+		})
+	})
+}
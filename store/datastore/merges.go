@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"github.com/go-gitea/lgtm/model"
+
+	"github.com/russross/meddler"
+)
+
+func (db *datastore) GetMergeNumber(repo int64, number int) (*model.Merge, error) {
+	var merge = new(model.Merge)
+	var err = meddler.QueryRow(db, merge, rebind(mergeNumberQuery), repo, number)
+	return merge, err
+}
+
+func (db *datastore) GetMergeList(repo int64, since, until int64) ([]*model.Merge, error) {
+	var merges = []*model.Merge{}
+	var err = meddler.QueryAll(db, &merges, rebind(mergeListQuery), repo, since, until)
+	return merges, err
+}
+
+func (db *datastore) CreateMerge(merge *model.Merge) error {
+	return meddler.Insert(db, mergeTable, merge)
+}
+
+const mergeTable = "merges"
+
+const mergeNumberQuery = `
+SELECT *
+FROM merges
+WHERE merge_repo_id = ?
+  AND merge_number = ?
+LIMIT 1
+`
+
+const mergeListQuery = `
+SELECT *
+FROM merges
+WHERE merge_repo_id = ?
+  AND merge_merged >= ?
+  AND merge_merged < ?
+ORDER BY merge_merged, merge_id
+`
@@ -0,0 +1,70 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/go-gitea/lgtm/model"
+)
+
+func Test_mergestore(t *testing.T) {
+	db := openTest()
+	defer db.Close()
+
+	s := From(db)
+	g := goblin.Goblin(t)
+	g.Describe("Merge", func() {
+
+		// before each test be sure to purge the package
+		// table data from the database.
+		g.BeforeEach(func() {
+			db.Exec("DELETE FROM merges")
+		})
+
+		g.It("Should Add a Merge", func() {
+			merge := model.Merge{
+				RepoID:    1,
+				Number:    42,
+				Base:      "master",
+				SHA:       "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Status:    model.StatusSuccess,
+				Protected: true,
+				Merged:    1000,
+			}
+			err := s.CreateMerge(&merge)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(merge.ID != 0).IsTrue()
+
+			getmerge, err := s.GetMergeNumber(1, 42)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(getmerge.SHA).Equal(merge.SHA)
+			g.Assert(getmerge.Protected).IsTrue()
+		})
+
+		g.It("Should Enforce Unique Pull Request", func() {
+			err1 := s.CreateMerge(&model.Merge{RepoID: 1, Number: 42})
+			err2 := s.CreateMerge(&model.Merge{RepoID: 1, Number: 42})
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsFalse()
+		})
+
+		g.It("Should Get the Merges in a Time Range", func() {
+			merges := []*model.Merge{
+				{RepoID: 1, Number: 1, Merged: 3000},
+				{RepoID: 1, Number: 2, Merged: 1000},
+				{RepoID: 1, Number: 3, Merged: 2000},
+				{RepoID: 1, Number: 4, Merged: 4000},
+				{RepoID: 2, Number: 1, Merged: 2000},
+			}
+			for _, merge := range merges {
+				s.CreateMerge(merge)
+			}
+			list, err := s.GetMergeList(1, 1000, 4000)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(len(list)).Equal(3)
+			g.Assert(list[0].Number).Equal(2)
+			g.Assert(list[1].Number).Equal(3)
+			g.Assert(list[2].Number).Equal(1)
+		})
+	})
+}
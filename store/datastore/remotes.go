@@ -0,0 +1,21 @@
+package datastore
+
+func (db *datastore) UpdateRemote(remote string) error {
+	if _, err := db.Exec(rebind(userRemoteStmt), remote); err != nil {
+		return err
+	}
+	_, err := db.Exec(rebind(repoRemoteStmt), remote)
+	return err
+}
+
+const userRemoteStmt = `
+UPDATE users
+SET user_remote = ?
+WHERE user_remote = '' OR user_remote IS NULL
+`
+
+const repoRemoteStmt = `
+UPDATE repos
+SET repo_remote = ?
+WHERE repo_remote = '' OR repo_remote IS NULL
+`
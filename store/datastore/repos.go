@@ -15,23 +15,23 @@ func (db *datastore) GetRepo(id int64) (*model.Repo, error) {
 	return repo, err
 }
 
-func (db *datastore) GetRepoSlug(slug string) (*model.Repo, error) {
+func (db *datastore) GetRepoSlug(remote, slug string) (*model.Repo, error) {
 	var repo = new(model.Repo)
-	var err = meddler.QueryRow(db, repo, rebind(repoSlugQuery), slug)
+	var err = meddler.QueryRow(db, repo, rebind(repoSlugQuery), remote, slug)
 	return repo, err
 }
 
-func (db *datastore) GetRepoMulti(slug ...string) ([]*model.Repo, error) {
+func (db *datastore) GetRepoMulti(remote string, slug ...string) ([]*model.Repo, error) {
 	var repos = []*model.Repo{}
 	var instr, params = toList(slug)
 	var stmt = fmt.Sprintf(repoListQuery, instr)
-	var err = meddler.QueryAll(db, &repos, rebind(stmt), params...)
+	var err = meddler.QueryAll(db, &repos, rebind(stmt), append([]interface{}{remote}, params...)...)
 	return repos, err
 }
 
-func (db *datastore) GetRepoOwner(owner string) ([]*model.Repo, error) {
+func (db *datastore) GetRepoOwner(remote, owner string) ([]*model.Repo, error) {
 	var repos = []*model.Repo{}
-	var err = meddler.QueryAll(db, &repos, rebind(repoOwnerQuery), owner)
+	var err = meddler.QueryAll(db, &repos, rebind(repoOwnerQuery), remote, owner)
 	return repos, err
 }
 
@@ -68,20 +68,23 @@ const repoTable = "repos"
 const repoSlugQuery = `
 SELECT *
 FROM repos
-WHERE repo_slug = ?
+WHERE repo_remote = ?
+  AND repo_slug = ?
 LIMIT 1;
 `
 
 const repoOwnerQuery = `
 SELECT *
 FROM repos
-WHERE repo_owner = ?
+WHERE repo_remote = ?
+  AND repo_owner = ?
 `
 
 const repoListQuery = `
 SELECT *
 FROM repos
-WHERE repo_slug IN (%s)
+WHERE repo_remote = ?
+  AND repo_slug IN (%s)
 ORDER BY repo_slug
 `
 
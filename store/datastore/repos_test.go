@@ -58,6 +58,7 @@ func Test_repostore(t *testing.T) {
 				Name:    "drone",
 				Link:    "https://github.com/octocat/hello-world",
 				Private: true,
+				Signed:  true,
 			}
 			s.CreateRepo(&repo)
 			getrepo, err := s.GetRepo(repo.ID)
@@ -68,6 +69,7 @@ func Test_repostore(t *testing.T) {
 			g.Assert(repo.Name).Equal(getrepo.Name)
 			g.Assert(repo.Private).Equal(getrepo.Private)
 			g.Assert(repo.Link).Equal(getrepo.Link)
+			g.Assert(repo.Signed).Equal(getrepo.Signed)
 		})
 
 		g.It("Should Get a Repo by Slug", func() {
@@ -78,7 +80,7 @@ func Test_repostore(t *testing.T) {
 				Name:   "drone",
 			}
 			s.CreateRepo(&repo)
-			getrepo, err := s.GetRepoSlug(repo.Slug)
+			getrepo, err := s.GetRepoSlug(repo.Remote, repo.Slug)
 			g.Assert(err == nil).IsTrue()
 			g.Assert(repo.ID).Equal(getrepo.ID)
 			g.Assert(repo.UserID).Equal(getrepo.UserID)
@@ -109,7 +111,7 @@ func Test_repostore(t *testing.T) {
 			s.CreateRepo(repo2)
 			s.CreateRepo(repo3)
 
-			repos, err := s.GetRepoMulti("octocat/fork-knife", "octocat/hello-world")
+			repos, err := s.GetRepoMulti("", "octocat/fork-knife", "octocat/hello-world")
 			g.Assert(err == nil).IsTrue()
 			g.Assert(len(repos)).Equal(2)
 			g.Assert(repos[0].ID).Equal(repo2.ID)
@@ -150,5 +152,55 @@ func Test_repostore(t *testing.T) {
 			g.Assert(err1 == nil).IsTrue()
 			g.Assert(err2 == nil).IsFalse()
 		})
+
+		g.It("Should Get a Repo by Remote and Slug", func() {
+			repo1 := model.Repo{
+				UserID: 1,
+				Slug:   "bradrydzewski/drone",
+				Owner:  "bradrydzewski",
+				Name:   "drone",
+				Remote: "github.com",
+			}
+			repo2 := model.Repo{
+				UserID: 2,
+				Slug:   "bradrydzewski/drone",
+				Owner:  "bradrydzewski",
+				Name:   "drone",
+				Remote: "github.example.com",
+			}
+			err1 := s.CreateRepo(&repo1)
+			err2 := s.CreateRepo(&repo2)
+			getrepo, err3 := s.GetRepoSlug("github.example.com", repo2.Slug)
+			repos, err4 := s.GetRepoOwner("github.com", "bradrydzewski")
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsTrue()
+			g.Assert(err3 == nil).IsTrue()
+			g.Assert(err4 == nil).IsTrue()
+			g.Assert(getrepo.ID).Equal(repo2.ID)
+			g.Assert(len(repos)).Equal(1)
+			g.Assert(repos[0].ID).Equal(repo1.ID)
+		})
+
+		g.It("Should Update the Remote of Repos and Users", func() {
+			repo := model.Repo{
+				UserID: 1,
+				Slug:   "bradrydzewski/drone",
+				Owner:  "bradrydzewski",
+				Name:   "drone",
+			}
+			user := model.User{
+				Login: "bradrydzewski",
+			}
+			s.CreateRepo(&repo)
+			s.CreateUser(&user)
+			err := s.UpdateRemote("github.com")
+			getrepo, err1 := s.GetRepoSlug("github.com", repo.Slug)
+			getuser, err2 := s.GetUserLogin("github.com", user.Login)
+			g.Assert(err == nil).IsTrue()
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsTrue()
+			g.Assert(getrepo.Remote).Equal("github.com")
+			g.Assert(getuser.Remote).Equal("github.com")
+		})
 	})
 }
@@ -74,12 +74,32 @@ func Test_userstore(t *testing.T) {
 				Token: "e42080dddf012c718e476da161d21ad5",
 			}
 			s.CreateUser(&user)
-			getuser, err := s.GetUserLogin(user.Login)
+			getuser, err := s.GetUserLogin(user.Remote, user.Login)
 			g.Assert(err == nil).IsTrue()
 			g.Assert(user.ID).Equal(getuser.ID)
 			g.Assert(user.Login).Equal(getuser.Login)
 		})
 
+		g.It("Should Get a User By Remote and Login", func() {
+			user1 := model.User{
+				Login:  "joe",
+				Remote: "github.com",
+			}
+			user2 := model.User{
+				Login:  "joe",
+				Remote: "github.example.com",
+			}
+			err1 := s.CreateUser(&user1)
+			err2 := s.CreateUser(&user2)
+			getuser, err3 := s.GetUserLogin("github.example.com", "joe")
+			_, err4 := s.GetUserLogin("gitlab.com", "joe")
+			g.Assert(err1 == nil).IsTrue()
+			g.Assert(err2 == nil).IsTrue()
+			g.Assert(err3 == nil).IsTrue()
+			g.Assert(err4 == nil).IsFalse()
+			g.Assert(user2.ID).Equal(getuser.ID)
+		})
+
 		g.It("Should Enforce Unique User Login", func() {
 			user1 := model.User{
 				Login: "joe",
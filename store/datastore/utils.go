@@ -3,6 +3,9 @@ package datastore
 import (
 	"strconv"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
 	"github.com/russross/meddler"
 )
 
@@ -34,3 +37,18 @@ func rebind(query string) string {
 
 	return string(rqb)
 }
+
+// isUnique is a helper function that returns true if the error is the
+// violation of a unique constraint, as reported by the sql drivers.
+func isUnique(err error) bool {
+	switch err := err.(type) {
+	case sqlite3.Error:
+		return err.ExtendedCode == sqlite3.ErrConstraintUnique ||
+			err.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	case *mysql.MySQLError:
+		return err.Number == 1062
+	case *pq.Error:
+		return err.Code == "23505"
+	}
+	return false
+}
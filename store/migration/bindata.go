@@ -1,8 +1,41 @@
 // Code generated by go-bindata.
 // sources:
 // sqlite3/1.sql
+// sqlite3/10.sql
+// sqlite3/11.sql
+// sqlite3/12.sql
+// sqlite3/2.sql
+// sqlite3/3.sql
+// sqlite3/4.sql
+// sqlite3/5.sql
+// sqlite3/6.sql
+// sqlite3/7.sql
+// sqlite3/8.sql
+// sqlite3/9.sql
 // mysql/1.sql
+// mysql/10.sql
+// mysql/11.sql
+// mysql/12.sql
+// mysql/2.sql
+// mysql/3.sql
+// mysql/4.sql
+// mysql/5.sql
+// mysql/6.sql
+// mysql/7.sql
+// mysql/8.sql
+// mysql/9.sql
 // postgres/1.sql
+// postgres/10.sql
+// postgres/11.sql
+// postgres/12.sql
+// postgres/2.sql
+// postgres/3.sql
+// postgres/4.sql
+// postgres/5.sql
+// postgres/6.sql
+// postgres/7.sql
+// postgres/8.sql
+// postgres/9.sql
 // DO NOT EDIT!
 
 package migration
@@ -90,6 +123,226 @@ func sqlite31SQL() (*asset, error) {
 	return a, nil
 }
 
+var _sqlite310SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x94\xc1\x6e\xa3\x30\x10\x86\xef\x7e\x8a\xb9\x35\xd5\x42\x5f\xa0\x27\xda\x38\x2b\xb4\x04\xba\xc4\x48\xe9\x29\xa2\x8d\x95\x5a\x25\x36\xb5\x4d\xbb\xfb\xf6\x6b\xcc\x40\x0c\xdd\x95\xba\x9c\x66\x7e\xcf\x8c\x67\xe6\xb3\x88\x63\xf8\x76\x16\x27\x5d\x5b\x0e\x55\x4b\x48\x1c\x83\x79\x6b\x84\xf3\x8e\x8a\x1b\x90\xca\x82\xe9\xda\x56\x69\x0b\x47\xad\xda\x56\xc8\x13\x3c\x2b\x69\xac\xae\x85\xb4\x26\x02\xa3\xc0\xbe\x70\xb0\xf5\x53\xe3\xe2\x6b\xcd\xfb\x12\x9a\x3f\x75\xa2\xb1\xf0\x21\xec\x0b\x34\xea\x24\xa4\x3b\x92\x47\xa7\xb7\xca\x08\xab\xf4\x6f\x90\xf5\xd9\xc5\x77\x52\xbc\x75\x1c\x5a\xae\xdd\xd9\x59\x59\x7e\x43\xc8\x7d\x49\x13\x46\x81\x25\x77\x19\x85\xce\x70\x6d\x0e\xc3\x19\xac\x88\xf7\x0f\xe2\x08\xfe\x4b\x73\x46\xbf\xd3\x12\x1e\xca\x74\x9b\x94\x8f\xf0\x83\x3e\x42\x52\xb1\x22\xcd\x5d\x8d\x2d\xcd\x19\x89\x7c\xbc\xef\xc0\xc5\x33\xba\x1f\x25\xab\x5e\xf9\x42\xe2\xe7\x5a\x34\x73\xa9\x7e\xaf\x6d\xad\x67\x92\xe1\xcf\x9a\xdb\x99\x84\xdd\x79\x09\xd6\x74\x93\x54\x19\x83\xab\x2b\x42\xa2\x2a\x4f\x7f\x56\x74\x15\x44\x45\x70\xe9\xe8\x9a\x5c\xdf\x12\x92\xe6\x3b\x5a\xb2\x7e\x96\x62\x36\x2d\xd9\xd1\x8c\xde\xb3\x71\xe2\x30\x11\x6d\x3f\x03\xda\xbe\x79\xb4\x87\xae\xd1\x19\xfa\x45\x07\x2b\x6f\xca\x62\x3b\xdc\xe5\xee\x5f\x97\xc5\x43\xb8\xec\x5b\x92\x64\xcc\x2d\xf5\x2f\xfb\x2f\x69\x9e\x6c\x1d\x99\x62\x4a\x9e\xb1\xf2\x74\x03\x56\xbd\x3f\xb1\xfa\x0a\x2c\x9f\x30\x11\xc6\x04\x94\xd5\x87\x74\x8f\x64\xc2\xe3\xb5\xfe\x0d\xc1\x42\x33\x4d\x77\x5a\x6a\x8d\x90\xaf\x4b\xad\xd5\xe2\xbd\x7f\xf4\x70\x57\x14\x19\x4d\xf2\x31\x1d\xf1\x86\xa1\x23\xdf\x7f\x03\x0e\xc2\x22\x98\xda\xf8\xcc\x37\xdc\xd0\xc8\x17\xb7\x84\x79\x13\xed\xcb\xd0\x68\xf7\xc3\x06\xc5\xd1\xec\x47\x43\x13\x27\x1a\x63\x90\x7b\xd0\xda\xc0\xdd\xf7\x30\xe7\x8e\x52\xc8\x7d\xc6\xf2\xc2\x7d\x4c\x46\xee\x69\xbe\xa6\x7b\x48\x37\x90\x17\x0c\xe8\x3e\xdd\xb1\x1d\x88\x5f\x87\x19\xb0\x22\x1f\xb2\x60\x75\x91\xdd\x5e\xbe\x50\x61\x7c\x09\x8b\x0a\x28\xf7\xbb\x8d\x83\xbf\xd7\xda\x55\xfe\xef\xff\xd7\x0d\xf9\x03\x4a\x48\x39\xbf\x01\x05\x00\x00")
+
+func sqlite310SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite310SQL,
+		"sqlite3/10.sql",
+	)
+}
+
+func sqlite310SQL() (*asset, error) {
+	bytes, err := sqlite310SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/10.sql", size: 1281, mode: os.FileMode(420), modTime: time.Unix(1792324931, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite311SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4d\xcc\xb1\x0e\xc2\x20\x14\x46\xe1\x9d\xa7\xf8\x77\x83\x2f\xe0\x44\x85\x4e\xd7\x92\x28\xcc\xc6\x08\x69\x48\x5a\x2e\x05\x1a\x5f\x5f\xd3\xc9\xf1\x7c\xc3\x91\x12\xa7\x35\xcd\xf5\xd5\x23\x7c\x11\x42\x91\x33\x77\x38\x35\x90\x41\x8d\x85\x1b\x94\xd6\xb8\x5a\xf2\xb7\xe9\x80\x67\x4b\x73\x8e\x01\x83\xb5\x64\xd4\x04\x6d\x46\xe5\xc9\x61\x54\xf4\x30\x17\x21\xe4\xdf\x50\xf3\x27\x1f\xd2\xb6\x25\xfd\x3a\x70\x6c\xc8\xdc\xd1\xf6\x52\xb8\x76\x84\xca\xa5\xa4\x3c\xe3\xcd\xcb\xbe\xe6\x76\x16\x5f\x53\xfb\x7e\x33\x90\x00\x00\x00")
+
+func sqlite311SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite311SQL,
+		"sqlite3/11.sql",
+	)
+}
+
+func sqlite311SQL() (*asset, error) {
+	bytes, err := sqlite311SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/11.sql", size: 144, mode: os.FileMode(420), modTime: time.Unix(1792326439, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite312SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x8e\xb1\x0e\xc2\x20\x14\x45\x77\xbe\xe2\x6e\x1d\x4c\xfd\x01\x27\x14\x9c\x50\x13\x43\x13\x37\xa3\x96\x54\x2c\xe5\x3d\xa1\xc4\xdf\x57\x1b\x07\xb7\x8e\xe7\xe6\x9c\xe4\xd6\x35\x16\x83\xef\xd2\x65\x74\x68\x58\x08\x69\xac\x3e\xc2\xca\xb5\xd1\x78\xd0\x35\x43\x2a\x85\xcd\xc1\x34\xbb\xfd\x97\xcf\xbd\x8f\x2d\xac\x3e\x59\x28\xbd\x95\x8d\xb1\xa8\xb8\x84\x50\xad\x66\x4b\x2e\xf9\x3e\x95\xf3\xea\xe0\x52\xe7\x7e\xae\xa8\xff\x1e\x2a\x7a\xc5\x69\xc9\xcf\xe0\x3f\xdc\x92\xcb\x88\x34\x22\x17\x66\x4a\x23\xda\x44\xcc\x3e\x76\xb8\x51\x28\x43\xcc\x4b\xf1\x06\xc8\x0b\x09\x76\xe1\x00\x00\x00")
+
+func sqlite312SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite312SQL,
+		"sqlite3/12.sql",
+	)
+}
+
+func sqlite312SQL() (*asset, error) {
+	bytes, err := sqlite312SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/12.sql", size: 225, mode: os.FileMode(420), modTime: time.Unix(1792326575, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite32SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x91\xcd\x8a\xc2\x30\x14\x85\xf7\x79\x8a\xbb\x74\x98\xe9\x13\xb8\xea\xd8\xab\x84\xd1\x54\x62\x84\xba\x2a\xa9\x0d\x4e\x85\xfe\x90\x1f\xf4\xf1\xa7\xb5\x71\x5a\xab\x60\x36\x81\x8f\x9c\x93\x73\xcf\x0d\x02\xf8\x2c\x8b\x93\x96\x56\xc1\xbe\x21\x64\xc1\x31\x14\x08\x22\xfc\x5e\x23\xd0\x25\xb0\x58\x00\x26\x74\x27\x76\x70\xae\x33\x03\x33\xd2\xdd\x69\x91\x83\x3f\x94\x09\x5c\x21\x87\x2d\xa7\x9b\x90\x1f\xe0\x07\x0f\x10\xee\x45\x4c\x59\x6b\xb5\x41\x26\xc8\x57\x27\xd0\xaa\xa9\x7b\x95\x17\xf4\xb8\x72\x65\xa6\x34\x4c\xb1\x74\xf6\xb7\xbe\x61\x81\x89\x77\xc8\xa4\x51\xfd\x97\x03\x33\x56\x5a\x67\x1e\x99\xb4\x56\x95\x8d\x35\x13\x4b\xa5\x75\xef\x38\x7a\x7a\xd4\xaa\x9d\xfb\x29\x94\x6b\xf2\x57\xb8\x52\x57\x9b\x6a\x57\x0d\xf8\x63\xfe\x5f\x18\x65\x11\x26\x93\xc2\x8a\x6b\x3a\x0e\x19\x33\x5f\xe1\x00\x5b\x83\xf7\xfa\x7b\x75\x0f\x7a\x0f\xbb\x04\xc1\x68\x85\x51\x7d\xa9\x08\x89\x78\xbc\xf5\x2b\xec\x14\x73\xf2\x07\x1a\x66\x62\x92\xe6\x01\x00\x00")
+
+func sqlite32SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite32SQL,
+		"sqlite3/2.sql",
+	)
+}
+
+func sqlite32SQL() (*asset, error) {
+	bytes, err := sqlite32SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/2.sql", size: 486, mode: os.FileMode(420), modTime: time.Unix(1792321673, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite33SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5d\x8e\xcd\x0a\x82\x40\x14\x85\xf7\xf7\x29\xee\x52\x49\x9f\xc0\xd5\x94\xb7\x18\xca\xd1\xc6\x3b\xa0\xab\x10\x1b\x42\xca\x1f\x34\xa8\xc7\x4f\xa6\x92\xe8\xac\x0e\x07\xce\xc7\x17\x86\xb8\x6a\x9b\xcb\x58\xdd\x2d\x9a\x01\x60\xa3\x49\x30\x21\x8b\xf5\x81\x50\x6e\x51\xa5\x8c\x54\xc8\x9c\x73\xbc\xf5\xf5\x75\x42\x0f\x5c\x39\x35\x67\x7c\x47\x2a\xa6\x1d\x69\xcc\xb4\x4c\x84\x2e\x71\x4f\x25\x0a\xc3\xa9\x54\x33\x2b\x21\xc5\x10\xb8\x43\x57\xb5\xd6\x1d\x98\x8a\xef\xd6\x3f\x3a\x3b\xfe\x6d\xf6\x39\x34\xa3\x9d\x16\x30\x40\x60\x94\x3c\x1a\xf2\x16\x8c\x0f\x7e\x04\x10\xfe\xa8\xc7\x33\x09\x20\xd6\x69\xf6\x51\x77\xb2\x11\xbc\x00\x77\xd2\x41\x95\xdf\x00\x00\x00")
+
+func sqlite33SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite33SQL,
+		"sqlite3/3.sql",
+	)
+}
+
+func sqlite33SQL() (*asset, error) {
+	bytes, err := sqlite33SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/3.sql", size: 223, mode: os.FileMode(420), modTime: time.Unix(1792321798, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite34SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x92\x4d\x6e\xc2\x30\x10\x46\xf7\x3e\xc5\x2c\x8b\xda\x9c\x80\x55\x68\xa6\x95\xd5\xe2\x20\x63\xa4\xb0\x42\xa1\x19\x21\x57\x90\x44\x13\x9b\xb6\xb7\x6f\x10\x09\x4d\x83\x91\xf0\xce\x7a\xfa\x9e\x3d\x3f\x51\x04\x8f\x07\xbb\xe3\xdc\x11\xac\x6a\x21\x9e\x35\xc6\x06\xc1\xc4\xb3\x77\x04\xf9\x02\x2a\x35\x80\x99\x5c\x9a\x25\x14\xb4\xb7\x47\x62\x4b\x0d\x3c\x88\xfe\xf6\xb3\xb1\x05\x74\x47\x2a\x83\xaf\xa8\x61\xa1\xe5\x3c\xd6\x6b\x78\xc3\x35\xc4\x2b\x93\x4a\xd5\x5a\xe7\xa8\x8c\x78\xba\xa4\x98\xea\xea\x1c\xed\x52\x03\xf6\x59\x6d\x3b\xeb\x35\xab\x73\xa6\xd2\x85\xd9\xce\xf7\x7f\x31\x98\x0d\x1f\xa3\x63\x97\x19\x83\xd2\x1f\xb6\xc4\x61\x5b\xe3\x72\xe7\x9b\x90\x8d\xb9\xe2\x90\x8d\xa9\xf1\x7b\x17\x00\x1f\x4c\x6d\x7f\xc3\xc5\xfa\xba\x18\xb1\xc9\xf4\x32\x06\xa9\x12\xcc\x46\x63\xb0\xdf\x9b\xab\x2e\xa6\xea\xdf\x74\xc6\xbc\x35\xde\x29\xec\x5b\x7f\x4b\x78\xe6\xf7\xfb\xfa\x91\xdc\xf2\x9d\xf8\xa9\xde\x68\xb0\x86\x49\xf5\x55\x0a\x91\xe8\x74\xd1\xad\xe1\x5f\x72\x2a\x7e\x01\xb2\x84\x03\x4b\xb0\x02\x00\x00")
+
+func sqlite34SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite34SQL,
+		"sqlite3/4.sql",
+	)
+}
+
+func sqlite34SQL() (*asset, error) {
+	bytes, err := sqlite34SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/4.sql", size: 688, mode: os.FileMode(420), modTime: time.Unix(1792321986, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite35SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x92\xcd\x0e\x82\x30\x10\x84\xef\x7d\x8a\x3d\x6a\x84\x27\xf0\x84\xb2\x9a\x46\x2d\xa6\xd6\x04\x4e\x04\xb5\x51\x22\x50\x52\xfc\x7b\x7c\xd1\x54\x52\x50\x12\x7b\x6b\xa6\xd3\x99\xfd\xb2\xae\x0b\xa3\x3c\x3d\xea\xe4\x22\x61\x5b\x12\x32\xe5\xe8\x09\x04\xe1\x4d\x96\x08\x74\x06\x2c\x10\x80\x21\xdd\x88\x0d\x24\x65\xa9\xd5\x2d\xc9\x2a\x18\x90\xe6\x12\xa7\x07\xf8\x1c\xca\x04\xce\x91\xc3\x9a\xd3\x95\xc7\x23\x58\x60\x04\xde\x56\x04\x94\xd5\xbf\xae\x90\x09\xe2\x34\x36\x2d\x4b\x65\xbc\xc6\x66\x89\xc5\x35\xdf\x49\x0d\xbf\xc5\xea\x94\x7c\x02\x05\x86\xf6\x9f\x99\x3a\xa6\xc5\x4f\xa5\x52\x57\xbd\x97\xfd\xca\xbb\xc9\x77\x54\x8d\xa5\xb8\xc8\x9e\x92\x5a\xde\xd4\xb9\x2d\x0e\xc7\x0d\x40\xca\x7c\x0c\x3b\x00\xd3\x47\xdc\x9e\xdf\xcc\x19\x30\x9b\x6d\x17\x91\x03\x1d\x2e\x75\xca\x9f\x21\x56\xff\xbe\x10\xf3\xe4\xd5\xdc\xb5\x56\xc1\x57\xf7\x82\x10\x9f\x07\x6b\xb3\x0a\x8d\x77\x4c\x9e\x21\xea\xba\xf3\x33\x02\x00\x00")
+
+func sqlite35SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite35SQL,
+		"sqlite3/5.sql",
+	)
+}
+
+func sqlite35SQL() (*asset, error) {
+	bytes, err := sqlite35SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/5.sql", size: 563, mode: os.FileMode(420), modTime: time.Unix(1792322297, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite36SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x92\xc1\x6e\x83\x30\x0c\x86\xef\x79\x0a\x1f\x37\xad\x3c\x41\x4f\xb4\x78\x53\xb4\x36\x69\x69\x90\xe8\x09\x85\x11\x15\xa4\x51\x50\x08\xda\xf6\xf6\x63\x4b\x40\xa1\x90\x43\x14\xf9\x8f\x3f\xdb\x7f\x12\x04\xf0\x52\x57\x37\x2d\x8d\x82\xa4\x25\x64\x1f\x63\x28\x10\x44\xb8\x3b\x20\xd0\x57\x60\x5c\x00\xa6\xf4\x22\x2e\x50\x2b\x7d\x53\x1d\x3c\x11\x7b\xca\xaa\x02\xc6\x45\x99\xc0\x37\x8c\xe1\x14\xd3\x63\x18\x5f\xe1\x1d\xaf\x10\x26\x82\x53\x36\xf0\x8e\xc8\x04\xd9\xd8\x1c\xad\xda\xc6\x25\xba\x9c\x51\xb9\xf7\x75\xae\x34\xac\x28\xa6\x32\x9f\xca\xd6\x11\x98\x4e\x28\xd9\x9b\xb2\xd1\x8b\x70\x2e\x3b\x77\x79\x16\xee\x4a\x09\x2b\xe1\x52\xc9\xc2\x69\x7e\xf8\x7f\x2f\xb2\xfc\xe7\x01\x62\xa4\xe9\xbb\x05\xa4\xd5\x8d\x51\x1f\x46\x0d\x63\xed\x38\x3f\x60\xc8\xe6\x9c\xd9\x50\xcf\xdb\xc9\xe4\x84\xd1\x73\x32\xb8\xcc\x22\x4c\x1f\xbc\xee\xbf\x33\xcf\x30\xe7\x0d\x67\xd3\x1b\xcc\xdc\xdc\x80\x6f\xe1\x50\xc0\xf1\xd7\xc0\xd5\x08\xf6\x5a\x5b\x82\xad\xf8\xd7\x6a\xe0\xfd\x8f\xa8\xf9\xba\x13\x12\xc5\xfc\xe4\xfe\x87\x4d\xda\x92\x5f\x13\x0a\xbf\xcb\x45\x02\x00\x00")
+
+func sqlite36SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite36SQL,
+		"sqlite3/6.sql",
+	)
+}
+
+func sqlite36SQL() (*asset, error) {
+	bytes, err := sqlite36SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/6.sql", size: 581, mode: os.FileMode(420), modTime: time.Unix(1792322428, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite37SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x91\xc1\x8e\x82\x30\x10\x86\xef\x7d\x8a\x39\xae\x59\x79\x02\x4f\xb8\x8c\x9b\x66\x97\xa2\xb5\x24\x78\x32\x05\x1b\x6c\x90\x42\x5a\xc8\xae\x6f\x2f\x2a\x2a\x42\x4f\xcd\xf4\x9f\x2f\x9d\x6f\x3c\x0f\x3e\x4b\x9d\x5b\xd9\x28\x88\x6b\x42\xbe\x38\xfa\x02\x41\xf8\xcb\x5f\x04\xba\x02\x16\x09\xc0\x84\x6e\xc5\x16\xd2\x73\x2d\x9d\x53\x0e\x3e\x48\x7f\xdf\xeb\x03\xdc\x0f\x65\x02\xbf\x91\xc3\x9a\xd3\xd0\xe7\x3b\xf8\xc1\x1d\xf8\xb1\x88\x28\xeb\x80\x21\x32\x41\xe6\x7d\x8b\x55\x75\x75\xeb\xeb\x5b\x9e\x0f\x85\x36\x77\x9a\xc0\xe4\x15\x37\x6d\x99\x2a\x0b\xd3\x78\x6a\xa5\xc9\x8e\xe3\xb8\x3b\x4a\x98\x42\x4e\x55\xae\xcd\xa4\x5a\x2a\xe7\x64\xae\xc6\x59\x6d\x8a\x29\xc1\x35\xb2\x69\xdd\xb8\x9a\x59\xd5\x79\x1b\xcc\x32\x5b\x3c\x0d\xc6\x8c\x6e\xe2\x4e\x21\x0b\x30\x19\x89\x6c\xff\xf7\x43\x19\xd7\x3f\x47\x6c\xa0\xf7\xdd\xd4\x1c\x5e\xb3\x5d\xf9\xde\x60\x63\x41\xf5\x67\x08\x09\x78\xb4\xee\x37\xf6\x80\x2c\xc8\x05\xba\x3a\xa8\xcb\xd9\x01\x00\x00")
+
+func sqlite37SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite37SQL,
+		"sqlite3/7.sql",
+	)
+}
+
+func sqlite37SQL() (*asset, error) {
+	bytes, err := sqlite37SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/7.sql", size: 473, mode: os.FileMode(420), modTime: time.Unix(1792322556, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite38SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\x31\x0a\x02\x31\x10\x45\xfb\x9c\x62\xba\x2d\x24\x5e\xc0\x2a\x9a\x58\x45\x05\x49\xc0\x4e\xc4\x0d\x4b\x60\x37\x33\xce\x24\x78\x7d\xd7\xad\xdc\xc2\xf2\x3f\xfe\x83\xa7\x35\x6c\xa6\x3c\xf0\xa3\x26\x88\xa4\x94\xf1\xc1\x5d\x21\x98\xbd\x77\xd0\x24\xb1\x80\xb1\x16\x0e\x17\x1f\x4f\xe7\x05\xdc\x39\x4d\x38\x9f\x83\xbb\x05\xb0\xee\x68\xa2\x0f\xd0\x75\xbb\x95\xc9\x89\x70\x65\x7e\xc1\x5f\x53\xe9\x9f\x08\x8b\xef\xb2\x10\x79\x8d\x79\xde\x3d\x26\x81\x82\x15\xa4\x11\x21\x57\xe8\x19\x89\x72\x19\xe0\x89\x63\x9b\x8a\x6c\xd5\x07\x47\x3a\x5c\x71\xc4\x00\x00\x00")
+
+func sqlite38SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite38SQL,
+		"sqlite3/8.sql",
+	)
+}
+
+func sqlite38SQL() (*asset, error) {
+	bytes, err := sqlite38SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/8.sql", size: 196, mode: os.FileMode(420), modTime: time.Unix(1792323696, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sqlite39SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x90\x4d\x0e\x82\x30\x10\x85\xf7\x3d\xc5\x2c\x35\xc2\x09\x58\x55\x19\x4d\xa3\x14\x2c\x25\x81\x95\xc1\xd0\x08\x0b\x7e\x02\x12\x3d\xbe\x58\x5b\x45\xed\x6a\x9a\x99\xf7\xde\x37\xe3\xba\xb0\xaa\xab\x4b\x9f\x5f\x15\x24\x1d\x21\x1b\x81\x54\x22\x48\xba\x3e\x20\xb0\x2d\xf0\x50\x02\xa6\x2c\x96\x31\x94\x2a\x2f\x06\x58\x10\x5d\x9c\xaa\x02\xec\x63\x5c\xe2\x0e\x05\x44\x82\x05\x54\x64\xb0\xc7\x0c\x68\x22\x43\xc6\x27\xb7\x00\xb9\x24\x8e\x96\xf4\xaa\x6b\x8d\xce\x48\x4c\xa3\x19\xeb\xb3\xea\xe1\xbf\x31\x94\xb9\x0d\x91\x98\x5a\x9f\x6e\x1c\x4a\x55\x7c\x8d\x2f\xbd\x37\x7a\xc2\xd9\x31\x99\xd8\xb9\x8f\xe9\xcf\x06\xe3\xfd\xf4\x01\x79\x85\xea\x88\x90\xdb\xe5\xe6\x9c\x0e\xcc\xe0\xcc\x67\x9a\x7e\x46\xb9\xb3\xab\xf9\xed\xad\x21\xc4\x17\x61\x64\xae\xa6\xad\x3c\xf2\x00\x99\xab\xcd\x62\x5a\x01\x00\x00")
+
+func sqlite39SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlite39SQL,
+		"sqlite3/9.sql",
+	)
+}
+
+func sqlite39SQL() (*asset, error) {
+	bytes, err := sqlite39SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sqlite3/9.sql", size: 346, mode: os.FileMode(420), modTime: time.Unix(1792324495, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _mysql1SQL = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x92\x4f\x6f\xc2\x20\x18\xc6\xef\x7c\x8a\xf7\xa8\x99\x26\x9b\x99\x27\x4f\xa8\x6c\x23\x53\x70\x48\x17\x3d\x19\xb2\x91\x86\xd8\x7f\xa1\xd5\xed\xe3\xaf\x25\xb4\xb5\xce\x2e\xeb\x89\xbc\xbf\xfc\xa0\xcf\x03\xe3\x31\xdc\xc5\x26\xb4\xaa\xd0\x10\x64\x08\x2d\x04\xc1\x92\x80\xc4\xf3\x15\x01\xfa\x04\x8c\x4b\x20\x3b\xba\x95\x5b\x38\xe5\xda\xe6\x30\x40\x6e\x71\x30\x9f\xe0\x3e\xca\x24\x79\x26\x02\x36\x82\xae\xb1\xd8\xc3\x2b\xd9\x03\x0e\x24\x3f\x50\x56\xee\xb5\x26\x4c\xa2\x91\x13\xa2\x34\x34\x49\x29\xbc\x63\xb1\x78\xc1\x62\x30\x99\x4e\x87\x1e\x15\xe9\x51\xf7\x20\x1d\x2b\x13\xdd\x46\xea\xac\x0a\x65\x5b\xf4\x70\x3f\x79\xac\x59\xae\x3f\xac\x2e\xae\x34\x34\x0a\x18\x7d\x0b\xc8\xa0\xfd\x9f\x21\x1a\xce\xfe\x0c\x6d\x75\x96\xba\xd0\xd5\xa2\x09\xfd\xaf\xd4\xce\x68\xba\xf2\x86\x1f\xa7\x5f\x89\xb6\xf0\x2b\x97\x63\x89\x8a\x35\xf4\xb0\x3c\x3a\x85\x7d\x2c\x32\xc9\xb1\xc3\x7c\x21\x0e\x66\xd6\x9c\xab\x3b\x86\x39\xe7\x2b\x82\x59\xbd\x9f\xef\xa9\xa7\xa8\xe6\xcc\x4e\x4f\x94\x2d\xc9\x0e\xcc\xf7\xa1\x13\x85\xb3\xba\xac\x76\x5c\x4a\x37\x9d\xba\x95\x2b\xc7\x8f\xab\xa3\x2e\xdf\xe5\xb2\xdc\x0b\xa1\xa5\xe0\x1b\x7f\x45\xce\x99\x5d\x4e\xdc\xdb\x9c\xa1\x9f\x00\x00\x00\xff\xff\xbb\xdd\xcc\xcc\xce\x02\x00\x00")
 
 func mysql1SQLBytes() ([]byte, error) {
@@ -110,6 +363,226 @@ func mysql1SQL() (*asset, error) {
 	return a, nil
 }
 
+var _mysql10SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x90\x41\x0a\x83\x30\x10\x45\xf7\x73\x8a\x59\x5a\x6a\x4e\x90\x95\xad\x59\x08\x12\xad\x28\x74\x27\x5d\x84\x20\xa8\x91\xa8\xb4\xc7\x6f\xd5\xd4\x46\x4b\xdc\xce\x0c\xef\xff\x37\x84\xe0\xb9\xa9\xa4\x7e\x0c\x02\x8b\x0e\x20\x88\x73\x96\x61\x1e\x5c\x62\x86\x63\x2f\x74\x8f\x61\x96\xa4\x18\xf1\x90\xdd\xe7\x41\x59\x2b\x59\xb5\x74\x73\xa8\x45\xa7\x36\x87\xd3\xa0\xec\xeb\x51\x52\x80\x6b\xc6\x82\x9c\x61\xc1\xa3\x5b\xc1\xbe\xa0\x57\x39\xb3\xb4\x68\xd4\x20\x16\x24\x26\xdc\x24\x7a\xd6\xce\xb7\x42\x4f\xd4\x05\x9b\xf3\x0c\x6c\x8a\xc5\x09\xb6\xb4\xf2\xac\x9d\xff\x2b\xf6\x61\x01\xb1\xdc\x43\xf5\x6c\x01\x6c\xd7\x23\x2a\xdd\x5d\xba\x65\x5c\x0f\x58\xa5\xf6\xda\x47\xa6\x6b\xfb\x3f\x3f\xa3\xf4\x06\x66\x97\xc8\xba\xce\x01\x00\x00")
+
+func mysql10SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql10SQL,
+		"mysql/10.sql",
+	)
+}
+
+func mysql10SQL() (*asset, error) {
+	bytes, err := mysql10SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/10.sql", size: 462, mode: os.FileMode(420), modTime: time.Unix(1792324931, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql11SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xc8\x2f\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\x0b\xc4\x17\x67\xa6\xe7\xa5\xa6\x28\x38\xf9\xfb\xfb\xb8\x3a\xfa\x29\xb8\xb8\xba\x39\x86\xfa\x84\x28\xb8\x39\xfa\x04\xbb\x5a\x73\x71\xe9\x22\x19\xe8\x92\x5f\x9e\x87\xcd\x48\x97\x20\xff\x00\x2c\x66\x5a\x73\x01\x00\xcc\x68\xf8\x7a\x8e\x00\x00\x00")
+
+func mysql11SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql11SQL,
+		"mysql/11.sql",
+	)
+}
+
+func mysql11SQL() (*asset, error) {
+	bytes, err := mysql11SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/11.sql", size: 142, mode: os.FileMode(420), modTime: time.Unix(1792326439, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql12SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\xf1\xe3\xb3\x33\xf3\x52\x14\xc2\x1c\x83\x9c\x3d\x1c\x83\x34\x4c\x0d\x34\x15\x5c\x5c\xdd\x1c\x43\x7d\x42\x14\xd4\x0b\x4a\x73\x72\xd4\xad\x09\x1a\x50\x50\x5a\x9c\xa1\xe0\xeb\xea\xe2\x19\xea\xeb\xe4\xe3\xef\x44\x58\x43\x6e\x6a\x51\x7a\x2a\x8a\x0e\x2e\x5d\x24\x47\xbb\xe4\x97\xe7\x61\x71\xb6\x4b\x90\x7f\x00\x86\x29\xd6\x84\xd5\x81\x9c\x47\x84\x32\x50\x30\x58\x73\x01\x00\x73\xf6\x92\xfb\x3d\x01\x00\x00")
+
+func mysql12SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql12SQL,
+		"mysql/12.sql",
+	)
+}
+
+func mysql12SQL() (*asset, error) {
+	bytes, err := mysql12SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/12.sql", size: 317, mode: os.FileMode(420), modTime: time.Unix(1792326575, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql2SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x91\x4b\x6b\xc3\x30\x10\x84\xef\xfa\x15\x7b\x4c\x48\x0d\xa6\xe0\x53\x4e\x6a\xac\xb6\x22\x89\x1c\x14\xa5\x24\x27\x23\x37\xa2\x75\xc1\x0f\xf4\x20\xf9\xf9\xb5\x2b\xa5\x76\x1e\xba\x08\x66\xf8\x76\x96\xd9\x28\x82\x59\x55\x7e\x69\x69\x15\xec\x5a\x84\x16\x9c\x60\x41\x40\xe0\x97\x15\x01\xfa\x0a\x2c\x13\x40\xf6\x74\x2b\xb6\xf0\xd3\x14\x06\x26\xa8\xff\xf3\xf2\x08\xe1\x51\x26\xc8\x1b\xe1\xb0\xe1\x74\x8d\xf9\x01\x96\xe4\x00\x78\x27\xb2\x9c\xb2\x6e\xd6\x9a\x30\x81\x9e\x7a\x42\xab\xb6\xf1\x58\x20\xbc\x5c\xbb\xaa\x50\x1a\x6e\x65\xe9\xec\x77\xf3\x27\x7f\x60\xbe\x78\xc7\x7c\xf2\x9c\x24\x53\xef\x15\xd2\x28\x9f\x7d\xef\x19\x2b\xad\x33\x63\x2f\x89\x83\x25\xad\x55\x55\x6b\xcd\x4d\x92\xd2\xda\x07\x0d\xd3\xe2\xf8\xc2\x7c\x6a\xd5\x15\x73\xb7\xb4\x6b\x8f\x8f\xe4\x5a\x9d\x6d\xae\x5d\x3d\xc8\xd3\xf9\x7f\xa3\x94\xa5\x64\x0f\xe5\x39\x1f\xaf\x99\xb1\xd0\xea\x20\x76\xc8\x23\xe2\x52\xdf\x15\x11\xc4\x3e\x25\x1a\xdd\x31\x6d\x4e\x35\x42\x29\xcf\x36\xe1\x8e\x3d\x31\x47\xbf\x78\x64\x7e\x2b\xeb\x01\x00\x00")
+
+func mysql2SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql2SQL,
+		"mysql/2.sql",
+	)
+}
+
+func mysql2SQL() (*asset, error) {
+	bytes, err := mysql2SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/2.sql", size: 491, mode: os.FileMode(420), modTime: time.Unix(1792321673, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql3SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8f\x4f\x0b\x82\x40\x10\xc5\xef\xf3\x29\xe6\xa8\xa4\x97\xc0\x93\xa7\xcd\xa6\x5a\xca\xd5\xd6\xdd\xc8\x93\x48\x2d\x21\xe5\x1f\x34\xa8\x8f\x9f\x6c\x22\x5d\x7a\xa7\x81\xc7\xfb\xcd\x7b\xbe\x8f\x8b\xba\xba\xf5\xe5\xd3\xa0\xee\x00\x22\x49\x4c\x11\x2a\xb6\x3a\x10\xf2\x0d\x8a\x44\x21\x9d\x79\xa6\x32\x7c\xb4\x97\xfb\x80\x0e\xd8\xa3\xa8\xae\xf8\x15\x17\x8a\xb6\x24\x31\x95\x3c\x66\x32\xc7\x3d\xe5\xc8\xb4\x4a\x0a\x2e\x46\x58\x4c\x42\x81\x67\x13\x4d\x59\x1b\x9b\x38\x31\x19\xed\x98\x74\x96\x41\xe0\x4e\x5e\xfb\x6a\x4c\xff\xc7\x33\xef\xae\xea\xcd\x30\x7f\x02\xf0\xb4\xe0\x47\x4d\xce\x8c\x75\xc1\x0d\x01\xfc\x9f\x2d\xeb\x91\x08\xb0\x96\x49\x3a\x6d\xb1\xed\x43\xf8\x00\x5f\x2d\xc8\xae\xf0\x00\x00\x00")
+
+func mysql3SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql3SQL,
+		"mysql/3.sql",
+	)
+}
+
+func mysql3SQL() (*asset, error) {
+	bytes, err := mysql3SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/3.sql", size: 240, mode: os.FileMode(420), modTime: time.Unix(1792321798, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql4SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x92\x41\x6f\x82\x40\x10\x85\xef\xfb\x2b\xe6\xa8\x69\x49\x88\x09\x27\x4f\x8b\x6c\xeb\xa6\xb2\x98\x75\x69\xf4\x44\xb0\x4c\x0c\x8d\x02\x59\xc0\xb6\xff\xbe\x58\x01\x41\xa1\x7b\xdb\x7c\xf3\xde\xec\xce\x1b\xc3\x80\xa7\x53\x7c\xd0\x61\x81\xe0\x67\x84\x2c\x24\xa3\x8a\x81\xa2\xf6\x8a\x01\x7f\x01\xe1\x29\x60\x5b\xbe\x51\x1b\x88\xf0\x18\x9f\x51\xc7\x98\xc3\x84\x34\xb7\x9f\x20\x8e\xa0\x3e\x5c\x28\xf6\xca\x24\xac\x25\x77\xa9\xdc\xc1\x1b\xdb\x01\xf5\x95\x17\x70\x51\xd9\xba\x4c\x28\xf2\xdc\xca\x34\x66\xe9\x55\x5b\xcb\x3a\xec\x33\xdd\xd7\xb6\x8f\x2c\x0b\x35\x26\xc5\x30\x3b\x94\xcd\x63\xde\xa9\x5c\x2c\xa9\x9c\xcc\x2c\x6b\xda\x29\xc0\x73\xad\x6d\x0b\x2c\xb3\xcb\x93\xf2\xb4\x47\x3d\x6c\x9e\x17\x61\x51\xe6\xe3\x5a\xd4\x3a\xd5\xfd\xe6\xa6\xd9\xab\xd0\x98\x97\xc7\xbf\xf6\x2e\x73\xb8\xef\xda\x2b\xcf\xee\xe0\x0f\x8d\x55\x0a\xc3\x13\x29\xb3\xe8\x8e\x4d\xe7\x6d\x58\x5c\x38\x6c\x0b\xf1\x77\xf0\x30\x5c\x4f\xf4\x52\xbb\xe7\x95\xc7\xa8\x45\x93\xc1\x98\xc5\x95\xff\xe7\xd0\xa4\x31\xe6\x70\xe1\x97\x5f\x18\x9d\x15\x74\xd2\xaf\x84\x10\x47\x7a\xeb\x7a\x05\x6f\xca\x39\xf9\x05\x27\x96\xd5\x21\xac\x02\x00\x00")
+
+func mysql4SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql4SQL,
+		"mysql/4.sql",
+	)
+}
+
+func mysql4SQL() (*asset, error) {
+	bytes, err := mysql4SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/4.sql", size: 684, mode: os.FileMode(420), modTime: time.Unix(1792321986, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql5SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x92\x51\x6f\x82\x30\x14\x85\xdf\xfb\x2b\xee\xa3\x46\x49\x16\x13\x9e\x78\xaa\x72\xa7\x8d\x52\x4c\xad\x8b\x3e\x11\x36\x1b\x25\x02\x25\x45\xdd\x7e\xfe\xd8\x52\x49\xd1\xb1\xbe\x35\xf7\x9c\xd3\xd3\x2f\xd7\xf3\x60\x54\x64\x47\x93\x5e\x14\x6c\x2b\x42\x66\x02\xa9\x44\x90\x74\xba\x42\x60\xaf\xc0\x63\x09\xb8\x63\x1b\xb9\x81\xb4\xaa\x8c\xbe\xa5\x79\x0d\x03\xd2\x5e\x92\xec\x00\xf7\xc3\xb8\xc4\x39\x0a\x58\x0b\x16\x51\xb1\x87\x25\xee\x81\x6e\x65\x9c\x30\xde\xc4\x46\xc8\x25\x19\xb7\x3e\xa3\x2a\x6d\xcd\xd6\xe7\x0c\xcb\x6b\xf1\xae\x0c\xfc\x3d\xac\x4f\xe9\xfd\xc5\x37\x2a\x66\x0b\x2a\x06\x13\xdf\x1f\x3a\x8a\x5c\x1f\xb3\xf2\x5f\x45\xad\xaf\xe6\x43\x75\x14\xfe\xcb\xb3\xe0\xb7\xe0\x94\xcd\x59\xa7\x7a\x43\xab\xbc\xa8\x9e\xea\x46\xdd\xf4\xb9\x3b\x1c\x06\x2d\x57\xc6\x43\xdc\x41\xf6\x95\x74\x39\xd8\xff\xc6\xdc\x85\xfc\x88\x6a\x0c\x0f\x7c\x9a\xdc\xde\x58\xa7\x63\x5f\xac\x95\xfc\xb4\xf3\x9c\x2d\x08\xf5\x67\x49\x48\x28\xe2\xb5\xdd\x82\xd6\x1b\x90\x6f\x86\x8e\x5d\x20\x2e\x02\x00\x00")
+
+func mysql5SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql5SQL,
+		"mysql/5.sql",
+	)
+}
+
+func mysql5SQL() (*asset, error) {
+	bytes, err := mysql5SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/5.sql", size: 558, mode: os.FileMode(420), modTime: time.Unix(1792322297, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql6SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x92\x41\x4f\x83\x30\x18\x86\xef\xfd\x15\xdf\x71\x8b\x23\x21\x26\x9c\x76\xea\x46\xd5\xc6\xad\x9d\x5d\x31\xdb\x89\x14\x69\x06\x89\x0c\x52\x4a\xd4\x7f\x2f\x4a\x41\x88\x68\x0f\xa4\xc9\xc3\xfb\xb4\x79\xbf\x7a\x1e\xdc\x14\xf9\xc5\x28\xab\x21\xaa\x10\xda\x0a\x82\x25\x01\x89\x37\x3b\x02\xf4\x0e\x18\x97\x40\x4e\xf4\x28\x8f\x50\x68\x73\xd1\x35\x2c\x50\xb7\x8b\xf3\x14\xfa\x45\x99\x24\xf7\x44\xc0\x41\xd0\x3d\x16\x67\x78\x24\x67\xc0\x91\xe4\x31\x65\xad\x70\x4f\x98\x44\xab\x2e\x64\x74\x55\xba\xa4\x0b\xf5\xe4\xda\x14\x89\x36\x30\x43\x6c\x6e\x5f\x75\x77\xd0\x33\x16\xdb\x07\x2c\x16\xb7\xbe\xef\x2f\x7b\xae\x1a\x9b\x95\x66\xca\x83\x60\xc0\x89\xaa\x5d\x7a\x16\xd7\x99\x82\x7f\x70\xa6\x55\xea\xfe\x99\xc3\xdf\xdf\x34\x4e\x3e\xfe\x90\x5b\x65\x9b\x7a\x22\x0f\x7e\x2e\x5e\x99\xd2\xea\x17\xab\xdb\x3a\x36\x9c\xef\x08\x66\x53\xed\xa4\x8c\xe5\x7a\x98\x4e\xc4\xe8\x53\xd4\x8e\x87\x85\xe4\x04\xcd\x7b\x3c\xaa\xd6\xb5\xc8\xd9\x30\xae\x49\xef\x2b\x18\x97\xdd\x2a\x9d\xb1\x53\xe5\xbd\x6a\x74\xfc\x6f\x55\x07\xbf\xae\xe3\x8d\x1e\x4f\x58\xbe\x5d\x11\x0a\x05\x3f\xb8\xc7\xd3\x85\xd6\xe8\x13\x9a\x9c\x22\xe7\x62\x02\x00\x00")
+
+func mysql6SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql6SQL,
+		"mysql/6.sql",
+	)
+}
+
+func mysql6SQL() (*asset, error) {
+	bytes, err := mysql6SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/6.sql", size: 610, mode: os.FileMode(420), modTime: time.Unix(1792322428, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql7SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x91\xc1\x6b\xc2\x30\x18\xc5\xef\xf9\x2b\xbe\xa3\x32\x0b\x65\xd0\x93\xa7\xcc\x66\x33\x6c\xa6\x2e\xa6\x43\x4f\x25\xd5\x50\x83\x36\x2d\x4d\xcb\xb6\xff\x7e\x75\x76\x35\x61\x98\xd3\x07\x8f\xf7\xc8\xfb\xbd\x20\x80\x87\x52\x17\x8d\x6c\x15\xa4\x35\x42\x0b\x4e\xb0\x20\x20\xf0\xd3\x1b\x01\xfa\x0c\x2c\x11\x40\xb6\x74\x23\x36\x90\x7f\xd7\xd2\x5a\x65\x61\x82\x86\x3b\xd3\x07\xb8\x3e\xca\x04\x79\x21\x1c\xd6\x9c\xae\x30\xdf\xc1\x2b\xd9\x01\x4e\x45\x92\x51\xd6\x27\xae\x08\x13\x68\x36\x78\x1a\x55\x57\xbf\xc6\xc1\x33\x0a\x27\x6d\xae\x71\x1f\x98\x2f\x96\x98\x4f\xa2\x70\x3a\x8a\xa6\x2b\x73\xd5\xc0\x7f\x57\xde\x48\xb3\x3f\x3a\xae\xc7\x28\xba\xd9\xec\x51\x82\x97\xe9\xa9\xe7\xaa\xd0\xe6\xae\x5a\x2a\x6b\x65\xa1\x1c\x35\x0c\x9d\x1f\x9d\xb5\x39\xf9\xd1\x9e\x6c\x5b\xd9\x76\xf6\x4e\x9b\x7d\xa3\x7a\xe0\x0e\x83\xe9\x7c\x44\x9f\x32\xfa\x9e\xf6\xec\x59\x4c\xb6\xd0\x7d\x65\x2e\xb6\x4b\x9d\x84\x39\x4b\xf8\x4c\x67\x70\xab\x7d\x49\x0c\x9c\x71\xe3\xea\xd3\x20\x14\xf3\x64\x3d\x8c\xfb\x17\x32\x47\x3f\x1e\x77\x1d\x13\x04\x02\x00\x00")
+
+func mysql7SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql7SQL,
+		"mysql/7.sql",
+	)
+}
+
+func mysql7SQL() (*asset, error) {
+	bytes, err := mysql7SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/7.sql", size: 516, mode: os.FileMode(420), modTime: time.Unix(1792322556, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql8SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\x0b\xc4\x17\xa5\xe6\xe6\x03\x15\x87\x39\x06\x39\x7b\x38\x06\x69\x18\x99\x9a\x6a\x2a\xb8\xb8\xba\x39\x86\xfa\x84\x28\xa8\xab\x5b\xa3\x98\x50\x94\x5a\x90\x8f\x62\x02\x48\x80\xa0\x09\x5c\xba\x48\x8e\x72\xc9\x2f\xcf\xe3\xc2\x62\xa8\x4b\x90\x7f\x00\x16\x53\xad\xb1\xf8\x00\x59\x29\x92\x17\xac\xb9\x00\x51\xc2\x06\xa0\xfd\x00\x00\x00")
+
+func mysql8SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql8SQL,
+		"mysql/8.sql",
+	)
+}
+
+func mysql8SQL() (*asset, error) {
+	bytes, err := mysql8SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/8.sql", size: 253, mode: os.FileMode(420), modTime: time.Unix(1792323696, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _mysql9SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x50\xc1\x0e\x82\x30\x0c\xbd\xef\x2b\x7a\xd4\x08\x17\x13\x4e\x9e\x26\x54\x5d\x94\x0d\xe7\x30\x78\x22\x18\x16\xe1\xa0\x12\x90\xe8\xe7\x8b\x73\x28\xc6\x9e\xda\xbc\xbe\xd7\xf7\xea\xba\x30\x39\x97\xa7\x3a\xbb\x69\x88\x2b\x42\x7c\x89\x54\x21\x28\x3a\xdf\x20\xb0\x05\x70\xa1\x00\x13\xb6\x53\x3b\x28\x74\x96\x37\x30\x22\xa6\x49\xcb\x1c\xfa\x62\x5c\xe1\x12\x25\x44\x92\x85\x54\x1e\x60\x8d\x07\xa0\xb1\x12\x29\xe3\x9d\x5c\x88\x5c\x11\xc7\x70\x6a\x5d\x5d\x2d\xd1\x72\x2c\x70\x69\xcf\x47\x5d\xc3\x3f\xd0\x14\x59\x7f\x65\x4f\xa5\xbf\xa2\x72\x34\xf5\xbc\xb1\x45\xab\xb6\x29\x74\xfe\x43\x1b\xcf\x3e\x19\x62\xce\xb6\x71\x17\x82\x07\x98\x40\xfb\x48\xbf\x16\xde\xe7\x8c\xb8\xe0\x7d\xae\xa1\x43\x07\x06\xb6\xec\xd0\x6d\xbf\xc4\xdd\xc1\xc3\x82\xeb\xfd\x42\x48\x20\x45\x64\x1f\x66\xa4\x66\xe4\x09\xbd\xc5\x2b\x8a\x55\x01\x00\x00")
+
+func mysql9SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_mysql9SQL,
+		"mysql/9.sql",
+	)
+}
+
+func mysql9SQL() (*asset, error) {
+	bytes, err := mysql9SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "mysql/9.sql", size: 341, mode: os.FileMode(420), modTime: time.Unix(1792324495, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _postgres1SQL = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x92\xcf\x4f\x83\x30\x1c\xc5\xef\xfd\x2b\xbe\xc7\x2d\x6e\x89\x2e\xee\xc4\xa9\x1b\x55\x1b\xb1\xcc\x02\x66\x3b\x2d\x8d\x36\xa4\x19\xbf\x52\xd8\xf4\xcf\x17\x9a\x02\x63\x82\x9c\x9a\xf7\xf9\xbe\x96\xf7\xda\xe5\x12\xee\x52\x15\x6b\x51\x49\x88\x0a\x84\xb6\x9c\xe0\x90\x40\x88\x37\x1e\x01\xfa\x04\xcc\x0f\x81\xec\x69\x10\x06\x70\x2e\xa5\x2e\x61\x86\xcc\xe2\xa8\xbe\xc0\x7c\x01\xe1\x14\x7b\xb0\xe3\xf4\x0d\xf3\x03\xbc\x92\x03\x5a\x98\x81\x24\x8f\x55\x56\x0f\x7c\x60\xbe\x7d\xc1\x7c\xb6\x5a\xaf\xe7\x16\x55\xf9\x49\x4e\x20\x99\x0a\x95\x8c\x23\x71\x11\x95\xd0\x3d\x7a\xb8\x5f\x3d\xb6\xac\x94\x9f\x5a\x56\x37\x36\xb4\x88\x18\x7d\x8f\xc8\xac\xff\x9f\x39\x9a\x3b\xff\x86\xd4\xb2\xc8\x4d\xc8\x66\xd1\x85\x1c\x4d\x69\x26\xba\x2e\x28\x0b\xc9\x33\xe1\x56\xce\xbf\x33\xa9\xe1\x4f\x0e\xc3\x32\x91\x4a\x98\x60\x65\x72\x8e\xa7\x58\xa2\xb2\xd3\x80\xd9\x02\x0c\x2c\xb4\xba\x34\x77\x08\x1b\xdf\xf7\x08\x66\xed\x7e\xb6\x97\x89\x62\xba\x33\x07\xbd\x50\xe6\x92\x3d\xa8\x9f\xe3\x20\x8a\xcf\xda\x72\x7a\xb9\x36\x8d\x7a\xda\x56\x6e\x3c\x56\x6e\x8e\xba\x7e\x77\x6e\xbd\x17\x42\x2e\xf7\x77\xf6\x4a\x8c\xc7\xb9\x56\xcc\xdb\x73\xd0\x6f\x00\x00\x00\xff\xff\x05\x71\xe8\xdb\xae\x02\x00\x00")
 
 func postgres1SQLBytes() ([]byte, error) {
@@ -130,6 +603,226 @@ func postgres1SQL() (*asset, error) {
 	return a, nil
 }
 
+var _postgres10SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x90\xcd\x0e\x82\x30\x10\x84\xef\x7d\x8a\x3d\x62\x84\x27\xe0\x54\x69\x0f\x24\xa4\x68\x2d\x89\xb7\xc6\x43\x43\x88\x40\x09\x3f\x51\xdf\x5e\x4b\x11\xf9\x91\x78\xd9\xc3\x6e\xe6\xdb\x99\xf1\x3c\xd8\x17\x59\x5a\x5f\x5b\x05\x49\x85\x10\x8e\x04\xe5\x20\xf0\x21\xa2\xd0\x35\xaa\x6e\x80\xf0\xf8\x08\x41\xcc\xce\x82\xe3\x90\x09\xbb\x95\x66\xca\x5c\xa7\x59\x29\x6f\xea\xe9\xcf\x74\xb5\xaa\xf4\x5a\xd7\x6f\xa5\x99\xb2\xc9\xbb\xd4\xca\x50\xc0\x29\x16\x14\x12\x16\x9e\x12\x0a\x21\x23\xf4\x02\xdd\xc3\xe2\x6b\x55\xe8\x56\xd9\x2f\x10\xb3\xc1\x8f\x33\xb9\xb9\xf0\xf5\xb1\xf3\xb7\x60\xfd\xcf\x01\x66\x5e\x83\x81\x59\x93\xce\xe4\xe6\xc2\x68\xee\xcd\x42\xde\xa4\x19\xa2\xef\x25\x42\x7d\xa2\x4d\xaa\xbf\xb8\xaf\x22\xf8\xbf\xda\xc5\x84\xfc\x2b\xf7\x93\xc7\x99\x65\x5d\x17\xbe\x40\xfd\xe8\x7b\x24\x4d\x83\xbe\x00\x32\x23\x14\xab\x02\x02\x00\x00")
+
+func postgres10SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres10SQL,
+		"postgres/10.sql",
+	)
+}
+
+func postgres10SQL() (*asset, error) {
+	bytes, err := postgres10SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/10.sql", size: 514, mode: os.FileMode(420), modTime: time.Unix(1792324931, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres11SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xc8\x2f\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\x0b\xc4\x17\x67\xa6\xe7\xa5\xa6\x28\x38\xf9\xfb\xfb\xb8\x3a\xfa\x29\xb8\xb8\xba\x39\x86\xfa\x84\x28\xb8\x39\xfa\x04\xbb\x5a\x73\x71\xe9\x22\x19\xe8\x92\x5f\x9e\x87\xcd\x48\x97\x20\xff\x00\x2c\x66\x5a\x73\x01\x00\xcc\x68\xf8\x7a\x8e\x00\x00\x00")
+
+func postgres11SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres11SQL,
+		"postgres/11.sql",
+	)
+}
+
+func postgres11SQL() (*asset, error) {
+	bytes, err := postgres11SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/11.sql", size: 142, mode: os.FileMode(420), modTime: time.Unix(1792326439, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres12SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\xf1\xe3\xb3\x33\xf3\x52\x14\xc2\x1c\x83\x9c\x3d\x1c\x83\x34\x4c\x0d\x34\x15\x5c\x5c\xdd\x1c\x43\x7d\x42\x14\xd4\x0b\x4a\x73\x72\xd4\xad\x09\x1a\x50\x50\x5a\x9c\xa1\xe0\x14\x19\xe2\xea\x48\x58\x6d\x6e\x6a\x51\x7a\x2a\x4c\x31\x97\x2e\x92\x53\x5d\xf2\xcb\xf3\xb0\x38\xd6\x25\xc8\x3f\x00\xc3\x00\x6b\xc2\xea\x40\x8e\x22\x42\x19\xc8\xf3\xd6\x5c\x00\x4d\x70\x08\x5a\x33\x01\x00\x00")
+
+func postgres12SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres12SQL,
+		"postgres/12.sql",
+	)
+}
+
+func postgres12SQL() (*asset, error) {
+	bytes, err := postgres12SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/12.sql", size: 307, mode: os.FileMode(420), modTime: time.Unix(1792326575, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres2SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x91\x4b\x4f\xc3\x30\x10\x84\xef\xfe\x15\x7b\x6c\x05\x91\xa2\x4a\x39\xf5\x64\x9a\x85\x5a\x94\xa4\x72\x0c\x6a\x4f\x91\x43\x2d\x08\x52\x1e\xf2\x43\xf4\xe7\xd3\x60\x97\x84\xb6\xbe\x58\xfa\x66\xc7\xb3\x1a\x47\x11\xdc\x35\xf5\x87\x96\x56\xc1\x6b\x4f\xc8\x8a\x23\x15\x08\x82\x3e\x6c\x10\xd8\x23\x64\xb9\x00\xdc\xb1\x42\x14\xf0\xd5\x55\x06\x66\x64\xb8\xcb\xfa\x00\xe1\x14\xc8\x19\xdd\xc0\x96\xb3\x17\xca\xf7\xf0\x8c\x7b\x72\x3f\x4c\x68\xd5\x77\x7e\x8c\x65\x02\x9f\x90\x7b\xdc\xba\xa6\x52\x1a\x2e\xb1\x74\xf6\xb3\xfb\xc5\x6f\x94\xaf\xd6\x94\xcf\x16\x49\x32\xf7\x5a\x25\x8d\xf2\x59\xd7\x9a\xb1\xd2\x3a\x33\xd5\x92\x38\x48\xd2\x5a\xd5\xf4\xd6\x5c\x24\x29\xad\x7d\xd0\xf8\x5a\x1c\x9f\x3d\xef\x5a\x9d\x8a\xb8\x5a\xda\xf5\x87\x5b\xb8\x55\x47\x5b\x6a\xd7\x8e\x78\xbe\xfc\x6b\x90\x65\x29\xee\xa0\x3e\x96\xd3\x35\xf3\x2c\xb4\x38\xc2\x93\xe5\x96\xe3\x5c\xdf\x3f\x47\x80\x43\x4a\x34\xf9\xb7\xb4\xfb\x6e\x09\x49\x79\xbe\x0d\xff\x36\x38\x96\xe4\x07\x5b\xd4\xac\x4f\xdb\x01\x00\x00")
+
+func postgres2SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres2SQL,
+		"postgres/2.sql",
+	)
+}
+
+func postgres2SQL() (*asset, error) {
+	bytes, err := postgres2SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/2.sql", size: 475, mode: os.FileMode(420), modTime: time.Unix(1792321673, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres3SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\x4d\x0b\x82\x40\x14\x45\xf7\xef\x57\xbc\xa5\x92\x6e\x02\x57\xae\x26\x7d\xd5\x90\xa9\x3d\xc7\xc8\x55\x48\x0d\x31\x94\x1f\x68\x50\x3f\xbf\x98\x24\xda\x74\x57\x17\xee\xe1\x72\x7c\x1f\x67\x8d\xb9\x0c\xf5\x5d\x63\xd9\x03\x44\x4c\x42\x11\x2a\xb1\x48\x08\xe5\x12\xd3\x4c\x21\x1d\x64\xa1\x0a\xbc\x75\xa7\xeb\x88\x0e\xd8\x72\x34\x67\xfc\xa4\x20\x96\x22\xc1\x9c\xe5\x56\x70\x85\x1b\xaa\xc0\xb3\x44\x5b\x37\xda\x12\x7b\xc1\xd1\x5a\xb0\x33\x0f\x02\x77\xda\xba\x47\xab\x87\x3f\x9b\x7e\xf6\x66\xd0\x23\xa2\x4c\x15\xad\x88\x01\xbc\x32\x95\xbb\x92\x9c\xef\xad\x0b\x6e\x08\xe0\xff\xb8\xc7\xef\x47\x80\x98\xb3\x7c\x72\xb7\xb6\x21\xbc\x00\x53\x60\x79\xd7\xe0\x00\x00\x00")
+
+func postgres3SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres3SQL,
+		"postgres/3.sql",
+	)
+}
+
+func postgres3SQL() (*asset, error) {
+	bytes, err := postgres3SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/3.sql", size: 224, mode: os.FileMode(420), modTime: time.Unix(1792321798, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres4SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x92\x41\x6f\x82\x40\x10\x85\xef\xfb\x2b\xe6\xa8\x69\x49\x88\x09\x27\x4f\xab\x6c\xeb\xa6\x16\xcc\xb2\x6d\xe4\x64\xb0\x4c\xcc\x36\x0a\x64\x61\x6d\xfb\xef\xab\x15\x70\x51\xe8\xde\x36\xdf\x9b\x37\x99\x37\xe3\x38\xf0\x70\x50\x3b\x9d\x54\x08\x6f\x05\x21\x73\xc1\xa8\x64\x20\xe9\x6c\xc9\x80\x3f\x41\x10\x4a\x60\x6b\x1e\xc9\x08\x52\xdc\xab\x23\x6a\x85\x25\x8c\x48\xf3\xfb\xd9\xa8\x14\xea\x17\x31\xc1\xe9\x12\x56\x82\xbf\x52\x11\xc3\x0b\x8b\xc9\x63\x2b\xd3\x58\xe4\x17\x2d\x0f\x24\x7b\x66\xc2\x62\x9f\xf9\xb6\xb6\xb9\x67\x45\xa2\x31\xab\xfa\xd9\xce\x34\xcd\xdf\xa9\x98\x2f\xa8\x18\x4d\x3c\x6f\x6c\x09\xf0\x58\xd7\xb6\x02\xcf\xb5\x79\x66\x0e\x5b\xd4\xfd\xe6\x65\x95\x54\xa6\x1c\xae\x45\xad\x73\xdd\x6d\xee\xba\x1d\x85\xc6\xd2\xec\xff\xda\xcf\x62\xc9\xa8\x45\x3e\x34\x9e\x02\xef\x0f\xc3\x14\xe9\x0d\x1b\x4f\xdb\xbd\xf0\xc0\x67\x6b\x50\xdf\x9b\xbb\x5c\xc3\xa0\xb3\xa0\x5b\x7e\xf2\x18\xb4\x68\xe2\x1f\xb2\xb8\xf0\xff\x1c\x9a\x45\x0c\x39\x9c\xf9\x79\x0a\xc7\xba\x36\x3f\xff\xca\x08\xf1\x45\xb8\xaa\xaf\xed\x5a\x39\x25\xbf\xed\x17\xf6\x98\x97\x02\x00\x00")
+
+func postgres4SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres4SQL,
+		"postgres/4.sql",
+	)
+}
+
+func postgres4SQL() (*asset, error) {
+	bytes, err := postgres4SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/4.sql", size: 663, mode: os.FileMode(420), modTime: time.Unix(1792321986, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres5SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x91\xcd\x0e\x82\x30\x10\x84\xef\x7d\x8a\x3d\x6a\x94\xc4\x98\x70\xf2\x54\x61\xd5\x46\x05\x53\xaa\xd1\x13\x41\x6d\x94\xa8\x94\x14\xff\x1e\x5f\x34\x48\x8a\x8a\xbd\x35\xf3\x75\x3a\x3b\x6b\x59\xd0\x3a\xc5\x3b\x1d\x9d\x25\xcc\x53\x42\x1c\x8e\x54\x20\x08\xda\x9f\x20\xb0\x01\x78\xbe\x00\x5c\xb2\x40\x04\x10\xa5\xa9\x56\xd7\xe8\x98\x41\x83\x94\x97\x30\xde\xc2\xfb\x04\xc8\x19\x9d\xc0\x8c\xb3\x29\xe5\x2b\x18\xe3\x8a\xb4\x4b\x4e\xcb\x54\x15\x30\xf3\x04\x0e\x91\x1b\x62\x72\x39\xad\xa5\x86\xdf\x62\xb6\x8f\xde\x3f\x2c\x28\x77\x46\x94\x37\xba\xb6\xdd\x34\x88\xa3\xda\xc5\xc9\x5f\x22\x53\x17\xbd\x91\x15\xc2\xee\x7c\x03\xaf\x80\x7d\x36\xcc\x43\x18\x5a\xde\x4e\x72\x96\x35\xd1\xb5\xbc\xaa\x43\x55\x6c\xf6\xca\x1e\x99\xe7\xe2\x12\xe2\x7b\x58\xed\xa1\x98\xd7\xf7\xcc\x52\x3f\xab\x6a\xc3\x47\x3f\xb9\x6f\xad\xad\x91\xb1\xce\xb6\x40\x9e\xe9\x2c\x63\xeb\xae\xba\x25\x84\xb8\xdc\x9f\x15\x5b\x2f\xdf\xf6\xc8\x03\x81\xf6\x14\x9f\x1e\x02\x00\x00")
+
+func postgres5SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres5SQL,
+		"postgres/5.sql",
+	)
+}
+
+func postgres5SQL() (*asset, error) {
+	bytes, err := postgres5SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/5.sql", size: 542, mode: os.FileMode(420), modTime: time.Unix(1792322297, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres6SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x92\x41\x4f\x83\x30\x14\xc7\xef\xfd\x14\xef\xb8\xc5\x91\x10\x13\x4e\x3b\x75\xa3\x6a\x23\x96\x59\x60\xd9\x4e\xa4\x48\x33\x48\x64\x90\x52\xa2\x7e\x7b\xd1\x16\x84\x88\xf6\xd0\x34\xf9\xbd\xfe\xfa\xf2\x7f\x75\x1c\xb8\xa9\xca\x8b\x12\x5a\x42\xd2\x20\xb4\xe7\x04\xc7\x04\x62\xbc\x0b\x08\xd0\x3b\x60\x61\x0c\xe4\x44\xa3\x38\x82\x4a\xaa\x8b\x6c\x61\x85\xcc\x29\x2d\x73\x18\x56\x44\x38\xc5\x01\x1c\x38\x7d\xc2\xfc\x0c\x8f\xe4\x8c\x36\xa6\x48\xc9\xa6\xb6\x95\x94\xc5\xe4\x9e\xf0\x81\x5c\xbb\x2a\x93\x0a\x16\x88\x2e\xf5\xab\x34\xe2\x23\xe6\xfb\x07\xcc\x57\xb7\xae\xeb\xae\x07\x2e\x3a\x5d\xd4\x6a\xce\x3d\x6f\xc4\x99\x68\xed\xed\x45\xdc\x16\x02\xfe\xc1\x85\x14\xb9\xad\x59\xc2\xdf\x7b\x9e\x66\x1f\x7f\xc8\xb5\xd0\x5d\x3b\x93\x7b\x3f\x8d\x37\xaa\xd6\xf2\x45\xcb\x3e\x8e\x5d\x18\x06\x04\xb3\xb9\x76\x16\xc6\x7a\x3b\x4e\x23\x61\xf4\x39\xe9\xc7\xc1\x7c\x72\x82\xee\x3d\x9d\x44\x6b\x53\x0c\xd9\x38\x9e\x59\xee\x1b\x98\x86\xdd\x2b\xad\xd1\xa8\xca\x41\x35\x79\xfe\xb7\xca\xc0\xaf\x76\x9c\xc9\x67\xf1\xeb\xb7\x2b\x42\x3e\x0f\x0f\xf6\xb3\x98\x4b\x5b\xf4\x09\x78\x8c\x94\xbb\x52\x02\x00\x00")
+
+func postgres6SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres6SQL,
+		"postgres/6.sql",
+	)
+}
+
+func postgres6SQL() (*asset, error) {
+	bytes, err := postgres6SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/6.sql", size: 594, mode: os.FileMode(420), modTime: time.Unix(1792322428, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres7SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x91\x51\x4f\xc2\x30\x14\x85\xdf\xfb\x2b\xee\x23\x44\x96\x2c\x26\x7b\xe2\xa9\xb2\xaa\x8d\xd8\x61\xd7\x19\x78\x22\x1d\x36\xa3\x81\x75\x4b\xbb\x45\xfd\xf7\x82\xcc\xd1\xc6\xd0\xa7\x9b\x9c\x7b\x4e\x7a\xbe\x1b\x45\x70\x57\xeb\xca\xca\x4e\x41\xd1\x22\xb4\xe0\x04\x0b\x02\x02\x3f\x2c\x09\xd0\x47\x60\x99\x00\xb2\xa6\xb9\xc8\xa1\xfc\x6e\xa5\x73\xca\xc1\x04\x0d\xf3\x56\x7f\xc0\xe5\xe5\x84\x53\xbc\x84\x15\xa7\xaf\x98\x6f\xe0\x85\x6c\xd0\x6c\xd8\xb1\xaa\x6d\x7e\x17\x29\x13\xe4\x89\xf0\x51\x38\x68\x73\xb1\xbf\x63\xbe\x78\xc6\x7c\x92\xc4\xd3\x51\x34\x7d\x5d\x2a\x0b\xff\x5d\xa5\x95\x66\xb7\xf7\x5c\xf7\x49\x72\xb5\xb9\xbd\x84\x20\x33\x50\x8f\x4d\xa5\xcd\x4d\xb5\x56\xce\xc9\x4a\x79\x6a\x1c\x7b\x3f\x3a\x6a\x73\x08\xa3\x03\xd9\x75\xb2\xeb\xdd\x8d\x36\x3b\xab\x4e\x80\x3d\x06\xd3\xf9\x88\xba\x60\xf4\xad\x38\xb1\x66\x29\x59\x43\xff\xb5\xf5\xb1\x9d\xeb\x64\xcc\x23\x1f\x32\x9d\xc1\xb5\xf6\x39\x31\xf2\x8e\x99\x36\x9f\x06\xa1\x94\x67\xab\xe1\x98\x7f\x21\x73\xf4\x03\xa8\x0f\xdb\x7f\xf4\x01\x00\x00")
+
+func postgres7SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres7SQL,
+		"postgres/7.sql",
+	)
+}
+
+func postgres7SQL() (*asset, error) {
+	bytes, err := postgres7SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/7.sql", size: 500, mode: os.FileMode(420), modTime: time.Unix(1792322556, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres8SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x03\x0b\xc4\x17\xa5\xe6\xe6\x03\x15\x87\x39\x06\x39\x7b\x38\x06\x69\x18\x99\x9a\x6a\x2a\xb8\xb8\xba\x39\x86\xfa\x84\x28\xa8\xab\x5b\xa3\x98\x50\x94\x5a\x90\x8f\x62\x02\x48\x80\xa0\x09\x5c\xba\x48\x8e\x72\xc9\x2f\xcf\xe3\xc2\x62\xa8\x4b\x90\x7f\x00\x16\x53\xad\xb1\xf8\x00\x59\x29\x92\x17\xac\xb9\x00\x51\xc2\x06\xa0\xfd\x00\x00\x00")
+
+func postgres8SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres8SQL,
+		"postgres/8.sql",
+	)
+}
+
+func postgres8SQL() (*asset, error) {
+	bytes, err := postgres8SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/8.sql", size: 253, mode: os.FileMode(420), modTime: time.Unix(1792323696, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postgres9SQL = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x90\x31\x0f\x82\x30\x10\x85\xf7\xfe\x8a\x1b\x35\xc2\x62\xe2\xc4\x54\xe5\xd4\x46\x2c\x7a\x14\x03\x93\xc1\xd0\x88\x03\x48\x40\xa2\x3f\x5f\xc4\xa2\x18\x6f\xba\xcb\x7b\xf7\xfa\xf5\x6c\x1b\x26\xf9\xe5\x5c\x25\x37\x0d\x61\xc9\xd8\x82\x90\x2b\x04\xc5\xe7\x1e\x82\x58\x82\xf4\x15\x60\x24\x02\x15\x40\xa6\x93\xb4\x86\x11\xeb\x9a\xe3\x25\x85\xbe\x02\x24\xc1\x3d\xd8\x91\xd8\x72\x8a\x61\x83\x31\xb3\x3a\x4f\xa5\xcb\xab\x31\x0a\xa9\x70\x85\x64\x84\xa2\xc9\x4f\xba\x82\x7f\xa1\xce\x92\x3e\xf5\xc0\x69\xb1\xe6\x34\x9a\xce\x66\x63\xa3\x96\x4d\x9d\xe9\xf4\x67\x6d\xec\x7c\x98\x43\x29\xf6\x61\x0b\x2d\x5d\x8c\xa0\x79\x1c\xbf\x08\xef\xe7\xba\x70\x5f\xf6\xff\x18\x12\x5a\x30\xc0\x32\x43\xeb\x7e\x85\xdb\x83\x03\xb9\xd7\x7b\xc1\x98\x4b\xfe\xce\x1c\xa8\x8b\x72\xd8\x13\x77\x2c\x8e\x29\x45\x01\x00\x00")
+
+func postgres9SQLBytes() ([]byte, error) {
+	return bindataRead(
+		_postgres9SQL,
+		"postgres/9.sql",
+	)
+}
+
+func postgres9SQL() (*asset, error) {
+	bytes, err := postgres9SQLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/9.sql", size: 325, mode: os.FileMode(420), modTime: time.Unix(1792324495, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -182,9 +875,42 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"sqlite3/1.sql":  sqlite31SQL,
-	"mysql/1.sql":    mysql1SQL,
-	"postgres/1.sql": postgres1SQL,
+	"sqlite3/1.sql":   sqlite31SQL,
+	"sqlite3/10.sql":  sqlite310SQL,
+	"sqlite3/11.sql":  sqlite311SQL,
+	"sqlite3/12.sql":  sqlite312SQL,
+	"sqlite3/2.sql":   sqlite32SQL,
+	"sqlite3/3.sql":   sqlite33SQL,
+	"sqlite3/4.sql":   sqlite34SQL,
+	"sqlite3/5.sql":   sqlite35SQL,
+	"sqlite3/6.sql":   sqlite36SQL,
+	"sqlite3/7.sql":   sqlite37SQL,
+	"sqlite3/8.sql":   sqlite38SQL,
+	"sqlite3/9.sql":   sqlite39SQL,
+	"mysql/1.sql":     mysql1SQL,
+	"mysql/10.sql":    mysql10SQL,
+	"mysql/11.sql":    mysql11SQL,
+	"mysql/12.sql":    mysql12SQL,
+	"mysql/2.sql":     mysql2SQL,
+	"mysql/3.sql":     mysql3SQL,
+	"mysql/4.sql":     mysql4SQL,
+	"mysql/5.sql":     mysql5SQL,
+	"mysql/6.sql":     mysql6SQL,
+	"mysql/7.sql":     mysql7SQL,
+	"mysql/8.sql":     mysql8SQL,
+	"mysql/9.sql":     mysql9SQL,
+	"postgres/1.sql":  postgres1SQL,
+	"postgres/10.sql": postgres10SQL,
+	"postgres/11.sql": postgres11SQL,
+	"postgres/12.sql": postgres12SQL,
+	"postgres/2.sql":  postgres2SQL,
+	"postgres/3.sql":  postgres3SQL,
+	"postgres/4.sql":  postgres4SQL,
+	"postgres/5.sql":  postgres5SQL,
+	"postgres/6.sql":  postgres6SQL,
+	"postgres/7.sql":  postgres7SQL,
+	"postgres/8.sql":  postgres8SQL,
+	"postgres/9.sql":  postgres9SQL,
 }
 
 // AssetDir returns the file names below a certain
@@ -230,12 +956,45 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"mysql": &bintree{nil, map[string]*bintree{
 		"1.sql": &bintree{mysql1SQL, map[string]*bintree{}},
+		"10.sql": &bintree{mysql10SQL, map[string]*bintree{}},
+		"11.sql": &bintree{mysql11SQL, map[string]*bintree{}},
+		"12.sql": &bintree{mysql12SQL, map[string]*bintree{}},
+		"2.sql": &bintree{mysql2SQL, map[string]*bintree{}},
+		"3.sql": &bintree{mysql3SQL, map[string]*bintree{}},
+		"4.sql": &bintree{mysql4SQL, map[string]*bintree{}},
+		"5.sql": &bintree{mysql5SQL, map[string]*bintree{}},
+		"6.sql": &bintree{mysql6SQL, map[string]*bintree{}},
+		"7.sql": &bintree{mysql7SQL, map[string]*bintree{}},
+		"8.sql": &bintree{mysql8SQL, map[string]*bintree{}},
+		"9.sql": &bintree{mysql9SQL, map[string]*bintree{}},
 	}},
 	"postgres": &bintree{nil, map[string]*bintree{
 		"1.sql": &bintree{postgres1SQL, map[string]*bintree{}},
+		"10.sql": &bintree{postgres10SQL, map[string]*bintree{}},
+		"11.sql": &bintree{postgres11SQL, map[string]*bintree{}},
+		"12.sql": &bintree{postgres12SQL, map[string]*bintree{}},
+		"2.sql": &bintree{postgres2SQL, map[string]*bintree{}},
+		"3.sql": &bintree{postgres3SQL, map[string]*bintree{}},
+		"4.sql": &bintree{postgres4SQL, map[string]*bintree{}},
+		"5.sql": &bintree{postgres5SQL, map[string]*bintree{}},
+		"6.sql": &bintree{postgres6SQL, map[string]*bintree{}},
+		"7.sql": &bintree{postgres7SQL, map[string]*bintree{}},
+		"8.sql": &bintree{postgres8SQL, map[string]*bintree{}},
+		"9.sql": &bintree{postgres9SQL, map[string]*bintree{}},
 	}},
 	"sqlite3": &bintree{nil, map[string]*bintree{
 		"1.sql": &bintree{sqlite31SQL, map[string]*bintree{}},
+		"10.sql": &bintree{sqlite310SQL, map[string]*bintree{}},
+		"11.sql": &bintree{sqlite311SQL, map[string]*bintree{}},
+		"12.sql": &bintree{sqlite312SQL, map[string]*bintree{}},
+		"2.sql": &bintree{sqlite32SQL, map[string]*bintree{}},
+		"3.sql": &bintree{sqlite33SQL, map[string]*bintree{}},
+		"4.sql": &bintree{sqlite34SQL, map[string]*bintree{}},
+		"5.sql": &bintree{sqlite35SQL, map[string]*bintree{}},
+		"6.sql": &bintree{sqlite36SQL, map[string]*bintree{}},
+		"7.sql": &bintree{sqlite37SQL, map[string]*bintree{}},
+		"8.sql": &bintree{sqlite38SQL, map[string]*bintree{}},
+		"9.sql": &bintree{sqlite39SQL, map[string]*bintree{}},
 	}},
 }}
 
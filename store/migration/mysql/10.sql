@@ -0,0 +1,15 @@
+-- +migrate Up
+
+ALTER TABLE users DROP INDEX user_login;
+ALTER TABLE repos DROP INDEX repo_slug;
+
+CREATE UNIQUE INDEX ux_user_remote_login ON users (user_remote, user_login);
+CREATE UNIQUE INDEX ux_repo_remote_slug  ON repos (repo_remote, repo_slug);
+
+-- +migrate Down
+
+DROP INDEX ux_repo_remote_slug  ON repos;
+DROP INDEX ux_user_remote_login ON users;
+
+CREATE UNIQUE INDEX user_login ON users (user_login);
+CREATE UNIQUE INDEX repo_slug  ON repos (repo_slug);
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS jobs (
+ job_id        INTEGER PRIMARY KEY AUTO_INCREMENT
+,job_repo_id   INTEGER
+,job_number    INTEGER
+,job_author    VARCHAR(255)
+,job_base      VARCHAR(255)
+,job_status    VARCHAR(50)
+,job_attempts  INTEGER
+,job_error     VARCHAR(2000)
+,job_created   INTEGER
+,job_updated   INTEGER
+,job_next_run  INTEGER
+);
+
+CREATE INDEX ix_job_status  ON jobs (job_status);
+CREATE INDEX ix_job_repo_id ON jobs (job_repo_id);
+
+-- +migrate Down
+
+DROP TABLE jobs;
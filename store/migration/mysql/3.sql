@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS locks (
+ lock_id       INTEGER PRIMARY KEY AUTO_INCREMENT
+,lock_name     VARCHAR(255)
+,lock_owner    VARCHAR(255)
+,lock_expires  INTEGER
+
+,UNIQUE(lock_name)
+);
+
+-- +migrate Down
+
+DROP TABLE locks;
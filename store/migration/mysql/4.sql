@@ -0,0 +1,24 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS deliveries (
+ delivery_id        INTEGER PRIMARY KEY AUTO_INCREMENT
+,delivery_repo_id   INTEGER
+,delivery_job_id    INTEGER
+,delivery_parent    INTEGER
+,delivery_guid      VARCHAR(255)
+,delivery_event     VARCHAR(50)
+,delivery_number    INTEGER
+,delivery_status    VARCHAR(50)
+,delivery_error     VARCHAR(2000)
+,delivery_result    MEDIUMBLOB
+,delivery_created   INTEGER
+,delivery_updated   INTEGER
+);
+
+CREATE INDEX ix_delivery_repo_id ON deliveries (delivery_repo_id);
+CREATE INDEX ix_delivery_job_id  ON deliveries (delivery_job_id);
+CREATE INDEX ix_delivery_guid    ON deliveries (delivery_guid);
+
+-- +migrate Down
+
+DROP TABLE deliveries;
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS approvals (
+ approval_id         INTEGER PRIMARY KEY AUTO_INCREMENT
+,approval_repo_id    INTEGER
+,approval_number     INTEGER
+,approval_sha        VARCHAR(255)
+,approval_login      VARCHAR(255)
+,approval_source     VARCHAR(50)
+,approval_source_id  BIGINT
+,approval_granted    INTEGER
+,approval_revoked    INTEGER
+);
+
+CREATE INDEX ix_approval_repo_number ON approvals (approval_repo_id, approval_number);
+CREATE INDEX ix_approval_granted     ON approvals (approval_granted);
+
+-- +migrate Down
+
+DROP TABLE approvals;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS merges (
+ merge_id         INTEGER PRIMARY KEY AUTO_INCREMENT
+,merge_repo_id    INTEGER
+,merge_number     INTEGER
+,merge_title      VARCHAR(2000)
+,merge_author     VARCHAR(255)
+,merge_base       VARCHAR(255)
+,merge_sha        VARCHAR(255)
+,merge_head_sha   VARCHAR(255)
+,merge_merged_by  VARCHAR(255)
+,merge_status     VARCHAR(50)
+,merge_protected  BOOLEAN
+,merge_merged     INTEGER
+);
+
+CREATE UNIQUE INDEX ux_merge_repo_number ON merges (merge_repo_id, merge_number);
+CREATE INDEX ix_merge_merged      ON merges (merge_merged);
+
+-- +migrate Down
+
+DROP TABLE merges;
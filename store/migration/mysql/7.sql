@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS bypasses (
+ bypass_id       INTEGER PRIMARY KEY AUTO_INCREMENT
+,bypass_repo_id  INTEGER
+,bypass_kind     VARCHAR(50)
+,bypass_number   INTEGER
+,bypass_branch   VARCHAR(255)
+,bypass_sha      VARCHAR(255)
+,bypass_login    VARCHAR(255)
+,bypass_message  VARCHAR(2000)
+,bypass_link     VARCHAR(2000)
+,bypass_status   VARCHAR(50)
+,bypass_created  INTEGER
+);
+
+CREATE UNIQUE INDEX ux_bypass_repo_sha ON bypasses (bypass_repo_id, bypass_sha);
+
+-- +migrate Down
+
+DROP TABLE bypasses;
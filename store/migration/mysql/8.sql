@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE users ADD COLUMN user_remote VARCHAR(255) DEFAULT '';
+ALTER TABLE repos ADD COLUMN repo_remote VARCHAR(255) DEFAULT '';
+
+-- +migrate Down
+
+ALTER TABLE repos DROP COLUMN repo_remote;
+ALTER TABLE users DROP COLUMN user_remote;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS heads (
+ head_id         INTEGER PRIMARY KEY AUTO_INCREMENT
+,head_repo_id    INTEGER
+,head_number     INTEGER
+,head_sha        VARCHAR(255)
+,head_pushed     INTEGER
+);
+
+CREATE UNIQUE INDEX ux_head_repo_number_sha ON heads (head_repo_id, head_number, head_sha);
+
+-- +migrate Down
+
+DROP TABLE heads;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+ALTER TABLE users DROP CONSTRAINT users_user_login_key;
+ALTER TABLE repos DROP CONSTRAINT repos_repo_slug_key;
+
+CREATE UNIQUE INDEX ux_user_remote_login ON users (user_remote, user_login);
+CREATE UNIQUE INDEX ux_repo_remote_slug  ON repos (repo_remote, repo_slug);
+
+-- +migrate Down
+
+DROP INDEX ux_repo_remote_slug;
+DROP INDEX ux_user_remote_login;
+
+ALTER TABLE users ADD CONSTRAINT users_user_login_key UNIQUE (user_login);
+ALTER TABLE repos ADD CONSTRAINT repos_repo_slug_key UNIQUE (repo_slug);
@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE repos ADD COLUMN repo_signed BOOLEAN DEFAULT FALSE;
+
+-- +migrate Down
+
+ALTER TABLE repos DROP COLUMN repo_signed;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE jobs ADD COLUMN job_kind VARCHAR(50) DEFAULT 'pull';
+ALTER TABLE jobs ADD COLUMN job_push BYTEA;
+ALTER TABLE jobs ADD COLUMN job_merge BYTEA;
+
+-- +migrate Down
+
+ALTER TABLE jobs DROP COLUMN job_merge;
+ALTER TABLE jobs DROP COLUMN job_push;
+ALTER TABLE jobs DROP COLUMN job_kind;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS locks (
+ lock_id       SERIAL PRIMARY KEY
+,lock_name     VARCHAR(255)
+,lock_owner    VARCHAR(255)
+,lock_expires  INTEGER
+
+,UNIQUE(lock_name)
+);
+
+-- +migrate Down
+
+DROP TABLE locks;
@@ -0,0 +1,51 @@
+-- +migrate Up
+
+-- sqlite does not support dropping constraints, so the tables are
+-- rebuilt with logins and repository names unique per remote.
+
+CREATE TABLE users_remote (
+ user_id      INTEGER PRIMARY KEY AUTOINCREMENT
+,user_login   TEXT
+,user_token   TEXT
+,user_email   TEXT
+,user_avatar  TEXT
+,user_secret  TEXT
+,user_remote  TEXT DEFAULT ''
+
+,UNIQUE(user_remote, user_login)
+);
+
+INSERT INTO users_remote
+SELECT user_id, user_login, user_token, user_email, user_avatar, user_secret, user_remote
+FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_remote RENAME TO users;
+
+CREATE TABLE repos_remote (
+ repo_id       INTEGER PRIMARY KEY AUTOINCREMENT
+,repo_user_id  INTEGER
+,repo_owner    TEXT
+,repo_name     TEXT
+,repo_slug     TEXT
+,repo_link     TEXT
+,repo_private  BOOLEAN
+,repo_secret   TEXT
+,repo_remote   TEXT DEFAULT ''
+
+,UNIQUE(repo_remote, repo_slug)
+);
+
+INSERT INTO repos_remote
+SELECT repo_id, repo_user_id, repo_owner, repo_name, repo_slug, repo_link, repo_private, repo_secret, repo_remote
+FROM repos;
+
+DROP TABLE repos;
+ALTER TABLE repos_remote RENAME TO repos;
+
+CREATE INDEX IF NOT EXISTS ix_repo_owner   ON repos (repo_owner);
+CREATE INDEX IF NOT EXISTS ix_repo_user_id ON repos (repo_user_id);
+
+-- +migrate Down
+
+-- sqlite does not support dropping constraints.
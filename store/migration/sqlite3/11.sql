@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE repos ADD COLUMN repo_signed BOOLEAN DEFAULT FALSE;
+
+-- +migrate Down
+
+-- sqlite does not support dropping columns.
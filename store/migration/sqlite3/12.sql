@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE jobs ADD COLUMN job_kind TEXT DEFAULT 'pull';
+ALTER TABLE jobs ADD COLUMN job_push TEXT;
+ALTER TABLE jobs ADD COLUMN job_merge TEXT;
+
+-- +migrate Down
+
+-- sqlite does not support dropping columns.
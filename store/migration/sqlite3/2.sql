@@ -0,0 +1,22 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS jobs (
+ job_id        INTEGER PRIMARY KEY AUTOINCREMENT
+,job_repo_id   INTEGER
+,job_number    INTEGER
+,job_author    TEXT
+,job_base      TEXT
+,job_status    TEXT
+,job_attempts  INTEGER
+,job_error     TEXT
+,job_created   INTEGER
+,job_updated   INTEGER
+,job_next_run  INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS ix_job_status  ON jobs (job_status);
+CREATE INDEX IF NOT EXISTS ix_job_repo_id ON jobs (job_repo_id);
+
+-- +migrate Down
+
+DROP TABLE jobs;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS locks (
+ lock_id       INTEGER PRIMARY KEY AUTOINCREMENT
+,lock_name     TEXT
+,lock_owner    TEXT
+,lock_expires  INTEGER
+
+,UNIQUE(lock_name)
+);
+
+-- +migrate Down
+
+DROP TABLE locks;
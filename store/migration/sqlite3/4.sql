@@ -0,0 +1,24 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS deliveries (
+ delivery_id        INTEGER PRIMARY KEY AUTOINCREMENT
+,delivery_repo_id   INTEGER
+,delivery_job_id    INTEGER
+,delivery_parent    INTEGER
+,delivery_guid      TEXT
+,delivery_event     TEXT
+,delivery_number    INTEGER
+,delivery_status    TEXT
+,delivery_error     TEXT
+,delivery_result    TEXT
+,delivery_created   INTEGER
+,delivery_updated   INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS ix_delivery_repo_id ON deliveries (delivery_repo_id);
+CREATE INDEX IF NOT EXISTS ix_delivery_job_id  ON deliveries (delivery_job_id);
+CREATE INDEX IF NOT EXISTS ix_delivery_guid    ON deliveries (delivery_guid);
+
+-- +migrate Down
+
+DROP TABLE deliveries;
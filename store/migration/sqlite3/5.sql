@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS approvals (
+ approval_id         INTEGER PRIMARY KEY AUTOINCREMENT
+,approval_repo_id    INTEGER
+,approval_number     INTEGER
+,approval_sha        TEXT
+,approval_login      TEXT
+,approval_source     TEXT
+,approval_source_id  INTEGER
+,approval_granted    INTEGER
+,approval_revoked    INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS ix_approval_repo_number ON approvals (approval_repo_id, approval_number);
+CREATE INDEX IF NOT EXISTS ix_approval_granted     ON approvals (approval_granted);
+
+-- +migrate Down
+
+DROP TABLE approvals;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS merges (
+ merge_id         INTEGER PRIMARY KEY AUTOINCREMENT
+,merge_repo_id    INTEGER
+,merge_number     INTEGER
+,merge_title      TEXT
+,merge_author     TEXT
+,merge_base       TEXT
+,merge_sha        TEXT
+,merge_head_sha   TEXT
+,merge_merged_by  TEXT
+,merge_status     TEXT
+,merge_protected  BOOLEAN
+,merge_merged     INTEGER
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_merge_repo_number ON merges (merge_repo_id, merge_number);
+CREATE INDEX IF NOT EXISTS ix_merge_merged      ON merges (merge_merged);
+
+-- +migrate Down
+
+DROP TABLE merges;
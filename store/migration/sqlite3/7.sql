@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS bypasses (
+ bypass_id       INTEGER PRIMARY KEY AUTOINCREMENT
+,bypass_repo_id  INTEGER
+,bypass_kind     TEXT
+,bypass_number   INTEGER
+,bypass_branch   TEXT
+,bypass_sha      TEXT
+,bypass_login    TEXT
+,bypass_message  TEXT
+,bypass_link     TEXT
+,bypass_status   TEXT
+,bypass_created  INTEGER
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_bypass_repo_sha ON bypasses (bypass_repo_id, bypass_sha);
+
+-- +migrate Down
+
+DROP TABLE bypasses;
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+ALTER TABLE users ADD COLUMN user_remote TEXT DEFAULT '';
+ALTER TABLE repos ADD COLUMN repo_remote TEXT DEFAULT '';
+
+-- +migrate Down
+
+-- sqlite does not support dropping columns.
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS heads (
+ head_id         INTEGER PRIMARY KEY AUTOINCREMENT
+,head_repo_id    INTEGER
+,head_number     INTEGER
+,head_sha        TEXT
+,head_pushed     INTEGER
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_head_repo_number_sha ON heads (head_repo_id, head_number, head_sha);
+
+-- +migrate Down
+
+DROP TABLE heads;
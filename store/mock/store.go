@@ -88,13 +88,13 @@ func (_m *Store) GetRepo(_a0 int64) (*model.Repo, error) {
 	return r0, r1
 }
 
-// GetRepoMulti provides a mock function with given fields: _a0
-func (_m *Store) GetRepoMulti(_a0 ...string) ([]*model.Repo, error) {
-	ret := _m.Called(_a0)
+// GetRepoMulti provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetRepoMulti(_a0 string, _a1 ...string) ([]*model.Repo, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 []*model.Repo
-	if rf, ok := ret.Get(0).(func(...string) []*model.Repo); ok {
-		r0 = rf(_a0...)
+	if rf, ok := ret.Get(0).(func(string, ...string) []*model.Repo); ok {
+		r0 = rf(_a0, _a1...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Repo)
@@ -102,8 +102,8 @@ func (_m *Store) GetRepoMulti(_a0 ...string) ([]*model.Repo, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(...string) error); ok {
-		r1 = rf(_a0...)
+	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
+		r1 = rf(_a0, _a1...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,13 +111,13 @@ func (_m *Store) GetRepoMulti(_a0 ...string) ([]*model.Repo, error) {
 	return r0, r1
 }
 
-// GetRepoOwner provides a mock function with given fields: _a0
-func (_m *Store) GetRepoOwner(_a0 string) ([]*model.Repo, error) {
-	ret := _m.Called(_a0)
+// GetRepoOwner provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetRepoOwner(_a0 string, _a1 string) ([]*model.Repo, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 []*model.Repo
-	if rf, ok := ret.Get(0).(func(string) []*model.Repo); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string) []*model.Repo); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Repo)
@@ -125,8 +125,8 @@ func (_m *Store) GetRepoOwner(_a0 string) ([]*model.Repo, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,13 +134,13 @@ func (_m *Store) GetRepoOwner(_a0 string) ([]*model.Repo, error) {
 	return r0, r1
 }
 
-// GetRepoSlug provides a mock function with given fields: _a0
-func (_m *Store) GetRepoSlug(_a0 string) (*model.Repo, error) {
-	ret := _m.Called(_a0)
+// GetRepoSlug provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetRepoSlug(_a0 string, _a1 string) (*model.Repo, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *model.Repo
-	if rf, ok := ret.Get(0).(func(string) *model.Repo); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string) *model.Repo); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Repo)
@@ -148,8 +148,8 @@ func (_m *Store) GetRepoSlug(_a0 string) (*model.Repo, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -180,13 +180,13 @@ func (_m *Store) GetUser(_a0 int64) (*model.User, error) {
 	return r0, r1
 }
 
-// GetUserLogin provides a mock function with given fields: _a0
-func (_m *Store) GetUserLogin(_a0 string) (*model.User, error) {
-	ret := _m.Called(_a0)
+// GetUserLogin provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetUserLogin(_a0 string, _a1 string) (*model.User, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -194,8 +194,8 @@ func (_m *Store) GetUserLogin(_a0 string) (*model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -232,3 +232,600 @@ func (_m *Store) UpdateUser(_a0 *model.User) error {
 }
 
 var _ store.Store = (*Store)(nil)
+
+// GetJob provides a mock function with given fields: _a0
+func (_m *Store) GetJob(_a0 int64) (*model.Job, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *model.Job
+	if rf, ok := ret.Get(0).(func(int64) *model.Job); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobQueue provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetJobQueue(_a0 int64, _a1 int) ([]*model.Job, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*model.Job
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Job); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJob provides a mock function with given fields: _a0
+func (_m *Store) CreateJob(_a0 *model.Job) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Job) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateJob provides a mock function with given fields: _a0
+func (_m *Store) UpdateJob(_a0 *model.Job) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Job) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimJob provides a mock function with given fields: _a0
+func (_m *Store) ClaimJob(_a0 *model.Job) (bool, error) {
+	ret := _m.Called(_a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.Job) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Job) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetJobs provides a mock function with given fields: _a0
+func (_m *Store) ResetJobs(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJobPending provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetJobPending(_a0 int64, _a1 int) (*model.Job, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *model.Job
+	if rf, ok := ret.Get(0).(func(int64, int) *model.Job); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: name, owner, now, expires
+func (_m *Store) Lock(name string, owner string, now int64, expires int64) (bool, error) {
+	ret := _m.Called(name, owner, now, expires)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, int64, int64) bool); ok {
+		r0 = rf(name, owner, now, expires)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64, int64) error); ok {
+		r1 = rf(name, owner, now, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unlock provides a mock function with given fields: name, owner
+func (_m *Store) Unlock(name string, owner string) error {
+	ret := _m.Called(name, owner)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, owner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDelivery provides a mock function with given fields: _a0
+func (_m *Store) GetDelivery(_a0 int64) (*model.Delivery, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *model.Delivery
+	if rf, ok := ret.Get(0).(func(int64) *model.Delivery); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryGUID provides a mock function with given fields: _a0
+func (_m *Store) GetDeliveryGUID(_a0 string) (*model.Delivery, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *model.Delivery
+	if rf, ok := ret.Get(0).(func(string) *model.Delivery); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryList provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) GetDeliveryList(_a0 int64, _a1 int, _a2 int) ([]*model.Delivery, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*model.Delivery
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*model.Delivery); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryJob provides a mock function with given fields: _a0
+func (_m *Store) GetDeliveryJob(_a0 int64) ([]*model.Delivery, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*model.Delivery
+	if rf, ok := ret.Get(0).(func(int64) []*model.Delivery); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDelivery provides a mock function with given fields: _a0
+func (_m *Store) CreateDelivery(_a0 *model.Delivery) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Delivery) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDelivery provides a mock function with given fields: _a0
+func (_m *Store) UpdateDelivery(_a0 *model.Delivery) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Delivery) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetApprovalList provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetApprovalList(_a0 int64, _a1 int) ([]*model.Approval, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*model.Approval
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Approval); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Approval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApprovalActive provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetApprovalActive(_a0 int64, _a1 int) ([]*model.Approval, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*model.Approval
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Approval); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Approval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateApproval provides a mock function with given fields: _a0
+func (_m *Store) CreateApproval(_a0 *model.Approval) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Approval) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateApproval provides a mock function with given fields: _a0
+func (_m *Store) UpdateApproval(_a0 *model.Approval) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Approval) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMergeNumber provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetMergeNumber(_a0 int64, _a1 int) (*model.Merge, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *model.Merge
+	if rf, ok := ret.Get(0).(func(int64, int) *model.Merge); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Merge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMergeList provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) GetMergeList(_a0 int64, _a1 int64, _a2 int64) ([]*model.Merge, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*model.Merge
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) []*model.Merge); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Merge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMerge provides a mock function with given fields: _a0
+func (_m *Store) CreateMerge(_a0 *model.Merge) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Merge) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBypassSHA provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetBypassSHA(_a0 int64, _a1 string) (*model.Bypass, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *model.Bypass
+	if rf, ok := ret.Get(0).(func(int64, string) *model.Bypass); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bypass)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBypassList provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) GetBypassList(_a0 int64, _a1 int, _a2 int) ([]*model.Bypass, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*model.Bypass
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*model.Bypass); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bypass)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBypass provides a mock function with given fields: _a0
+func (_m *Store) CreateBypass(_a0 *model.Bypass) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Bypass) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetHead provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) GetHead(_a0 int64, _a1 int, _a2 string) (*model.Head, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *model.Head
+	if rf, ok := ret.Get(0).(func(int64, int, string) *model.Head); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Head)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateHead provides a mock function with given fields: _a0
+func (_m *Store) CreateHead(_a0 *model.Head) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Head) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRemote provides a mock function with given fields: _a0
+func (_m *Store) UpdateRemote(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewLock provides a mock function with given fields: name, owner, expires
+func (_m *Store) RenewLock(name string, owner string, expires int64) (bool, error) {
+	ret := _m.Called(name, owner, expires)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, int64) bool); ok {
+		r0 = rf(name, owner, expires)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(name, owner, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobRetrying provides a mock function with given fields: _a0, _a1
+func (_m *Store) GetJobRetrying(_a0 int64, _a1 int) ([]*model.Job, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*model.Job
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Job); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SupersedeJob provides a mock function with given fields: _a0
+func (_m *Store) SupersedeJob(_a0 *model.Job) (bool, error) {
+	ret := _m.Called(_a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.Job) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Job) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -16,8 +16,8 @@ type Store interface {
 	// GetUser gets a user by unique ID.
 	GetUser(int64) (*model.User, error)
 
-	// GetUserLogin gets a user by unique Login name.
-	GetUserLogin(string) (*model.User, error)
+	// GetUserLogin gets a user by remote and unique Login name.
+	GetUserLogin(string, string) (*model.User, error)
 
 	// CreateUser creates a new user account.
 	CreateUser(*model.User) error
@@ -31,14 +31,17 @@ type Store interface {
 	// GetRepo gets a repo by unique ID.
 	GetRepo(int64) (*model.Repo, error)
 
-	// GetRepoSlug gets a repo by its full name.
-	GetRepoSlug(string) (*model.Repo, error)
+	// GetRepoSlug gets a repo by remote and full name.
+	GetRepoSlug(string, string) (*model.Repo, error)
 
-	// GetRepoMulti gets a list of multiple repos by their full name.
-	GetRepoMulti(...string) ([]*model.Repo, error)
+	// GetRepoMulti gets a list of multiple repos by remote and full name.
+	GetRepoMulti(string, ...string) ([]*model.Repo, error)
 
-	// GetRepoOwner gets a list by owner.
-	GetRepoOwner(string) ([]*model.Repo, error)
+	// GetRepoOwner gets a list by remote and owner.
+	GetRepoOwner(string, string) ([]*model.Repo, error)
+
+	// UpdateRemote sets the remote of the users and repos without one.
+	UpdateRemote(string) error
 
 	// CreateRepo creates a new repository.
 	CreateRepo(*model.Repo) error
@@ -48,6 +51,107 @@ type Store interface {
 
 	// DeleteRepo deletes a user repository.
 	DeleteRepo(*model.Repo) error
+
+	// GetJob gets a job by unique ID.
+	GetJob(int64) (*model.Job, error)
+
+	// GetJobQueue gets a list of pending jobs that are ready to run at the
+	// specified time, with at most one job per repository.
+	GetJobQueue(int64, int) ([]*model.Job, error)
+
+	// CreateJob creates a new job.
+	CreateJob(*model.Job) error
+
+	// UpdateJob updates a job.
+	UpdateJob(*model.Job) error
+
+	// ClaimJob marks a pending job as running, and returns false if the
+	// job was claimed by another worker.
+	ClaimJob(*model.Job) (bool, error)
+
+	// ResetJobs marks the jobs running since before the time as pending.
+	ResetJobs(int64) error
+
+	// GetJobPending gets the pending job evaluating a pull request.
+	GetJobPending(int64, int) (*model.Job, error)
+
+	// GetJobRetrying gets the failed jobs of a pull request that are
+	// waiting to be retried.
+	GetJobRetrying(int64, int) ([]*model.Job, error)
+
+	// SupersedeJob marks a pending job as superseded, and returns false
+	// if the job is no longer pending.
+	SupersedeJob(*model.Job) (bool, error)
+
+	// Lock acquires the named lock until it expires, and returns false
+	// if the lock is held by another owner.
+	Lock(name, owner string, now, expires int64) (bool, error)
+
+	// RenewLock extends the named lock held by the owner until it expires,
+	// and returns false if the lock is no longer held by the owner.
+	RenewLock(name, owner string, expires int64) (bool, error)
+
+	// Unlock releases the named lock.
+	Unlock(name, owner string) error
+
+	// GetDelivery gets a hook delivery by unique ID.
+	GetDelivery(int64) (*model.Delivery, error)
+
+	// GetDeliveryGUID gets a hook delivery by the unique ID assigned by
+	// the remote system.
+	GetDeliveryGUID(string) (*model.Delivery, error)
+
+	// GetDeliveryList gets a list of the most recent hook deliveries of
+	// a repository.
+	GetDeliveryList(int64, int, int) ([]*model.Delivery, error)
+
+	// GetDeliveryJob gets the hook deliveries processed by a job.
+	GetDeliveryJob(int64) ([]*model.Delivery, error)
+
+	// CreateDelivery creates a new hook delivery.
+	CreateDelivery(*model.Delivery) error
+
+	// UpdateDelivery updates a hook delivery.
+	UpdateDelivery(*model.Delivery) error
+
+	// GetApprovalList gets the approval history of a pull request.
+	GetApprovalList(int64, int) ([]*model.Approval, error)
+
+	// GetApprovalActive gets the approvals of a pull request that are
+	// not revoked.
+	GetApprovalActive(int64, int) ([]*model.Approval, error)
+
+	// CreateApproval creates a new approval.
+	CreateApproval(*model.Approval) error
+
+	// UpdateApproval updates an approval.
+	UpdateApproval(*model.Approval) error
+
+	// GetMergeNumber gets the merge of a pull request.
+	GetMergeNumber(int64, int) (*model.Merge, error)
+
+	// GetMergeList gets the merges of a repository in the time range,
+	// in chronological order.
+	GetMergeList(int64, int64, int64) ([]*model.Merge, error)
+
+	// CreateMerge creates a new merge.
+	CreateMerge(*model.Merge) error
+
+	// GetBypassSHA gets the bypass of a commit.
+	GetBypassSHA(int64, string) (*model.Bypass, error)
+
+	// GetBypassList gets a list of the most recent bypasses of a
+	// repository.
+	GetBypassList(int64, int, int) ([]*model.Bypass, error)
+
+	// CreateBypass creates a new bypass.
+	CreateBypass(*model.Bypass) error
+
+	// GetHead gets the push of the head commit of a pull request.
+	GetHead(int64, int, string) (*model.Head, error)
+
+	// CreateHead creates a new head commit push.
+	CreateHead(*model.Head) error
 }
 
 // GetUser gets a user by unique ID.
@@ -55,9 +159,19 @@ func GetUser(c context.Context, id int64) (*model.User, error) {
 	return FromContext(c).GetUser(id)
 }
 
-// GetUserLogin gets a user by unique Login name.
-func GetUserLogin(c context.Context, login string) (*model.User, error) {
-	return FromContext(c).GetUserLogin(login)
+// GetRepoUser gets the user acting on the repository. Repositories that
+// were activated by installing an app have no user, in which case the
+// remote acts as the app.
+func GetRepoUser(c context.Context, repo *model.Repo) (*model.User, error) {
+	if repo.UserID == 0 {
+		return &model.User{Remote: repo.Remote}, nil
+	}
+	return GetUser(c, repo.UserID)
+}
+
+// GetUserLogin gets a user by remote and unique Login name.
+func GetUserLogin(c context.Context, remote, login string) (*model.User, error) {
+	return FromContext(c).GetUserLogin(remote, login)
 }
 
 // CreateUser creates a new user account.
@@ -80,39 +194,39 @@ func GetRepo(c context.Context, id int64) (*model.Repo, error) {
 	return FromContext(c).GetRepo(id)
 }
 
-// GetRepoSlug gets a repo by its full name.
-func GetRepoSlug(c context.Context, slug string) (*model.Repo, error) {
-	return FromContext(c).GetRepoSlug(slug)
+// GetRepoSlug gets a repo by remote and full name.
+func GetRepoSlug(c context.Context, remote, slug string) (*model.Repo, error) {
+	return FromContext(c).GetRepoSlug(remote, slug)
 }
 
-// GetRepoOwnerName gets a repo by its owner and name.
-func GetRepoOwnerName(c context.Context, owner, name string) (*model.Repo, error) {
-	return GetRepoSlug(c, path.Join(owner, name))
+// GetRepoOwnerName gets a repo by remote, owner and name.
+func GetRepoOwnerName(c context.Context, remote, owner, name string) (*model.Repo, error) {
+	return GetRepoSlug(c, remote, path.Join(owner, name))
 }
 
-// GetRepoMulti gets a list of multiple repos by their full name.
-func GetRepoMulti(c context.Context, slug ...string) ([]*model.Repo, error) {
-	return FromContext(c).GetRepoMulti(slug...)
+// GetRepoMulti gets a list of multiple repos by remote and full name.
+func GetRepoMulti(c context.Context, remote string, slug ...string) ([]*model.Repo, error) {
+	return FromContext(c).GetRepoMulti(remote, slug...)
 }
 
-// GetRepoOwner gets a repo list by account.
-func GetRepoOwner(c context.Context, owner string) ([]*model.Repo, error) {
-	return FromContext(c).GetRepoOwner(owner)
+// GetRepoOwner gets a repo list by remote and account.
+func GetRepoOwner(c context.Context, remote, owner string) ([]*model.Repo, error) {
+	return FromContext(c).GetRepoOwner(remote, owner)
 }
 
-// GetRepoIntersect gets a repo list by account login.
-func GetRepoIntersect(c context.Context, repos []*model.Repo) ([]*model.Repo, error) {
+// GetRepoIntersect gets a repo list of the remote by account login.
+func GetRepoIntersect(c context.Context, remote string, repos []*model.Repo) ([]*model.Repo, error) {
 	slugs := make([]string, len(repos))
 	for i, repo := range repos {
 		slugs[i] = repo.Slug
 	}
-	return GetRepoMulti(c, slugs...)
+	return GetRepoMulti(c, remote, slugs...)
 }
 
-// GetRepoIntersectMap gets a repo set by account login where the key is
-// the repository slug and the value is the repository struct.
-func GetRepoIntersectMap(c context.Context, repos []*model.Repo) (map[string]*model.Repo, error) {
-	repos, err := GetRepoIntersect(c, repos)
+// GetRepoIntersectMap gets a repo set of the remote by account login where
+// the key is the repository slug and the value is the repository struct.
+func GetRepoIntersectMap(c context.Context, remote string, repos []*model.Repo) (map[string]*model.Repo, error) {
+	repos, err := GetRepoIntersect(c, remote, repos)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +237,12 @@ func GetRepoIntersectMap(c context.Context, repos []*model.Repo) (map[string]*mo
 	return set, nil
 }
 
+// UpdateRemote sets the remote of the users and repositories that were
+// activated before several remotes were supported.
+func UpdateRemote(c context.Context, remote string) error {
+	return FromContext(c).UpdateRemote(remote)
+}
+
 // CreateRepo creates a new repository.
 func CreateRepo(c context.Context, repo *model.Repo) error {
 	return FromContext(c).CreateRepo(repo)
@@ -137,3 +257,164 @@ func UpdateRepo(c context.Context, repo *model.Repo) error {
 func DeleteRepo(c context.Context, repo *model.Repo) error {
 	return FromContext(c).DeleteRepo(repo)
 }
+
+// GetJob gets a job by unique ID.
+func GetJob(c context.Context, id int64) (*model.Job, error) {
+	return FromContext(c).GetJob(id)
+}
+
+// GetJobQueue gets a list of pending jobs that are ready to run at the
+// specified time, with at most one job per repository.
+func GetJobQueue(c context.Context, now int64, limit int) ([]*model.Job, error) {
+	return FromContext(c).GetJobQueue(now, limit)
+}
+
+// CreateJob creates a new job.
+func CreateJob(c context.Context, job *model.Job) error {
+	return FromContext(c).CreateJob(job)
+}
+
+// UpdateJob updates a job.
+func UpdateJob(c context.Context, job *model.Job) error {
+	return FromContext(c).UpdateJob(job)
+}
+
+// ClaimJob marks a pending job as running, and returns false if the
+// job was claimed by another worker.
+func ClaimJob(c context.Context, job *model.Job) (bool, error) {
+	return FromContext(c).ClaimJob(job)
+}
+
+// ResetJobs marks the jobs running since before the time as pending.
+func ResetJobs(c context.Context, before int64) error {
+	return FromContext(c).ResetJobs(before)
+}
+
+// GetJobPending gets the pending job evaluating a pull request.
+func GetJobPending(c context.Context, repo int64, number int) (*model.Job, error) {
+	return FromContext(c).GetJobPending(repo, number)
+}
+
+// GetJobRetrying gets the failed jobs of a pull request that are
+// waiting to be retried.
+func GetJobRetrying(c context.Context, repo int64, number int) ([]*model.Job, error) {
+	return FromContext(c).GetJobRetrying(repo, number)
+}
+
+// SupersedeJob marks a pending job as superseded, and returns false
+// if the job is no longer pending.
+func SupersedeJob(c context.Context, job *model.Job) (bool, error) {
+	return FromContext(c).SupersedeJob(job)
+}
+
+// Lock acquires the named lock until it expires, and returns false
+// if the lock is held by another owner.
+func Lock(c context.Context, name, owner string, now, expires int64) (bool, error) {
+	return FromContext(c).Lock(name, owner, now, expires)
+}
+
+// RenewLock extends the named lock held by the owner until it expires,
+// and returns false if the lock is no longer held by the owner.
+func RenewLock(c context.Context, name, owner string, expires int64) (bool, error) {
+	return FromContext(c).RenewLock(name, owner, expires)
+}
+
+// Unlock releases the named lock.
+func Unlock(c context.Context, name, owner string) error {
+	return FromContext(c).Unlock(name, owner)
+}
+
+// GetDelivery gets a hook delivery by unique ID.
+func GetDelivery(c context.Context, id int64) (*model.Delivery, error) {
+	return FromContext(c).GetDelivery(id)
+}
+
+// GetDeliveryGUID gets a hook delivery by the unique ID assigned by
+// the remote system.
+func GetDeliveryGUID(c context.Context, guid string) (*model.Delivery, error) {
+	return FromContext(c).GetDeliveryGUID(guid)
+}
+
+// GetDeliveryList gets a list of the most recent hook deliveries of
+// a repository.
+func GetDeliveryList(c context.Context, repo int64, limit, offset int) ([]*model.Delivery, error) {
+	return FromContext(c).GetDeliveryList(repo, limit, offset)
+}
+
+// GetDeliveryJob gets the hook deliveries processed by a job.
+func GetDeliveryJob(c context.Context, job int64) ([]*model.Delivery, error) {
+	return FromContext(c).GetDeliveryJob(job)
+}
+
+// CreateDelivery creates a new hook delivery.
+func CreateDelivery(c context.Context, delivery *model.Delivery) error {
+	return FromContext(c).CreateDelivery(delivery)
+}
+
+// UpdateDelivery updates a hook delivery.
+func UpdateDelivery(c context.Context, delivery *model.Delivery) error {
+	return FromContext(c).UpdateDelivery(delivery)
+}
+
+// GetApprovalList gets the approval history of a pull request.
+func GetApprovalList(c context.Context, repo int64, number int) ([]*model.Approval, error) {
+	return FromContext(c).GetApprovalList(repo, number)
+}
+
+// GetApprovalActive gets the approvals of a pull request that are
+// not revoked.
+func GetApprovalActive(c context.Context, repo int64, number int) ([]*model.Approval, error) {
+	return FromContext(c).GetApprovalActive(repo, number)
+}
+
+// CreateApproval creates a new approval.
+func CreateApproval(c context.Context, approval *model.Approval) error {
+	return FromContext(c).CreateApproval(approval)
+}
+
+// UpdateApproval updates an approval.
+func UpdateApproval(c context.Context, approval *model.Approval) error {
+	return FromContext(c).UpdateApproval(approval)
+}
+
+// GetMergeNumber gets the merge of a pull request.
+func GetMergeNumber(c context.Context, repo int64, number int) (*model.Merge, error) {
+	return FromContext(c).GetMergeNumber(repo, number)
+}
+
+// GetMergeList gets the merges of a repository in the time range,
+// in chronological order.
+func GetMergeList(c context.Context, repo int64, since, until int64) ([]*model.Merge, error) {
+	return FromContext(c).GetMergeList(repo, since, until)
+}
+
+// CreateMerge creates a new merge.
+func CreateMerge(c context.Context, merge *model.Merge) error {
+	return FromContext(c).CreateMerge(merge)
+}
+
+// GetBypassSHA gets the bypass of a commit.
+func GetBypassSHA(c context.Context, repo int64, sha string) (*model.Bypass, error) {
+	return FromContext(c).GetBypassSHA(repo, sha)
+}
+
+// GetBypassList gets a list of the most recent bypasses of a
+// repository.
+func GetBypassList(c context.Context, repo int64, limit, offset int) ([]*model.Bypass, error) {
+	return FromContext(c).GetBypassList(repo, limit, offset)
+}
+
+// CreateBypass creates a new bypass.
+func CreateBypass(c context.Context, bypass *model.Bypass) error {
+	return FromContext(c).CreateBypass(bypass)
+}
+
+// GetHead gets the push of the head commit of a pull request.
+func GetHead(c context.Context, repo int64, number int, sha string) (*model.Head, error) {
+	return FromContext(c).GetHead(repo, number, sha)
+}
+
+// CreateHead creates a new head commit push.
+func CreateHead(c context.Context, head *model.Head) error {
+	return FromContext(c).CreateHead(head)
+}
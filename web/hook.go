@@ -1,214 +1,197 @@
 package web
 
 import (
-	"errors"
-	"regexp"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
 
-	"github.com/go-gitea/lgtm/cache"
+	"github.com/go-gitea/lgtm/engine"
 	"github.com/go-gitea/lgtm/model"
+	"github.com/go-gitea/lgtm/queue"
 	"github.com/go-gitea/lgtm/remote"
+	"github.com/go-gitea/lgtm/shared/httputil"
+	"github.com/go-gitea/lgtm/shared/token"
 	"github.com/go-gitea/lgtm/store"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
-var (
-	labels = []string{"lgtm/need 2", "lgtm/need 1", "lgtm/done"}
-)
-
-// Hook is the handler for hook pages.
+// Hook is the handler for hook pages. The remote path parameter selects
+// the remote that parses the payload, which defaults to the default
+// remote.
 func Hook(c *gin.Context) {
-	hook, err := remote.GetHook(c, c.Request)
-	if err != nil {
-		log.Errorf("Error parsing hook. %s", err)
-		c.String(500, "Error parsing hook. %s", err)
-		return
-	}
-	if hook == nil {
-		c.String(200, "pong")
-		return
-	}
-
-	repo, err := store.GetRepoSlug(c, hook.Repo.Slug)
-	if err != nil {
-		log.Errorf("Error getting repository %s. %s", hook.Repo.Slug, err)
-		c.String(404, "Repository not found.")
-		return
-	}
-	user, err := store.GetUser(c, repo.UserID)
+	// the raw payload is needed to verify the signature, so we buffer
+	// it before handing the request over to the remote hook parser.
+	payload, err := ioutil.ReadAll(c.Request.Body)
 	if err != nil {
-		log.Errorf("Error getting repository owner %s. %s", repo.Slug, err)
-		c.String(404, "Repository owner not found.")
+		log.Errorf("Error reading hook. %s", err)
+		c.String(500, "Error reading hook. %s", err)
 		return
 	}
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(payload))
 
-	rcfile, _ := remote.GetContents(c, user, repo, ".lgtm")
-	config, err := model.ParseConfig(rcfile)
+	host := remote.Host(c, c.Param("remote"))
+	r, err := remote.FromHost(c, host)
 	if err != nil {
-		log.Errorf("Error parsing .lgtm file for %s. %s", repo.Slug, err)
-		c.String(500, "Error parsing .lgtm file. %s.", err)
+		log.Errorf("Error parsing hook. %s", err)
+		c.String(404, "Remote not found.")
 		return
 	}
 
-	// THIS IS COMPLETELY DUPLICATED IN THE API SECTION. NOT IDEAL
-	var file []byte
-	err = errors.New("MAINTAINERS file")
-	if !config.IgnoreMaintainersFile {
-		file, err = remote.GetContents(c, user, repo, "MAINTAINERS")
-	}
-	if err != nil {
-		log.Debugf("no MAINTAINERS file for %s. Checking for team members.", repo.Slug)
-		members, merr := cache.GetMembers(c, user, repo.Owner)
-		if merr != nil {
-			log.Errorf("Error getting repository %s. %s", repo.Slug, err)
-			log.Errorf("Error getting org members %s. %s", repo.Owner, merr)
-			c.String(404, "MAINTAINERS file not found. %s", err)
+	// hooks delivered to an app carry no repository access token, and
+	// are signed with the app webhook secret instead.
+	var app bool
+	if a, ok := r.(remote.App); ok && len(c.Query("access_token")) == 0 {
+		if err := a.CheckHook(c.Request, payload); err != nil {
+			log.Errorf("Error authorizing hook for %s. %s", host, err)
+			c.String(403, "Invalid or missing access token.")
 			return
 		}
-
-		for _, member := range members {
-			file = append(file, member.Login...)
-			file = append(file, '\n')
-		}
+		app = true
 	}
 
-	maintainer, err := model.ParseMaintainer(file)
+	hook, err := r.GetHook(c, c.Request)
 	if err != nil {
-		log.Errorf("Error parsing MAINTAINERS file for %s. %s", repo.Slug, err)
-		c.String(500, "Error parsing MAINTAINERS file. %s.", err)
+		log.Errorf("Error parsing hook. %s", err)
+		c.String(500, "Error parsing hook. %s", err)
 		return
 	}
-
-	comments, err := remote.GetComments(c, user, repo, hook.Issue.Number)
-	if err != nil {
-		log.Errorf("Error retrieving comments for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
-		c.String(500, "Error retrieving comments. %s.", err)
+	if hook == nil {
+		c.String(200, "pong")
 		return
 	}
-
-	reviews, err := remote.GetReviews(c, user, repo, hook.Issue.Number)
-	if err != nil {
-		log.Errorf("Error retrieving reviews for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
-		c.String(500, "Error retrieving comments. %s.", err)
+	if hook.Install != nil {
+		if !app {
+			log.Errorf("Error authorizing installation hook for %s. Not delivered to the app.", host)
+			c.String(403, "Invalid or missing access token.")
+			return
+		}
+		install(c, host, hook)
 		return
 	}
 
-	approvers := getApprovers(config, maintainer, hook.Issue, comments, reviews)
-	approved := len(approvers) >= config.Approvals
-
-	err = remote.SetStatus(c, user, repo, hook.Issue.Number, len(approvers), config.Approvals)
+	repo, err := store.GetRepoSlug(c, host, hook.Repo.Slug)
 	if err != nil {
-		log.Errorf("Error setting status for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
-		c.String(500, "Error setting status. %s.", err)
+		log.Errorf("Error getting repository %s. %s", hook.Repo.Slug, err)
+		c.String(404, "Repository not found.")
 		return
 	}
 
-	var idx = len(approvers)
-	if idx > 2 {
-		idx = 2
-	}
-
-	oriLabels, err := remote.GetIssueLabels(c, user, repo, hook.Issue.Number)
-	if err != nil {
-		log.Errorf("Error retrieving labels for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
-	}
+	// the hook url is signed with the repository secret when the
+	// repository is activated, and must match the payload repository.
+	if !app {
+		t, err := token.Parse(c.Query("access_token"), func(t *token.Token) (string, error) {
+			return repo.Secret, nil
+		})
+		if err != nil || t.Kind != token.HookToken || t.Text != repo.Slug {
+			log.Errorf("Error authorizing hook for %s. Invalid access token.", repo.Slug)
+			c.String(403, "Invalid or missing access token.")
+			return
+		}
 
-	var hasLabel bool
-	var removeLabels []string
-	for _, label := range oriLabels {
-		if label == labels[idx] {
-			hasLabel = true
-		} else {
-			for _, l := range labels[:idx] {
-				if label == l {
-					removeLabels = append(removeLabels, label)
-					break
-				}
+		// hooks registered before webhook secrets were introduced are
+		// not signed, in which case we rely on the access token alone.
+		if repo.Signed || len(c.GetHeader("X-Hub-Signature-256")) != 0 {
+			if err := token.CheckSignature(c.Request, payload, repo.Secret); err != nil {
+				log.Errorf("Error authorizing hook for %s. %s", repo.Slug, err)
+				c.String(403, "Invalid payload signature.")
+				return
 			}
 		}
 	}
 
-	if len(removeLabels) > 0 {
-		// remove old labels
-		err = remote.RemoveIssueLabels(c, user, repo, hook.Issue.Number, removeLabels)
-		if err != nil {
-			log.Errorf("Error remove old labels for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
+	// the remote system redelivers a hook with the same delivery id,
+	// in which case the original delivery is returned as is.
+	if len(hook.Delivery) != 0 {
+		if delivery, err := store.GetDeliveryGUID(c, hook.Delivery); err == nil {
+			c.JSON(200, delivery)
+			return
 		}
 	}
 
-	if !hasLabel {
-		// add new label
-		err = remote.AddIssueLabels(c, user, repo, hook.Issue.Number, []string{labels[idx]})
-		if err != nil {
-			log.Errorf("Error add new label for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
+	// the hook is persisted and processed asynchronously, so that
+	// failures talking to the remote system can be retried.
+	now := time.Now().Unix()
+	delivery := &model.Delivery{
+		GUID:    hook.Delivery,
+		Event:   hook.Event,
+		Created: now,
+		Updated: now,
+	}
+
+	switch {
+	case hook.Push != nil:
+		// pushes to a protected branch are checked, and pushes to the
+		// default branch may change the approval policy.
+		_, err = queue.EnqueuePush(c, repo, hook.Push, delivery)
+	case hook.Merge != nil:
+		// merges are recorded along with the approval status at the
+		// time of the merge.
+		_, err = queue.EnqueueMerge(c, repo, hook.Merge, delivery)
+	default:
+		// the push of the head commit is recorded when the hook arrives,
+		// since the commit date is set by the pusher.
+		if len(hook.Issue.Head) != 0 {
+			if _, err := engine.Pushed(c, repo, hook.Issue.Number, hook.Issue.Head, time.Unix(now, 0)); err != nil {
+				log.Errorf("Error recording push for %s pr %d. %s", repo.Slug, hook.Issue.Number, err)
+			}
 		}
+		_, err = queue.Enqueue(c, repo, hook.Issue, delivery)
 	}
-
-	log.Debugf("processed comment for %s. received %d of %d approvals", repo.Slug, len(approvers), config.Approvals)
-
-	c.IndentedJSON(200, gin.H{
-		"approvers":   maintainer.People,
-		"settings":    config,
-		"approved":    approved,
-		"approved_by": approvers,
-	})
-}
-
-// getApprovers is a helper function that analyzes the list of comments
-// and returns the list of approvers.
-func getApprovers(config *model.Config, maintainer *model.Maintainer, issue *model.Issue, comments []*model.Comment, reviews []*model.Review) []*model.Person {
-	approverm := map[string]bool{}
-	approvers := []*model.Person{}
-
-	matcher, err := regexp.Compile(config.Pattern)
 	if err != nil {
-		// this should never happen
-		return approvers
+		log.Errorf("Error queueing hook for %s. %s", repo.Slug, err)
+		c.String(500, "Error queueing hook. %s.", err)
+		return
 	}
 
-	for _, comment := range comments {
-		// cannot lgtm your own pull request
-		if config.SelfApprovalOff && comment.Author == issue.Author {
-			continue
-		}
-		// the user must be a valid maintainer of the project
-		person, ok := maintainer.People[comment.Author]
-		if !ok {
-			continue
-		}
-		// the same author can't approve something twice
-		if _, ok := approverm[comment.Author]; ok {
+	c.JSON(202, delivery)
+}
+
+// install is a helper function that activates the repositories added to
+// an app installation, and deactivates the repositories removed from it.
+// The activated repositories have no user, so that the remote acts as
+// the app.
+func install(c *gin.Context, host string, hook *model.Hook) {
+	var activated []*model.Repo
+	for _, repo := range hook.Install.Added {
+		if _, err := store.GetRepoSlug(c, host, repo.Slug); err == nil {
 			continue
 		}
-		// verify the comment matches the approval pattern
-		if matcher.MatchString(comment.Body) {
-			approverm[comment.Author] = true
-			approvers = append(approvers, person)
+		repo.Secret = model.Rand()
+		repo.Remote = host
+
+		// the app receives the hooks of the repository, and the
+		// branch protection is configured on a best effort basis,
+		// since the app may not be allowed to administer the
+		// repository.
+		user := &model.User{Remote: host}
+		link := fmt.Sprintf("%s/hook/%s", httputil.GetURL(c.Request), host)
+		if err := remote.SetHook(c, user, repo, link); err != nil {
+			log.Warnf("Error configuring the status check for %s. %s", repo.Slug, err)
 		}
-	}
 
-	for _, review := range reviews {
-		// cannot lgtm your own pull request
-		if config.SelfApprovalOff && review.Author == issue.Author {
-			continue
+		if err := store.CreateRepo(c, repo); err != nil {
+			log.Errorf("Error activating repository %s. %s", repo.Slug, err)
+			c.String(500, "Error activating repository. %s.", err)
+			return
 		}
-		// the user must be a valid maintainer of the project
+		log.Infof("Activated repository %s installed by %s.", repo.Slug, hook.Install.Sender)
+		activated = append(activated, repo)
+	}
 
-		person, ok := maintainer.People[review.Author]
-		if !ok {
-			continue
-		}
-		// the same author can't approve something twice
-		if _, ok := approverm[review.Author]; ok {
+	for _, removed := range hook.Install.Removed {
+		repo, err := store.GetRepoSlug(c, host, removed.Slug)
+		if err != nil {
 			continue
 		}
-		// verify the comment matches the approval pattern
-		if review.IsApproved() {
-			approverm[review.Author] = true
-			approvers = append(approvers, person)
+		if err := store.DeleteRepo(c, repo); err != nil {
+			log.Errorf("Error deactivating repository %s. %s", repo.Slug, err)
+			c.String(500, "Error deactivating repository. %s.", err)
+			return
 		}
+		log.Infof("Deactivated repository %s uninstalled by %s.", repo.Slug, hook.Install.Sender)
 	}
-
-	return approvers
+	c.JSON(200, activated)
 }
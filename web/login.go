@@ -14,7 +14,7 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// Login attempts to authorize a user via GitHub oauth2. If the user does not
+// Login attempts to authorize a user via the remote oauth2. If the user does not
 // yet exist, and new account is created. Upon successful login the user is
 // redirected to the main screen.
 func Login(c *gin.Context) {
@@ -30,7 +30,27 @@ func Login(c *gin.Context) {
 	// rememver why, so need to revisit this line.
 	c.Writer.Header().Del("Content-Type")
 
-	tmpuser, err := remote.GetUser(c, c.Writer, c.Request)
+	// the remote is chosen on the login page, and remembered while
+	// the user is redirected by the remote provider.
+	host := c.Query("remote")
+	switch {
+	case len(host) != 0:
+		httputil.SetCookie(c.Writer, c.Request, "user_remote", host)
+	case len(c.Query("code")) != 0:
+		host = httputil.GetCookie(c.Request, "user_remote")
+	case len(remote.Hosts(c)) > 1:
+		c.HTML(200, "login.html", gin.H{"remotes": remote.Hosts(c)})
+		return
+	}
+	host = remote.Host(c, host)
+
+	r, err := remote.FromHost(c, host)
+	if err != nil {
+		log.Errorf("cannot authenticate user. %s", err)
+		c.Redirect(303, "/login?error=unknown_remote")
+		return
+	}
+	tmpuser, err := r.GetUser(c, c.Writer, c.Request)
 	if err != nil {
 		log.Errorf("cannot authenticate user. %s", err)
 		c.Redirect(303, "/login?error=oauth_error")
@@ -43,7 +63,7 @@ func Login(c *gin.Context) {
 	}
 
 	// get the user from the database
-	u, err := store.GetUserLogin(c, tmpuser.Login)
+	u, err := store.GetUserLogin(c, host, tmpuser.Login)
 	if err != nil {
 
 		// create the user account
@@ -52,6 +72,7 @@ func Login(c *gin.Context) {
 		u.Token = tmpuser.Token
 		u.Avatar = tmpuser.Avatar
 		u.Secret = model.Rand()
+		u.Remote = host
 
 		// insert the user into the database
 		if err := store.CreateUser(c, u); err != nil {
@@ -74,6 +95,7 @@ func Login(c *gin.Context) {
 
 	exp := time.Now().Add(time.Hour * 72).Unix()
 	token := token.New(token.SessToken, u.Login)
+	token.Remote = u.Remote
 	tokenstr, err := token.SignExpires(u.Secret, exp)
 	if err != nil {
 		log.Errorf("cannot create token for %s. %s", u.Login, err)
@@ -85,22 +107,30 @@ func Login(c *gin.Context) {
 	c.Redirect(303, "/")
 }
 
-// LoginToken authenticates a user with their GitHub token and
-// returns an LGTM API token in the response.
+// LoginToken authenticates a user with their remote token and
+// returns an LGTM API token in the response. The remote query parameter
+// selects the remote, which defaults to the default remote.
 func LoginToken(c *gin.Context) {
 	access := c.Query("access_token")
-	login, err := remote.GetUserToken(c, access)
+	host := remote.Host(c, c.Query("remote"))
+	r, err := remote.FromHost(c, host)
+	if err != nil {
+		c.String(404, "Unable to authenticate user. %s", err)
+		return
+	}
+	login, err := r.GetUserToken(c, access)
 	if err != nil {
 		c.String(403, "Unable to authenticate user. %s", err)
 		return
 	}
-	user, err := store.GetUserLogin(c, login)
+	user, err := store.GetUserLogin(c, host, login)
 	if err != nil {
-		c.String(404, "Unable to authenticate user %s. Not registered.", user.Login)
+		c.String(404, "Unable to authenticate user %s. Not registered.", login)
 		return
 	}
 	exp := time.Now().Add(time.Hour * 72).Unix()
 	token := token.New(token.UserToken, user.Login)
+	token.Remote = user.Remote
 	tokenstr, err := token.SignExpires(user.Secret, exp)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
@@ -3,6 +3,7 @@
 // files/brand.html
 // files/error.html
 // files/index.html
+// files/login.html
 // files/logout.html
 // DO NOT EDIT!
 
@@ -131,6 +132,26 @@ func filesIndexHTML() (*asset, error) {
 	return a, nil
 }
 
+var _filesLoginHTML = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x53\x3d\x8f\xd4\x30\x10\xed\xf9\x15\xc6\x4d\x0a\x2e\xf1\x22\x28\xd0\xc9\xce\x15\x77\x14\x14\x08\x04\x34\x94\xde\x64\x92\x8c\x70\xec\x10\x4f\xf6\x43\xa7\xfb\xef\x4c\xd6\xbb\xca\xb1\xbb\x1c\x2e\x32\x19\xe7\xcd\xbc\x37\x2f\xb6\x7e\xfd\xf0\xe5\xfe\xc7\xcf\xaf\x1f\x45\x47\xbd\x2b\x5f\xe9\x14\x04\x2f\xdd\x81\xad\xd3\xeb\x21\x25\x24\x07\xa5\x56\x29\x2e\xfb\x3d\x90\x15\x55\x67\xc7\x08\x64\xe4\x44\x4d\xfe\x41\x0a\x75\x01\x08\x9e\xc0\x33\x60\x8b\x35\x75\xa6\x86\x0d\x56\x90\x1f\x92\x1b\x81\x1e\x09\xad\xcb\x63\x65\x1d\x98\xb7\x52\x78\xdb\x83\x91\x1b\x84\xed\x10\x46\x7a\xa9\x1d\x82\x81\xba\x05\xc9\xfa\x69\xc8\xe1\xf7\x84\x1b\x23\x77\xf9\x64\xf3\x2a\xf4\x83\x25\x5c\x3b\xf8\xbb\xde\xa1\xff\x25\xba\x11\x1a\x23\x55\x24\x46\x54\xaa\xb1\xac\x26\xf8\x82\x1f\x52\x8c\xe0\xb8\x2d\xa7\x52\xd0\x7e\x60\x19\xd8\xdb\x16\xd4\x2e\x4f\x7b\xd7\x5b\x65\x33\x7d\xbc\x55\xaa\x61\x61\xb1\x68\x43\x68\x1d\xd8\x01\x63\xc1\x32\x54\x15\xe3\x5d\x63\x7b\x74\x7b\xf3\x2d\xac\x03\x85\xdb\xf7\xab\xd5\xcd\xbb\xd5\x2a\x3b\xb0\x65\x91\xf6\x0e\x62\x07\x40\x59\xe2\xcc\x08\x76\x34\x97\x65\xd7\x85\xbf\xc8\x36\xeb\x3c\xd1\x7d\xb6\x04\x23\x5b\xfb\xe6\x13\x6f\xc6\xe3\x70\x0b\xdd\x7f\x9d\x49\xd0\x82\x95\xfc\xbb\x56\xab\xe5\xa4\xe8\x75\xa8\xf7\xcf\x5a\xd6\xb8\x11\x95\xb3\x31\x1a\x39\xff\x32\x8b\x1e\x46\xe1\x42\x1b\x26\x92\x0b\xec\x1c\xda\x43\x8c\xec\xb9\x38\xc6\xbc\x99\x9c\x4b\x67\xe5\xac\x68\x5e\xdf\xb1\xf5\x7c\x82\xc4\x16\xa9\xbb\xf8\xf8\xf8\x28\x46\xeb\xb9\x55\x31\x42\x1f\x08\xa2\x78\x7a\xba\x00\xe9\xa1\xd4\xf6\x34\x38\x8b\x43\x7f\x97\xd0\x86\xcb\x0b\xae\x90\x27\x61\xeb\x89\x28\x78\x91\x42\xce\x43\xb0\x65\x50\xcb\xf2\x88\xd3\xca\xf2\x05\x19\xca\x6b\x32\xc0\xd7\xe7\xdc\x5a\xf1\xcc\xcf\xcc\x5a\x52\xad\x92\x8f\x6c\xed\xe1\x3e\xfe\x01\xd6\x6a\x9c\xe4\xa7\x03\x00\x00")
+
+func filesLoginHTMLBytes() ([]byte, error) {
+	return bindataRead(
+		_filesLoginHTML,
+		"files/login.html",
+	)
+}
+
+func filesLoginHTML() (*asset, error) {
+	bytes, err := filesLoginHTMLBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/login.html", size: 935, mode: os.FileMode(420), modTime: time.Unix(1792323753, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _filesLogoutHTML = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x53\xb1\x92\xd3\x40\x0c\xed\xef\x2b\xc4\x36\x2e\x38\x67\xc3\x40\xc1\xdc\x78\x43\x01\x14\x14\x0c\x0c\x43\x73\xa5\xb2\x96\xed\x1d\xd6\xbb\xc6\x92\x93\xf8\xef\x91\xe3\xbb\x49\x80\xe3\x70\xb1\xb2\xb4\xd2\x7b\x4f\xb2\x5c\xbd\xf8\xf0\xe5\xfd\xf7\xfb\xaf\x1f\xa1\x93\x3e\xee\x6e\xaa\xc5\x40\x6a\x4b\x1c\x06\x67\xf4\x30\x10\x31\xb5\xce\x50\x32\xbb\x1b\xd0\xa7\xea\x08\xeb\xf5\xf5\xec\x4a\x90\x48\xbb\xca\xae\xf6\x12\xdf\x23\x13\x74\x23\x35\xce\x58\x73\x15\xef\x49\x10\x7c\x87\x23\x93\x38\x33\x49\x53\xbe\x35\x60\xff\x4a\xc8\x49\x28\x69\xc2\x31\xd4\xd2\xb9\x9a\x0e\xc1\x53\x79\x76\x6e\x21\xa4\x20\x01\x63\xc9\x1e\x23\xb9\x57\x06\x12\xf6\xe4\xcc\x21\xd0\x71\xc8\xa3\x3c\x07\x17\xc8\x51\xdd\x92\xd1\x76\x65\x28\xe9\xe7\x14\x0e\xce\x9c\xca\x09\x4b\x9f\xfb\x01\x25\xec\x23\xfd\x5e\x1f\x43\xfa\xf1\xd8\x07\x8b\x66\x78\xdb\xa0\xaa\xc9\x69\xa3\x87\x81\x91\xa2\xc2\xaa\x6b\x40\xe6\x41\x65\x84\x1e\x5b\xb2\xa7\x72\x8d\x3d\x0d\x55\x2c\xf4\x7c\x67\x6d\xa3\xc2\x78\xd3\xe6\xdc\x46\xc2\x21\xf0\x46\x65\x58\xcf\xfc\xae\xc1\x3e\xc4\xd9\x7d\xcb\xfb\x2c\xf9\xee\xcd\x76\x7b\xfb\x7a\xbb\x2d\xce\x6c\x05\xcb\x1c\x89\x3b\x22\x29\x56\xce\x42\xe8\x24\x4b\x59\xf1\xb4\xf0\x67\xd9\x16\x9d\x8f\x74\x9f\x51\x68\xd4\xd1\xbe\xfc\xa4\x41\x7e\x68\xee\x42\xf7\xdf\xc9\xac\xa9\x1b\x55\xf2\xef\xda\xca\x5e\x36\xa8\xda\xe7\x7a\xbe\x82\xac\xc3\x01\x7c\x44\x66\x67\x96\x4f\x86\x21\xd1\x08\x31\xb7\x79\x92\xab\x1d\xfa\x33\xb5\x27\x66\x9d\x39\x3c\xd8\xb2\x99\x62\x5c\x77\xc5\xec\xee\xf3\x04\x47\x1a\x09\x78\xf2\x5e\xef\x97\xbb\x79\x81\x6c\xa9\x06\x85\xad\xac\x02\x5d\x29\xb8\xb8\x95\x5d\xc5\xa9\xde\xf3\xaf\xf1\x2b\x00\x00\xff\xff\x16\x92\xe2\x06\x2b\x03\x00\x00")
 
 func filesLogoutHTMLBytes() ([]byte, error) {
@@ -206,6 +227,7 @@ var _bindata = map[string]func() (*asset, error){
 	"files/brand.html":  filesBrandHTML,
 	"files/error.html":  filesErrorHTML,
 	"files/index.html":  filesIndexHTML,
+	"files/login.html":  filesLoginHTML,
 	"files/logout.html": filesLogoutHTML,
 }
 
@@ -254,6 +276,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"brand.html":  &bintree{filesBrandHTML, map[string]*bintree{}},
 		"error.html":  &bintree{filesErrorHTML, map[string]*bintree{}},
 		"index.html":  &bintree{filesIndexHTML, map[string]*bintree{}},
+		"login.html":  &bintree{filesLoginHTML, map[string]*bintree{}},
 		"logout.html": &bintree{filesLogoutHTML, map[string]*bintree{}},
 	}},
 }}